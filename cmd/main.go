@@ -13,6 +13,7 @@ import (
 	"github.com/kenmobility/git-api-service/infra/config"
 	"github.com/kenmobility/git-api-service/infra/database"
 	"github.com/kenmobility/git-api-service/infra/git"
+	"github.com/kenmobility/git-api-service/internal/domain"
 	"github.com/kenmobility/git-api-service/internal/http/handlers"
 	"github.com/kenmobility/git-api-service/internal/http/routes"
 	"github.com/kenmobility/git-api-service/internal/repository/postgres"
@@ -51,14 +52,40 @@ func main() {
 	// Initialize various layers
 	commitRepository := postgres.NewPostgresGitCommitRepository(db)
 	repoMetadataRepository := postgres.NewPostgresGitRepoMetadataRepository(db)
+	backfillRepository := postgres.NewPostgresBackfillRepository(db)
+	syncCursorRepository := postgres.NewPostgresSyncCursorRepository(db)
+	integrityRepository := postgres.NewPostgresIntegrityRepository(db)
+	metadataSnapshotRepository := postgres.NewPostgresMetadataSnapshotRepository(db)
+	stargazerRepository := postgres.NewPostgresStargazerRepository(db)
+	organizationRepository := postgres.NewPostgresOrganizationRepository(db)
+	branchRepository := postgres.NewPostgresBranchRepository(db)
+	releaseRepository := postgres.NewPostgresReleaseRepository(db)
+	pullRequestRepository := postgres.NewPostgresPullRequestRepository(db)
+	issueRepository := postgres.NewPostgresIssueRepository(db)
+	checkRepository := postgres.NewPostgresCheckRepository(db)
+	enrichmentRepository := postgres.NewPostgresEnrichmentRepository(db)
+	fileRepository := postgres.NewPostgresFileRepository(db)
+	contributorRepository := postgres.NewPostgresContributorRepository(db)
 
 	gitClient := git.NewGitHubClient(config.GitHubApiBaseURL, config.GitHubToken, config.FetchInterval)
 
-	gitCommitUsecase := usecases.NewManageGitCommitUsecase(commitRepository, repoMetadataRepository)
-	gitRepositoryUsecase := usecases.NewGitRepositoryUsecase(repoMetadataRepository, commitRepository, gitClient, *config)
+	gitCommitUsecase := usecases.NewManageGitCommitUsecase(commitRepository, repoMetadataRepository, branchRepository, releaseRepository,
+		pullRequestRepository, issueRepository, checkRepository, fileRepository, enrichmentRepository, *config)
+	gitRepositoryUsecase := usecases.NewGitRepositoryUsecase(repoMetadataRepository, commitRepository, backfillRepository,
+		syncCursorRepository, integrityRepository, metadataSnapshotRepository, stargazerRepository, branchRepository,
+		releaseRepository, pullRequestRepository, issueRepository, checkRepository,
+		enrichmentRepository, contributorRepository, gitClient, *config)
+	// Handle graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	organizationUsecase := usecases.NewOrganizationUsecase(ctx, organizationRepository, gitRepositoryUsecase, gitClient, *config)
+	contributorUsecase := usecases.NewContributorUsecase(contributorRepository)
 
 	commitHandler := handlers.NewCommitHandler(gitCommitUsecase)
 	repositoryHandler := handlers.NewRepositoryHandler(gitRepositoryUsecase)
+	organizationHandler := handlers.NewOrganizationHandler(organizationUsecase)
+	contributorHandler := handlers.NewContributorHandler(contributorUsecase)
 
 	//seed default repo
 	err = seedDefaultRepository(config, gitRepositoryUsecase)
@@ -71,19 +98,23 @@ func main() {
 	// register routes
 	routes.CommitRoutes(ginEngine, commitHandler)
 	routes.RepositoryRoutes(ginEngine, repositoryHandler)
+	routes.OrganizationRoutes(ginEngine, organizationHandler)
+	routes.ContributorRoutes(ginEngine, contributorHandler)
 
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%s", config.Address, config.Port),
 		Handler: ginEngine,
 	}
 
-	// Handle graceful shutdown
-	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-	defer stop()
-
 	// Resume repo commits fetching for all saved repositories
 	go gitRepositoryUsecase.ResumeFetching(ctx)
 
+	// Enrich the stored commits with their file changes in the background
+	go gitRepositoryUsecase.StartEnrichment(ctx)
+
+	// Resume watching the organizations and users imported with membership sync
+	go organizationUsecase.ResumeMembershipSync(ctx)
+
 	go func() {
 		for {
 			select {
@@ -108,7 +139,7 @@ func main() {
 
 // seedDefaultRepository seeds a default repository to database
 func seedDefaultRepository(config *config.Config, repositoryUsecase usecases.GitRepositoryUsecase) error {
-	repo, err := repositoryUsecase.StartIndexing(context.Background(), config.DefaultRepository)
+	repo, err := repositoryUsecase.StartIndexing(context.Background(), config.DefaultRepository, domain.TrackingOptions{})
 	if err != nil && err != message.ErrNoRecordFound {
 		return err
 	}
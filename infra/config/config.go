@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -20,6 +23,15 @@ type Config struct {
 	DatabasePassword      string `validate:"required"`
 	DatabaseName          string `validate:"required"`
 	FetchInterval         time.Duration
+	IntegrityInterval     time.Duration
+	MetadataInterval      time.Duration
+	OrgSyncInterval       time.Duration
+	EnrichmentInterval    time.Duration
+	EnrichmentBudget      int
+	OwnershipHalfLife     time.Duration
+	OwnershipMinShare     float64
+	OwnershipMaxOwners    int
+	OwnershipMinChanges   int
 	GitCommitFetchPerPage int
 	GitHubApiBaseURL      string
 	DefaultStartDate      time.Time
@@ -27,6 +39,10 @@ type Config struct {
 	DefaultRepository     string `validate:"required"`
 	Address               string
 	Port                  string
+	// TicketPatterns match the references to external tracker tickets, eg JIRA-789, in commit messages
+	TicketPatterns []*regexp.Regexp
+	// OwnerHandles maps lower cased commit author emails or names to the GitHub handles suggested as code owners
+	OwnerHandles map[string]string
 }
 
 func LoadConfig(path string) (*Config, error) {
@@ -54,6 +70,89 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, err
 	}
 
+	integrityInterval := helpers.Getenv("INTEGRITY_CHECK_INTERVAL", "24h")
+	integrityDuration, err := time.ParseDuration(integrityInterval)
+	if err != nil {
+		log.Error().Msgf("Invalid INTEGRITY_CHECK_INTERVAL :[%s] env format: %v", integrityInterval, err)
+		return nil, err
+	}
+
+	metadataInterval := helpers.Getenv("METADATA_REFRESH_INTERVAL", "6h")
+	metadataDuration, err := time.ParseDuration(metadataInterval)
+	if err != nil {
+		log.Error().Msgf("Invalid METADATA_REFRESH_INTERVAL :[%s] env format: %v", metadataInterval, err)
+		return nil, err
+	}
+
+	orgSyncInterval := helpers.Getenv("ORG_SYNC_INTERVAL", "1h")
+	orgSyncDuration, err := time.ParseDuration(orgSyncInterval)
+	if err != nil {
+		log.Error().Msgf("Invalid ORG_SYNC_INTERVAL :[%s] env format: %v", orgSyncInterval, err)
+		return nil, err
+	}
+
+	enrichmentInterval := helpers.Getenv("ENRICHMENT_INTERVAL", "10m")
+	enrichmentDuration, err := time.ParseDuration(enrichmentInterval)
+	if err != nil {
+		log.Error().Msgf("Invalid ENRICHMENT_INTERVAL :[%s] env format: %v", enrichmentInterval, err)
+		return nil, err
+	}
+
+	enrichmentBudget, err := strconv.Atoi(helpers.Getenv("ENRICHMENT_BUDGET", "500"))
+	if err != nil {
+		log.Error().Msgf("Invalid ENRICHMENT_BUDGET env format: %v", err)
+		return nil, err
+	}
+
+	ownershipHalfLife := helpers.Getenv("OWNERSHIP_HALF_LIFE", "2160h")
+	ownershipHalfLifeDuration, err := time.ParseDuration(ownershipHalfLife)
+	if err != nil {
+		log.Error().Msgf("Invalid OWNERSHIP_HALF_LIFE :[%s] env format: %v", ownershipHalfLife, err)
+		return nil, err
+	}
+
+	ownershipMinShare, err := strconv.ParseFloat(helpers.Getenv("OWNERSHIP_MIN_SHARE", "0.2"), 64)
+	if err != nil {
+		log.Error().Msgf("Invalid OWNERSHIP_MIN_SHARE env format: %v", err)
+		return nil, err
+	}
+
+	ownershipMaxOwners, err := strconv.Atoi(helpers.Getenv("OWNERSHIP_MAX_OWNERS", "3"))
+	if err != nil {
+		log.Error().Msgf("Invalid OWNERSHIP_MAX_OWNERS env format: %v", err)
+		return nil, err
+	}
+
+	ownershipMinChanges, err := strconv.Atoi(helpers.Getenv("OWNERSHIP_MIN_CHANGES", "5"))
+	if err != nil {
+		log.Error().Msgf("Invalid OWNERSHIP_MIN_CHANGES env format: %v", err)
+		return nil, err
+	}
+
+	// OWNER_HANDLES is a comma separated list of author=handle pairs, the author being a name or an email, eg Jane Doe=janedoe,jroe@example.com=@jroe
+	ownerHandles := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv("OWNER_HANDLES"), ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		author, handle, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(author) == "" || strings.TrimSpace(handle) == "" {
+			log.Error().Msgf("Invalid OWNER_HANDLES pair :[%s], it must be author=handle", pair)
+			return nil, fmt.Errorf("invalid OWNER_HANDLES pair %q, it must be author=handle", pair)
+		}
+		ownerHandles[strings.ToLower(strings.TrimSpace(author))] = strings.TrimSpace(handle)
+	}
+
+	var ticketPatterns []*regexp.Regexp
+	for _, pattern := range strings.Fields(os.Getenv("TICKET_PATTERNS")) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			log.Error().Msgf("Invalid TICKET_PATTERNS pattern :[%s]: %v", pattern, err)
+			return nil, err
+		}
+		ticketPatterns = append(ticketPatterns, re)
+	}
+
 	var sDate time.Time
 	var eDate time.Time
 
@@ -75,10 +174,9 @@ func LoadConfig(path string) (*Config, error) {
 		log.Error().Msgf("Invalid GIT_COMMIT_FETCH_PER_PAGE [%s] env format passed, setting to 50: %v", perPage, err)
 	}
 
+	// an empty end date leaves the tracking window open ended so that indexing keeps up with new commits
 	endDate := os.Getenv("DEFAULT_END_DATE")
-	if endDate == "" {
-		eDate = time.Now()
-	} else {
+	if endDate != "" {
 		eDate, err = time.Parse(time.RFC3339, endDate)
 		if err != nil {
 			log.Error().Msgf("Invalid DEFAULT_END_DATE [%s] env format: %v", endDate, err)
@@ -95,6 +193,17 @@ func LoadConfig(path string) (*Config, error) {
 		DatabaseName:          os.Getenv("DATABASE_NAME"),
 		DatabasePassword:      os.Getenv("DATABASE_PASSWORD"),
 		FetchInterval:         intervalDuration,
+		IntegrityInterval:     integrityDuration,
+		MetadataInterval:      metadataDuration,
+		OrgSyncInterval:       orgSyncDuration,
+		EnrichmentInterval:    enrichmentDuration,
+		EnrichmentBudget:      enrichmentBudget,
+		OwnershipHalfLife:     ownershipHalfLifeDuration,
+		OwnershipMinShare:     ownershipMinShare,
+		OwnershipMaxOwners:    ownershipMaxOwners,
+		OwnershipMinChanges:   ownershipMinChanges,
+		TicketPatterns:        ticketPatterns,
+		OwnerHandles:          ownerHandles,
 		DefaultStartDate:      sDate,
 		DefaultEndDate:        eDate,
 		GitCommitFetchPerPage: commitPerPage,
@@ -129,5 +129,17 @@ func TestLoadConfigDefaultValues(t *testing.T) {
 
 	// Check if default values are applied
 	assert.Equal(t, time.Hour, cfg.FetchInterval)
+	assert.Equal(t, 24*time.Hour, cfg.IntegrityInterval)
+	assert.Equal(t, 6*time.Hour, cfg.MetadataInterval)
+	assert.Equal(t, time.Hour, cfg.OrgSyncInterval)
+	assert.Empty(t, cfg.TicketPatterns)
+	assert.Equal(t, 10*time.Minute, cfg.EnrichmentInterval)
+	assert.Equal(t, 500, cfg.EnrichmentBudget)
+	assert.Equal(t, 90*24*time.Hour, cfg.OwnershipHalfLife)
+	assert.Equal(t, 0.2, cfg.OwnershipMinShare)
+	assert.Equal(t, 3, cfg.OwnershipMaxOwners)
+	assert.Equal(t, 5, cfg.OwnershipMinChanges)
+	assert.Empty(t, cfg.OwnerHandles)
 	assert.Equal(t, "chromium/chromium", cfg.DefaultRepository)
+	assert.True(t, cfg.DefaultEndDate.IsZero())
 }
@@ -12,8 +12,9 @@ import (
 )
 
 type PostgresDatabase struct {
-	DSN string
-	db  *gorm.DB
+	DSN    string
+	config config.Config
+	db     *gorm.DB
 }
 
 func NewPostgresDatabase(config config.Config) Database {
@@ -30,7 +31,7 @@ func NewPostgresDatabase(config config.Config) Database {
 		conString += " sslmode=disable"
 	}
 
-	return &PostgresDatabase{DSN: conString}
+	return &PostgresDatabase{DSN: conString, config: config}
 }
 
 // ConnectDb establishes a postgreSQL database connection or error if not successful
@@ -50,5 +51,26 @@ func (p *PostgresDatabase) ConnectDb() (*gorm.DB, error) {
 // Migrate does db schema migration for PostgreSQL
 func (p *PostgresDatabase) Migrate() error {
 	// Migrate the schema for PostgreSQL
-	return p.db.AutoMigrate(&postgreSQL.Repository{}, &postgreSQL.Commit{})
+	err := p.db.AutoMigrate(&postgreSQL.Repository{}, &postgreSQL.RepositoryAlias{}, &postgreSQL.Commit{}, &postgreSQL.RepositoryCommit{}, &postgreSQL.ArchivedCommit{},
+		&postgreSQL.SyncRange{}, &postgreSQL.BackfillJob{}, &postgreSQL.ReindexJob{}, &postgreSQL.SyncCursor{}, &postgreSQL.IntegrityReport{}, &postgreSQL.MetadataSnapshot{}, &postgreSQL.Stargazer{}, &postgreSQL.OrgMembership{}, &postgreSQL.OrgImport{}, &postgreSQL.OrgImportResult{}, &postgreSQL.UntrackedRepository{},
+		&postgreSQL.Branch{}, &postgreSQL.BranchCommit{}, &postgreSQL.Tag{}, &postgreSQL.Release{}, &postgreSQL.PullRequest{}, &postgreSQL.PullRequestCommit{}, &postgreSQL.PullRequestSync{}, &postgreSQL.Issue{}, &postgreSQL.CommitReference{}, &postgreSQL.TicketPatternSet{}, &postgreSQL.CheckRun{}, &postgreSQL.CommitFile{}, &postgreSQL.EnrichmentState{},
+		&postgreSQL.Contributor{}, &postgreSQL.ContributorIdentity{})
+	if err != nil {
+		return err
+	}
+
+	if err := postgreSQL.MigrateRepositoryCommits(p.db); err != nil {
+		return err
+	}
+
+	if err := postgreSQL.MigrateTrackingSettings(p.db, p.config.DefaultStartDate, p.config.DefaultEndDate,
+		p.config.FetchInterval, p.config.GitCommitFetchPerPage); err != nil {
+		return err
+	}
+
+	if err := postgreSQL.MigrateTicketPatterns(p.db, p.config.TicketPatterns); err != nil {
+		return err
+	}
+
+	return postgreSQL.MigrateCommitIdentities(p.db)
 }
@@ -9,5 +9,35 @@ import (
 
 type GitManagerClient interface {
 	FetchRepoMetadata(ctx context.Context, repositoryName string) (*domain.RepoMetadata, error)
-	FetchCommits(ctx context.Context, repo domain.RepoMetadata, since time.Time, until time.Time, lastFetchedCommit string, page, perPage int) ([]domain.Commit, bool, error)
+	FetchRepoMetadataByID(ctx context.Context, providerID int64) (*domain.RepoMetadata, error)
+	// FetchCommits lists the commits reachable from headSHA, or from the tracked branch of the repository
+	// when headSHA is empty, newest first
+	FetchCommits(ctx context.Context, repo domain.RepoMetadata, since time.Time, until time.Time, headSHA string, page, perPage int) ([]domain.Commit, bool, error)
+	CountCommits(ctx context.Context, repo domain.RepoMetadata, since time.Time, until time.Time) (int, error)
+	// FetchStargazers lists the users who starred a repository with the date of their star, oldest first
+	FetchStargazers(ctx context.Context, repo domain.RepoMetadata, page, perPage int) ([]domain.Stargazer, bool, error)
+	// FetchBranches lists the branches of a repository with their head commit
+	FetchBranches(ctx context.Context, repo domain.RepoMetadata, page, perPage int) ([]domain.Branch, bool, error)
+	// FetchTags lists the tags of a repository with the commit they point to
+	FetchTags(ctx context.Context, repo domain.RepoMetadata, page, perPage int) ([]domain.Tag, bool, error)
+	// FetchReleases lists the releases of a repository, newest first
+	FetchReleases(ctx context.Context, repo domain.RepoMetadata, page, perPage int) ([]domain.Release, bool, error)
+	// FetchPullRequests lists the pull requests of a repository in every state, most recently updated first
+	FetchPullRequests(ctx context.Context, repo domain.RepoMetadata, page, perPage int) ([]domain.PullRequest, bool, error)
+	// FetchPullRequestCommits lists the shas of the commits of a pull request, at most 250 commits are listed
+	FetchPullRequestCommits(ctx context.Context, repo domain.RepoMetadata, number int, page, perPage int) ([]string, bool, error)
+	CountPullRequestReviews(ctx context.Context, repo domain.RepoMetadata, number int) (int, error)
+	// FetchIssues lists the issues of a repository in every state updated since the given date, most recently updated first
+	FetchIssues(ctx context.Context, repo domain.RepoMetadata, since time.Time, page, perPage int) ([]domain.Issue, bool, error)
+	// FetchCommitDetail fetches the stats of a commit and a page of its changed files
+	FetchCommitDetail(ctx context.Context, repo domain.RepoMetadata, sha string, page, perPage int) (*domain.CommitDetail, bool, error)
+	// FetchCommitStatuses lists the statuses set on a commit, every state a status context was set to is a run
+	FetchCommitStatuses(ctx context.Context, repo domain.RepoMetadata, sha string, page, perPage int) ([]domain.CheckRun, bool, error)
+	// FetchCheckRuns lists the check runs of a commit, re-runs included
+	FetchCheckRuns(ctx context.Context, repo domain.RepoMetadata, sha string, page, perPage int) ([]domain.CheckRun, bool, error)
+	// FetchMailmap fetches the .mailmap file of a repository, empty when it has none and nil when it is unchanged since etag
+	FetchMailmap(ctx context.Context, repo domain.RepoMetadata, etag string) (*domain.Mailmap, error)
+	// FetchOwnerRepos lists the repositories of an organization or user
+	FetchOwnerRepos(ctx context.Context, owner string, page, perPage int) ([]domain.OwnerRepo, bool, error)
+	FetchRateLimit(ctx context.Context) (*domain.RateLimit, error)
 }
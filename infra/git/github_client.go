@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -54,9 +55,17 @@ func NewGitHubClient(baseUrl string, token string, fetchInterval time.Duration)
 }
 
 func (g *GitHubClient) FetchRepoMetadata(ctx context.Context, repositoryName string) (*domain.RepoMetadata, error) {
-	endpoint := fmt.Sprintf("%s/repos/%s", g.baseURL, repositoryName)
+	return g.fetchRepoMetadata(ctx, fmt.Sprintf("%s/repos/%s", g.baseURL, repositoryName))
+}
+
+// FetchRepoMetadataByID fetches the metadata of a repository by its provider id, which resolves
+// to the current name of a renamed or transferred repository
+func (g *GitHubClient) FetchRepoMetadataByID(ctx context.Context, providerID int64) (*domain.RepoMetadata, error) {
+	return g.fetchRepoMetadata(ctx, fmt.Sprintf("%s/repositories/%d", g.baseURL, providerID))
+}
 
-	resp, err := g.client.Get(endpoint)
+func (g *GitHubClient) fetchRepoMetadata(ctx context.Context, endpoint string) (*domain.RepoMetadata, error) {
+	resp, err := g.client.Get(endpoint, map[string]string{}, g.getHeaders())
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +75,11 @@ func (g *GitHubClient) FetchRepoMetadata(ctx context.Context, repositoryName str
 		return nil, message.ErrRateLimitExceeded
 	}
 
+	if isGone(resp.StatusCode) {
+		log.Error().Msgf("repository not found upstream; status code: %v, body: %v", resp.StatusCode, resp.Body)
+		return nil, message.ErrRepoGone
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		log.Error().Msgf("failed to fetch repository meta data; status code: %v, body: %v", resp.StatusCode, resp.Body)
 		return nil, message.ErrRepoMetaDataNotFetched
@@ -78,7 +92,13 @@ func (g *GitHubClient) FetchRepoMetadata(ctx context.Context, repositoryName str
 		return nil, errors.New("could not unmarshal repo metadata response")
 	}
 
+	return repoMetadataFromResponse(gitHubRepoResponse), nil
+}
+
+// repoMetadataFromResponse maps a GitHub repository to its domain metadata
+func repoMetadataFromResponse(gitHubRepoResponse GitHubRepoMetadataResponse) *domain.RepoMetadata {
 	repoMetadata := &domain.RepoMetadata{
+		ProviderID:      gitHubRepoResponse.Id,
 		Name:            gitHubRepoResponse.FullName,
 		Description:     gitHubRepoResponse.Description,
 		URL:             gitHubRepoResponse.Url,
@@ -87,21 +107,99 @@ func (g *GitHubClient) FetchRepoMetadata(ctx context.Context, repositoryName str
 		StarsCount:      gitHubRepoResponse.StargazersCount,
 		OpenIssuesCount: gitHubRepoResponse.OpenIssues,
 		WatchersCount:   gitHubRepoResponse.WatchersCount,
+		Archived:        gitHubRepoResponse.Archived,
+		Disabled:        gitHubRepoResponse.Disabled,
+		Visibility:      gitHubRepoResponse.Visibility,
+		PushedAt:        gitHubRepoResponse.PushedAt,
+		DefaultBranch:   gitHubRepoResponse.DefaultBranch,
 	}
+	repoMetadata.UpstreamState = domain.UpstreamStateOf(*repoMetadata)
 
-	return repoMetadata, nil
+	return repoMetadata
 }
 
-func (g *GitHubClient) FetchCommits(ctx context.Context, repo domain.RepoMetadata, since time.Time, until time.Time, lastFetchedCommit string, page, perPage int) ([]domain.Commit, bool, error) {
-	var endpoint string
+// FetchOwnerRepos lists the repositories of an organization, or of a user when no organization has the name
+func (g *GitHubClient) FetchOwnerRepos(ctx context.Context, owner string, page, perPage int) ([]domain.OwnerRepo, bool, error) {
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
 
-	if lastFetchedCommit != "" {
-		endpoint = fmt.Sprintf("%s/repos/%s/commits?sha=%s&per_page=%d&page=%d", g.baseURL, repo.Name, lastFetchedCommit, perPage, page)
-	} else {
-		endpoint = fmt.Sprintf("%s/repos/%s/commits?since=%s&until=%s&per_page=%d&page=%d", g.baseURL, repo.Name, since.Format(time.RFC3339), until.Format(time.RFC3339), perPage, page)
+	response, err := g.client.Get(fmt.Sprintf("%s/orgs/%s/repos", g.baseURL, owner), queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching repositories of %s: %v", owner, err)
+		return nil, false, err
 	}
 
-	response, err := g.client.Get(endpoint, map[string]string{}, g.getHeaders())
+	if response.StatusCode == http.StatusNotFound {
+		response, err = g.client.Get(fmt.Sprintf("%s/users/%s/repos", g.baseURL, owner), queryParams, g.getHeaders())
+		if err != nil {
+			log.Error().Msgf("error fetching repositories of %s: %v", owner, err)
+			return nil, false, err
+		}
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch repositories of %s; status code: %v, body: %v", owner, response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, false, message.ErrOwnerNotFound
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch repositories of %s; status code: %v, body: %v", owner, response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch repositories of %s; status code: %v, body: %v", owner, response.StatusCode, response.Body)
+	}
+
+	var repoRes []GitHubRepoMetadataResponse
+	if err := json.Unmarshal([]byte(response.Body), &repoRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal repositories response")
+	}
+
+	repos := make([]domain.OwnerRepo, 0, len(repoRes))
+	for _, rr := range repoRes {
+		repos = append(repos, domain.OwnerRepo{
+			Metadata: *repoMetadataFromResponse(rr),
+			Fork:     rr.Fork,
+			Topics:   rr.Topics,
+		})
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return repos, morePages, nil
+}
+
+func (g *GitHubClient) FetchCommits(ctx context.Context, repo domain.RepoMetadata, since time.Time, until time.Time, headSHA string, page, perPage int) ([]domain.Commit, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/commits", g.baseURL, repo.Name)
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+
+	// pinning the head sha keeps pages stable while new commits are pushed
+	if headSHA != "" {
+		queryParams["sha"] = headSHA
+	} else if repo.Branch != "" {
+		queryParams["sha"] = repo.Branch
+	}
+	if !since.IsZero() {
+		queryParams["since"] = since.Format(time.RFC3339)
+	}
+	if !until.IsZero() {
+		queryParams["until"] = until.Format(time.RFC3339)
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
 	if err != nil {
 		log.Error().Msgf("error fetching commits: %v", err)
 
@@ -115,6 +213,11 @@ func (g *GitHubClient) FetchCommits(ctx context.Context, repo domain.RepoMetadat
 
 	g.updateRateLimitHeaders(response)
 
+	if isGone(response.StatusCode) {
+		log.Error().Msgf("commits of repository %s not found upstream; status code: %v", repo.Name, response.StatusCode)
+		return nil, false, g.refNotFound(ctx, repo, response.StatusCode)
+	}
+
 	if g.rateLimitFields.rateLimitRemaining == 0 {
 		waitTime := time.Until(time.Unix(int64(g.rateLimitFields.rateLimitReset), 0))
 		log.Info().Msgf("Rate limit exceeded. Waiting for %v until reset...", waitTime)
@@ -135,13 +238,30 @@ func (g *GitHubClient) FetchCommits(ctx context.Context, repo domain.RepoMetadat
 
 	var cc []domain.Commit
 	for _, cr := range commitRes {
+		parentSHAs := make([]string, 0, len(cr.Parents))
+		for _, p := range cr.Parents {
+			parentSHAs = append(parentSHAs, p.SHA)
+		}
+
 		commit := domain.Commit{
 			CommitID:       cr.SHA,
 			Message:        cr.Commit.Message,
 			Author:         cr.Commit.Author.Name,
+			AuthorEmail:    cr.Commit.Author.Email,
 			Date:           cr.Commit.Author.Date,
+			Committer:      cr.Commit.Committer.Name,
+			CommitterEmail: cr.Commit.Committer.Email,
+			CommittedAt:    cr.Commit.Committer.Date,
 			URL:            cr.HtmlURL,
+			RepositoryID:   repo.ID,
 			RepositoryName: repo.Name,
+			ParentSHAs:     parentSHAs,
+		}
+		if cr.Author != nil {
+			commit.AuthorLogin = cr.Author.Login
+		}
+		if cr.Committer != nil {
+			commit.CommitterLogin = cr.Committer.Login
 		}
 
 		cc = append(cc, commit)
@@ -157,6 +277,816 @@ func (g *GitHubClient) FetchCommits(ctx context.Context, repo domain.RepoMetadat
 	return cc, morePages, nil
 }
 
+// CountCommits counts the commits of a repository within a date range by requesting a single commit per page,
+// the page number of the 'last' relation of the Link header is then the number of commits
+func (g *GitHubClient) CountCommits(ctx context.Context, repo domain.RepoMetadata, since time.Time, until time.Time) (int, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/commits", g.baseURL, repo.Name)
+	queryParams := map[string]string{
+		"per_page": "1",
+	}
+	if repo.Branch != "" {
+		queryParams["sha"] = repo.Branch
+	}
+	if !since.IsZero() {
+		queryParams["since"] = since.Format(time.RFC3339)
+	}
+	if !until.IsZero() {
+		queryParams["until"] = until.Format(time.RFC3339)
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error counting commits: %v", err)
+		return 0, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to count commits; status code: %v, body: %v", response.StatusCode, response.Body)
+		return 0, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	// an empty repository responds with 409 Conflict
+	if response.StatusCode == http.StatusConflict {
+		return 0, nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to count commits; status code: %v, body: %v", response.StatusCode, response.Body)
+		return 0, fmt.Errorf("failed to count commits; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		if last, ok := g.lastPage(linkHeader[0]); ok {
+			return last, nil
+		}
+	}
+
+	var commitRes []GithubCommitResponse
+	if err := json.Unmarshal([]byte(response.Body), &commitRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return 0, errors.New("could not unmarshal commits response")
+	}
+
+	return len(commitRes), nil
+}
+
+// FetchStargazers lists the users who starred a repository, oldest star first, the star+json media type
+// adds the date each star was given
+func (g *GitHubClient) FetchStargazers(ctx context.Context, repo domain.RepoMetadata, page, perPage int) ([]domain.Stargazer, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/stargazers", g.baseURL, repo.Name)
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+
+	headers := g.getHeaders()
+	headers["Accept"] = "application/vnd.github.star+json"
+
+	response, err := g.client.Get(endpoint, queryParams, headers)
+	if err != nil {
+		log.Error().Msgf("error fetching stargazers: %v", err)
+		return nil, false, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch stargazers; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch stargazers; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch stargazers; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var stargazerRes []GitHubStargazerResponse
+	if err := json.Unmarshal([]byte(response.Body), &stargazerRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal stargazers response")
+	}
+
+	stargazers := make([]domain.Stargazer, 0, len(stargazerRes))
+	for _, sr := range stargazerRes {
+		stargazers = append(stargazers, domain.Stargazer{
+			RepositoryID: repo.ID,
+			Login:        sr.User.Login,
+			StarredAt:    sr.StarredAt,
+		})
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return stargazers, morePages, nil
+}
+
+// FetchBranches lists the branches of a repository with their head commit
+func (g *GitHubClient) FetchBranches(ctx context.Context, repo domain.RepoMetadata, page, perPage int) ([]domain.Branch, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/branches", g.baseURL, repo.Name)
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching branches: %v", err)
+		return nil, false, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch branches; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if isGone(response.StatusCode) {
+		return nil, false, message.ErrRepoGone
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch branches; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch branches; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var branchRes []GitHubBranchResponse
+	if err := json.Unmarshal([]byte(response.Body), &branchRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal branches response")
+	}
+
+	branches := make([]domain.Branch, 0, len(branchRes))
+	for _, br := range branchRes {
+		branches = append(branches, domain.Branch{
+			RepositoryID: repo.ID,
+			Name:         br.Name,
+			HeadSHA:      br.Commit.SHA,
+			IsDefault:    br.Name == repo.DefaultBranch,
+		})
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return branches, morePages, nil
+}
+
+// FetchTags lists the tags of a repository
+func (g *GitHubClient) FetchTags(ctx context.Context, repo domain.RepoMetadata, page, perPage int) ([]domain.Tag, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/tags", g.baseURL, repo.Name)
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching tags: %v", err)
+		return nil, false, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch tags; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if isGone(response.StatusCode) {
+		return nil, false, message.ErrRepoGone
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch tags; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch tags; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var tagRes []GitHubTagResponse
+	if err := json.Unmarshal([]byte(response.Body), &tagRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal tags response")
+	}
+
+	tags := make([]domain.Tag, 0, len(tagRes))
+	for _, tr := range tagRes {
+		tags = append(tags, domain.Tag{
+			RepositoryID: repo.ID,
+			Name:         tr.Name,
+			SHA:          tr.Commit.SHA,
+		})
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return tags, morePages, nil
+}
+
+// FetchReleases lists the releases of a repository, drafts are only listed with a token allowed to push to it
+func (g *GitHubClient) FetchReleases(ctx context.Context, repo domain.RepoMetadata, page, perPage int) ([]domain.Release, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/releases", g.baseURL, repo.Name)
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching releases: %v", err)
+		return nil, false, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch releases; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if isGone(response.StatusCode) {
+		return nil, false, message.ErrRepoGone
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch releases; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch releases; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var releaseRes []GitHubReleaseResponse
+	if err := json.Unmarshal([]byte(response.Body), &releaseRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal releases response")
+	}
+
+	releases := make([]domain.Release, 0, len(releaseRes))
+	for _, rr := range releaseRes {
+		releases = append(releases, domain.Release{
+			RepositoryID: repo.ID,
+			Tag:          rr.TagName,
+			Name:         rr.Name,
+			Notes:        rr.Body,
+			Prerelease:   rr.Prerelease,
+			Draft:        rr.Draft,
+			PublishedAt:  rr.PublishedAt,
+		})
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return releases, morePages, nil
+}
+
+// FetchPullRequests lists the pull requests of a repository in every state, most recently updated first
+func (g *GitHubClient) FetchPullRequests(ctx context.Context, repo domain.RepoMetadata, page, perPage int) ([]domain.PullRequest, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/pulls", g.baseURL, repo.Name)
+	queryParams := map[string]string{
+		"state":     "all",
+		"sort":      "updated",
+		"direction": "desc",
+		"per_page":  strconv.Itoa(perPage),
+		"page":      strconv.Itoa(page),
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching pull requests: %v", err)
+		return nil, false, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch pull requests; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if isGone(response.StatusCode) {
+		return nil, false, message.ErrRepoGone
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch pull requests; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch pull requests; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var pullRes []GitHubPullRequestResponse
+	if err := json.Unmarshal([]byte(response.Body), &pullRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal pull requests response")
+	}
+
+	pullRequests := make([]domain.PullRequest, 0, len(pullRes))
+	for _, pr := range pullRes {
+		labels := make([]string, 0, len(pr.Labels))
+		for _, l := range pr.Labels {
+			labels = append(labels, l.Name)
+		}
+		pullRequests = append(pullRequests, domain.PullRequest{
+			RepositoryID:   repo.ID,
+			Number:         pr.Number,
+			Title:          pr.Title,
+			Author:         pr.User.Login,
+			State:          domain.PullRequestState(pr.State, pr.MergedAt),
+			BaseRef:        pr.Base.Ref,
+			HeadRef:        pr.Head.Ref,
+			MergeCommitSHA: pr.MergeCommitSHA,
+			Labels:         labels,
+			URL:            pr.HtmlUrl,
+			CreatedAt:      pr.CreatedAt,
+			UpdatedAt:      pr.UpdatedAt,
+			MergedAt:       pr.MergedAt,
+			ClosedAt:       pr.ClosedAt,
+		})
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return pullRequests, morePages, nil
+}
+
+// FetchPullRequestCommits lists the shas of the commits of a pull request, oldest first
+func (g *GitHubClient) FetchPullRequestCommits(ctx context.Context, repo domain.RepoMetadata, number int, page, perPage int) ([]string, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/pulls/%d/commits", g.baseURL, repo.Name, number)
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching pull request commits: %v", err)
+		return nil, false, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch pull request commits; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch pull request commits; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch pull request commits; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var commitRes []GithubCommitResponse
+	if err := json.Unmarshal([]byte(response.Body), &commitRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal pull request commits response")
+	}
+
+	shas := make([]string, 0, len(commitRes))
+	for _, c := range commitRes {
+		shas = append(shas, c.SHA)
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return shas, morePages, nil
+}
+
+// CountPullRequestReviews counts the reviews of a pull request from the last page of a single review per page listing
+func (g *GitHubClient) CountPullRequestReviews(ctx context.Context, repo domain.RepoMetadata, number int) (int, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/pulls/%d/reviews", g.baseURL, repo.Name, number)
+	queryParams := map[string]string{
+		"per_page": "1",
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error counting pull request reviews: %v", err)
+		return 0, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to count pull request reviews; status code: %v, body: %v", response.StatusCode, response.Body)
+		return 0, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to count pull request reviews; status code: %v, body: %v", response.StatusCode, response.Body)
+		return 0, fmt.Errorf("failed to count pull request reviews; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		if last, ok := g.lastPage(linkHeader[0]); ok {
+			return last, nil
+		}
+	}
+
+	var reviewRes []json.RawMessage
+	if err := json.Unmarshal([]byte(response.Body), &reviewRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return 0, errors.New("could not unmarshal pull request reviews response")
+	}
+	return len(reviewRes), nil
+}
+
+// FetchIssues lists the issues of a repository in every state updated since the given date, most recently updated
+// first, the pull requests the issues endpoint also lists are skipped
+func (g *GitHubClient) FetchIssues(ctx context.Context, repo domain.RepoMetadata, since time.Time, page, perPage int) ([]domain.Issue, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/issues", g.baseURL, repo.Name)
+	queryParams := map[string]string{
+		"state":     "all",
+		"sort":      "updated",
+		"direction": "desc",
+		"per_page":  strconv.Itoa(perPage),
+		"page":      strconv.Itoa(page),
+	}
+	if !since.IsZero() {
+		queryParams["since"] = since.Format(time.RFC3339)
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching issues: %v", err)
+		return nil, false, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch issues; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if isGone(response.StatusCode) {
+		return nil, false, message.ErrRepoGone
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch issues; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch issues; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var issueRes []GitHubIssueResponse
+	if err := json.Unmarshal([]byte(response.Body), &issueRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal issues response")
+	}
+
+	issues := make([]domain.Issue, 0, len(issueRes))
+	for _, is := range issueRes {
+		if is.PullRequest != nil {
+			continue
+		}
+		labels := make([]string, 0, len(is.Labels))
+		for _, l := range is.Labels {
+			labels = append(labels, l.Name)
+		}
+		assignees := make([]string, 0, len(is.Assignees))
+		for _, a := range is.Assignees {
+			assignees = append(assignees, a.Login)
+		}
+		issues = append(issues, domain.Issue{
+			RepositoryID: repo.ID,
+			Number:       is.Number,
+			Title:        is.Title,
+			Author:       is.User.Login,
+			State:        is.State,
+			Labels:       labels,
+			Assignees:    assignees,
+			URL:          is.HtmlUrl,
+			CreatedAt:    is.CreatedAt,
+			UpdatedAt:    is.UpdatedAt,
+			ClosedAt:     is.ClosedAt,
+		})
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return issues, morePages, nil
+}
+
+// FetchCommitStatuses lists the statuses set on a commit, newest first, every state a status context was set to is listed
+func (g *GitHubClient) FetchCommitStatuses(ctx context.Context, repo domain.RepoMetadata, sha string, page, perPage int) ([]domain.CheckRun, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/commits/%s/statuses", g.baseURL, repo.Name, sha)
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching commit statuses: %v", err)
+		return nil, false, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch commit statuses; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if isGone(response.StatusCode) {
+		return nil, false, g.refNotFound(ctx, repo, response.StatusCode)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch commit statuses; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch commit statuses; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var statusRes []GitHubCommitStatusResponse
+	if err := json.Unmarshal([]byte(response.Body), &statusRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal commit statuses response")
+	}
+
+	runs := make([]domain.CheckRun, 0, len(statusRes))
+	for _, st := range statusRes {
+		createdAt := st.CreatedAt
+		run := domain.CheckRun{
+			RepositoryID: repo.ID,
+			CommitID:     sha,
+			Kind:         domain.CheckKindStatus,
+			ExternalID:   st.ID,
+			Name:         st.Context,
+			URL:          st.TargetUrl,
+			StartedAt:    &createdAt,
+		}
+		// a status is set to a state at once, only the pending state is not final
+		if st.State != "pending" {
+			run.Conclusion = st.State
+			run.CompletedAt = &createdAt
+		}
+		runs = append(runs, run)
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return runs, morePages, nil
+}
+
+// FetchCheckRuns lists the check runs of a commit, re-runs included
+func (g *GitHubClient) FetchCheckRuns(ctx context.Context, repo domain.RepoMetadata, sha string, page, perPage int) ([]domain.CheckRun, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/commits/%s/check-runs", g.baseURL, repo.Name, sha)
+	queryParams := map[string]string{
+		"filter":   "all",
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching check runs: %v", err)
+		return nil, false, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch check runs; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if isGone(response.StatusCode) {
+		return nil, false, g.refNotFound(ctx, repo, response.StatusCode)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch check runs; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch check runs; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var checkRes GitHubCheckRunsResponse
+	if err := json.Unmarshal([]byte(response.Body), &checkRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal check runs response")
+	}
+
+	runs := make([]domain.CheckRun, 0, len(checkRes.CheckRuns))
+	for _, cr := range checkRes.CheckRuns {
+		run := domain.CheckRun{
+			RepositoryID: repo.ID,
+			CommitID:     sha,
+			Kind:         domain.CheckKindCheckRun,
+			ExternalID:   cr.ID,
+			Name:         cr.Name,
+			URL:          cr.HtmlUrl,
+			StartedAt:    cr.StartedAt,
+			CompletedAt:  cr.CompletedAt,
+		}
+		if cr.Status == "completed" {
+			run.Conclusion = cr.Conclusion
+		}
+		runs = append(runs, run)
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return runs, morePages, nil
+}
+
+// FetchCommitDetail fetches the stats of a commit and a page of its changed files, at most 3000 files are listed
+func (g *GitHubClient) FetchCommitDetail(ctx context.Context, repo domain.RepoMetadata, sha string, page, perPage int) (*domain.CommitDetail, bool, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/commits/%s", g.baseURL, repo.Name, sha)
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+
+	response, err := g.client.Get(endpoint, queryParams, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching commit detail: %v", err)
+		return nil, false, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch commit detail; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if isGone(response.StatusCode) {
+		return nil, false, g.refNotFound(ctx, repo, response.StatusCode)
+	}
+
+	// the detail of a commit whose diff is too large to be computed is never served
+	if response.StatusCode == http.StatusUnprocessableEntity {
+		log.Error().Msgf("failed to fetch commit detail; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, message.ErrCommitDetailUnavailable
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch commit detail; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, false, fmt.Errorf("failed to fetch commit detail; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var detailRes GithubCommitDetailResponse
+	if err := json.Unmarshal([]byte(response.Body), &detailRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, false, errors.New("could not unmarshal commit detail response")
+	}
+
+	detail := domain.CommitDetail{
+		Stats: domain.CommitStats{
+			Additions: detailRes.Stats.Additions,
+			Deletions: detailRes.Stats.Deletions,
+		},
+		Files: make([]domain.FileChange, 0, len(detailRes.Files)),
+	}
+	for _, f := range detailRes.Files {
+		detail.Files = append(detail.Files, domain.FileChange{
+			CommitID:     sha,
+			Path:         f.Filename,
+			PreviousPath: f.PreviousFilename,
+			Status:       f.Status,
+			Additions:    f.Additions,
+			Deletions:    f.Deletions,
+		})
+	}
+
+	morePages := false
+	linkHeader := response.Headers["Link"]
+	if len(linkHeader) > 0 {
+		morePages = g.hasNextPage(linkHeader[0])
+	}
+
+	return &detail, morePages, nil
+}
+
+// FetchMailmap fetches the .mailmap file at the root of the default branch of a repository, empty when the
+// repository has none. The request is conditional on etag, an unchanged file is not sent again and nil is returned.
+func (g *GitHubClient) FetchMailmap(ctx context.Context, repo domain.RepoMetadata, etag string) (*domain.Mailmap, error) {
+	endpoint := fmt.Sprintf("%s/repos/%s/contents/.mailmap", g.baseURL, repo.Name)
+
+	headers := g.getHeaders()
+	headers["Accept"] = "application/vnd.github.raw+json"
+	if etag != "" {
+		headers["If-None-Match"] = etag
+	}
+
+	response, err := g.client.Get(endpoint, map[string]string{}, headers)
+	if err != nil {
+		log.Error().Msgf("error fetching mailmap: %v", err)
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusForbidden {
+		log.Error().Msgf("failed to fetch mailmap; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, message.ErrRateLimitExceeded
+	}
+
+	g.updateRateLimitHeaders(response)
+
+	if response.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
+
+	// a missing file is not found either, a repository gone upstream is detected by the commit fetches
+	if response.StatusCode == http.StatusNotFound {
+		return &domain.Mailmap{}, nil
+	}
+
+	if isGone(response.StatusCode) {
+		return nil, message.ErrRepoGone
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch mailmap; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, fmt.Errorf("failed to fetch mailmap; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	mailmap := &domain.Mailmap{Content: response.Body}
+	if tags := response.Headers["Etag"]; len(tags) > 0 {
+		mailmap.ETag = tags[0]
+	}
+	return mailmap, nil
+}
+
+// FetchRateLimit fetches the current core API rate limit, the request itself does not count against it
+func (g *GitHubClient) FetchRateLimit(ctx context.Context) (*domain.RateLimit, error) {
+	endpoint := fmt.Sprintf("%s/rate_limit", g.baseURL)
+
+	response, err := g.client.Get(endpoint, map[string]string{}, g.getHeaders())
+	if err != nil {
+		log.Error().Msgf("error fetching rate limit: %v", err)
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		log.Error().Msgf("failed to fetch rate limit; status code: %v, body: %v", response.StatusCode, response.Body)
+		return nil, fmt.Errorf("failed to fetch rate limit; status code: %v, body: %v", response.StatusCode, response.Body)
+	}
+
+	var rateLimitRes GitHubRateLimitResponse
+	if err := json.Unmarshal([]byte(response.Body), &rateLimitRes); err != nil {
+		log.Err(err).Msgf("marshal error, [%v]", err)
+		return nil, errors.New("could not unmarshal rate limit response")
+	}
+
+	core := rateLimitRes.Resources.Core
+	return &domain.RateLimit{
+		Limit:     core.Limit,
+		Remaining: core.Remaining,
+		Reset:     time.Unix(core.Reset, 0),
+	}, nil
+}
+
+// lastPage returns the page number of the 'last' link in the Link header
+func (g *GitHubClient) lastPage(linkHeader string) (int, bool) {
+	last, ok := g.parseLinkHeader(linkHeader)["last"]
+	if !ok {
+		return 0, false
+	}
+
+	lastURL, err := url.Parse(last)
+	if err != nil {
+		return 0, false
+	}
+
+	page, err := strconv.Atoi(lastURL.Query().Get("page"))
+	if err != nil {
+		return 0, false
+	}
+	return page, true
+}
+
 // hasNextPage checks if there is a 'next' link in the Link header
 func (g *GitHubClient) hasNextPage(linkHeader string) bool {
 	links := g.parseLinkHeader(linkHeader)
@@ -174,7 +1104,7 @@ func (g *GitHubClient) parseLinkHeader(header string) map[string]string {
 			continue
 		}
 		url := strings.Trim(sections[0], " <>")
-		rel := strings.Trim(sections[1], " rel=\"")
+		rel := strings.Trim(strings.TrimPrefix(strings.TrimSpace(sections[1]), "rel="), "\"")
 		links[rel] = url
 	}
 	return links
@@ -204,3 +1134,21 @@ func (api *GitHubClient) updateRateLimitHeaders(resp *client.Response) {
 		log.Info().Msgf("Rate limit used: %d/%d", usedInt, api.rateLimitFields.rateLimitLimit)
 	}
 }
+
+// refNotFound tells a branch or commit missing upstream apart from a repository gone upstream, both are not found,
+// by looking the repository up before reporting it gone
+func (g *GitHubClient) refNotFound(ctx context.Context, repo domain.RepoMetadata, statusCode int) error {
+	if statusCode == http.StatusUnavailableForLegalReasons {
+		return message.ErrRepoGone
+	}
+	if _, err := g.FetchRepoMetadata(ctx, repo.Name); err != nil {
+		return err
+	}
+	return message.ErrUnknownRef
+}
+
+// isGone reports whether a response means the repository no longer exists upstream, 451 is returned
+// for repositories taken down for legal reasons
+func isGone(statusCode int) bool {
+	return statusCode == http.StatusNotFound || statusCode == http.StatusUnavailableForLegalReasons
+}
@@ -0,0 +1,420 @@
+package git_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kenmobility/git-api-service/infra/git"
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountCommitsFromLastPageLink(t *testing.T) {
+	repoMetadata := randomRepoMetadata()
+	repoMetadata.Branch = "main"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, fmt.Sprintf("/repos/%s/commits", repoMetadata.Name), r.URL.Path)
+		require.Equal(t, "1", r.URL.Query().Get("per_page"))
+		require.Equal(t, "main", r.URL.Query().Get("sha"))
+
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?per_page=1&page=2>; rel="next", <%s%s?per_page=1&page=4213>; rel="last"`,
+			"https://api.github.com", r.URL.Path, "https://api.github.com", r.URL.Path))
+		w.Write([]byte(`[{"sha": "abc123"}]`))
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	count, err := gitClient.CountCommits(context.Background(), repoMetadata, time.Now().AddDate(-1, 0, 0), time.Time{})
+
+	require.NoError(t, err)
+	require.Equal(t, 4213, count)
+}
+
+func TestCountCommitsSinglePage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"sha": "abc123"}]`))
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	count, err := gitClient.CountCommits(context.Background(), randomRepoMetadata(), time.Time{}, time.Time{})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+func TestFetchCommitsWithIdentities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"sha": "abc", "html_url": "https://github.com/o/r/commit/abc",
+			"commit": {"message": "Fix typo",
+				"author": {"name": "Jane Doe", "email": "jane@example.com", "date": "2024-01-02T03:04:05Z"},
+				"committer": {"name": "GitHub", "email": "noreply@github.com", "date": "2024-01-03T03:04:05Z"}},
+			"author": {"login": "janedoe"}, "committer": null, "parents": [{"sha": "def"}]}]`))
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	commits, _, err := gitClient.FetchCommits(context.Background(), randomRepoMetadata(), time.Time{}, time.Time{}, "", 1, 100)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, domain.Identity{Name: "Jane Doe", Email: "jane@example.com", Login: "janedoe",
+		Date: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, commits[0].AuthorIdentity())
+	require.Equal(t, domain.Identity{Name: "GitHub", Email: "noreply@github.com",
+		Date: time.Date(2024, 1, 3, 3, 4, 5, 0, time.UTC)}, commits[0].CommitterIdentity())
+}
+
+func TestFetchMailmap(t *testing.T) {
+	repo := randomRepoMetadata()
+	found := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/repos/"+repo.Name+"/contents/.mailmap", r.URL.Path)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"abc"`)
+		w.Write([]byte("Jane Doe <jane@example.com> <jdoe@laptop.local>\n"))
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	mailmap, err := gitClient.FetchMailmap(context.Background(), repo, "")
+	require.NoError(t, err)
+	require.Equal(t, &domain.Mailmap{Content: "Jane Doe <jane@example.com> <jdoe@laptop.local>\n", ETag: `"abc"`}, mailmap)
+
+	// an unchanged file is not sent again
+	mailmap, err = gitClient.FetchMailmap(context.Background(), repo, `"abc"`)
+	require.NoError(t, err)
+	require.Nil(t, mailmap)
+
+	// a repository without a .mailmap file has no aliases to map
+	found = false
+	mailmap, err = gitClient.FetchMailmap(context.Background(), repo, `"abc"`)
+	require.NoError(t, err)
+	require.Equal(t, &domain.Mailmap{}, mailmap)
+}
+
+func TestFetchRateLimit(t *testing.T) {
+	reset := time.Now().Add(30 * time.Minute).Unix()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rate_limit", r.URL.Path)
+		fmt.Fprintf(w, `{"resources": {"core": {"limit": 5000, "remaining": 4200, "reset": %d}}}`, reset)
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	rateLimit, err := gitClient.FetchRateLimit(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 5000, rateLimit.Limit)
+	require.Equal(t, 4200, rateLimit.Remaining)
+	require.Equal(t, reset, rateLimit.Reset.Unix())
+}
+
+// a renamed or transferred repository redirects to its provider id, the redirect is followed by the http client
+func TestFetchRepoMetadataOfRenamedRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/old-owner/old-name":
+			http.Redirect(w, r, "/repositories/42", http.StatusMovedPermanently)
+		case "/repositories/42":
+			w.Write([]byte(`{"id": 42, "full_name": "new-owner/new-name", "html_url": "https://github.com/new-owner/new-name"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	repoMetadata, err := gitClient.FetchRepoMetadata(context.Background(), "old-owner/old-name")
+
+	require.NoError(t, err)
+	require.Equal(t, int64(42), repoMetadata.ProviderID)
+	require.Equal(t, "new-owner/new-name", repoMetadata.Name)
+
+	repoMetadata, err = gitClient.FetchRepoMetadataByID(context.Background(), 42)
+
+	require.NoError(t, err)
+	require.Equal(t, "new-owner/new-name", repoMetadata.Name)
+}
+
+func TestFetchStargazersWithStarDates(t *testing.T) {
+	repoMetadata := randomRepoMetadata()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, fmt.Sprintf("/repos/%s/stargazers", repoMetadata.Name), r.URL.Path)
+		require.Equal(t, "application/vnd.github.star+json", r.Header.Get("Accept"))
+		require.Equal(t, "2", r.URL.Query().Get("page"))
+
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?per_page=100&page=3>; rel="next"`, "https://api.github.com", r.URL.Path))
+		w.Write([]byte(`[{"starred_at": "2024-03-10T15:42:00Z", "user": {"login": "octocat"}}]`))
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	stargazers, morePages, err := gitClient.FetchStargazers(context.Background(), repoMetadata, 2, 100)
+
+	require.NoError(t, err)
+	require.True(t, morePages)
+	require.Len(t, stargazers, 1)
+	require.Equal(t, "octocat", stargazers[0].Login)
+	require.Equal(t, time.Date(2024, 3, 10, 15, 42, 0, 0, time.UTC), stargazers[0].StarredAt)
+}
+
+func TestFetchBranchesWithHeads(t *testing.T) {
+	repoMetadata := randomRepoMetadata()
+	repoMetadata.DefaultBranch = "main"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, fmt.Sprintf("/repos/%s/branches", repoMetadata.Name), r.URL.Path)
+		require.Equal(t, "1", r.URL.Query().Get("page"))
+
+		w.Write([]byte(`[{"name": "main", "commit": {"sha": "aaa"}}, {"name": "release/1.0", "commit": {"sha": "bbb"}}]`))
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	branches, morePages, err := gitClient.FetchBranches(context.Background(), repoMetadata, 1, 100)
+
+	require.NoError(t, err)
+	require.False(t, morePages)
+	require.Len(t, branches, 2)
+	require.True(t, branches[0].IsDefault)
+	require.Equal(t, "release/1.0", branches[1].Name)
+	require.Equal(t, "bbb", branches[1].HeadSHA)
+	require.False(t, branches[1].IsDefault)
+}
+
+func TestFetchTagsAndReleases(t *testing.T) {
+	repoMetadata := randomRepoMetadata()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/repos/%s/tags", repoMetadata.Name):
+			w.Write([]byte(`[{"name": "v1.0.0", "commit": {"sha": "aaa"}}]`))
+		case fmt.Sprintf("/repos/%s/releases", repoMetadata.Name):
+			w.Write([]byte(`[{"tag_name": "v1.0.0", "name": "First", "body": "notes", "prerelease": true, "published_at": "2024-03-10T15:42:00Z"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	tags, morePages, err := gitClient.FetchTags(context.Background(), repoMetadata, 1, 100)
+	require.NoError(t, err)
+	require.False(t, morePages)
+	require.Equal(t, []domain.Tag{{RepositoryID: repoMetadata.ID, Name: "v1.0.0", SHA: "aaa"}}, tags)
+
+	releases, _, err := gitClient.FetchReleases(context.Background(), repoMetadata, 1, 100)
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	require.Equal(t, "v1.0.0", releases[0].Tag)
+	require.Equal(t, "notes", releases[0].Notes)
+	require.True(t, releases[0].Prerelease)
+	require.Equal(t, time.Date(2024, 3, 10, 15, 42, 0, 0, time.UTC), *releases[0].PublishedAt)
+}
+
+func TestFetchPullRequests(t *testing.T) {
+	repoMetadata := randomRepoMetadata()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/repos/%s/pulls", repoMetadata.Name):
+			require.Equal(t, "all", r.URL.Query().Get("state"))
+			require.Equal(t, "updated", r.URL.Query().Get("sort"))
+			w.Write([]byte(`[{"number": 7, "title": "Fix", "state": "closed", "user": {"login": "octocat"}, "base": {"ref": "main"},
+				"head": {"ref": "fix"}, "merge_commit_sha": "abc", "labels": [{"name": "bug"}], "merged_at": "2024-03-10T15:42:00Z"}]`))
+		case fmt.Sprintf("/repos/%s/pulls/7/reviews", repoMetadata.Name):
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?per_page=1&page=2>; rel="next", <%s%s?per_page=1&page=3>; rel="last"`,
+				"https://api.github.com", r.URL.Path, "https://api.github.com", r.URL.Path))
+			w.Write([]byte(`[{"id": 1}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	pullRequests, _, err := gitClient.FetchPullRequests(context.Background(), repoMetadata, 1, 100)
+	require.NoError(t, err)
+	require.Len(t, pullRequests, 1)
+	require.Equal(t, domain.PullRequestMerged, pullRequests[0].State)
+	require.Equal(t, "octocat", pullRequests[0].Author)
+	require.Equal(t, []string{"bug"}, pullRequests[0].Labels)
+	require.Equal(t, "abc", pullRequests[0].MergeCommitSHA)
+
+	reviews, err := gitClient.CountPullRequestReviews(context.Background(), repoMetadata, 7)
+	require.NoError(t, err)
+	require.Equal(t, 3, reviews)
+}
+
+func TestFetchIssuesSkipsPullRequests(t *testing.T) {
+	repoMetadata := randomRepoMetadata()
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, fmt.Sprintf("/repos/%s/issues", repoMetadata.Name), r.URL.Path)
+		require.Equal(t, "all", r.URL.Query().Get("state"))
+		require.Equal(t, "2024-03-01T00:00:00Z", r.URL.Query().Get("since"))
+		w.Write([]byte(`[{"number": 12, "title": "Crash", "state": "closed", "user": {"login": "octocat"},
+			"labels": [{"name": "bug"}], "assignees": [{"login": "hubot"}], "closed_at": "2024-03-10T15:42:00Z"},
+			{"number": 13, "title": "Fix crash", "state": "open", "user": {"login": "octocat"}, "pull_request": {"url": "x"}}]`))
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	issues, morePages, err := gitClient.FetchIssues(context.Background(), repoMetadata, since, 1, 100)
+	require.NoError(t, err)
+	require.False(t, morePages)
+	require.Len(t, issues, 1)
+	require.Equal(t, 12, issues[0].Number)
+	require.Equal(t, domain.IssueClosed, issues[0].State)
+	require.Equal(t, []string{"bug"}, issues[0].Labels)
+	require.Equal(t, []string{"hubot"}, issues[0].Assignees)
+	require.NotNil(t, issues[0].ClosedAt)
+}
+
+func TestFetchCommitStatusesAndCheckRuns(t *testing.T) {
+	repoMetadata := randomRepoMetadata()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/repos/%s/commits/abc/statuses", repoMetadata.Name):
+			w.Write([]byte(`[{"id": 2, "context": "ci/build", "state": "success", "created_at": "2024-03-10T15:50:00Z"},
+				{"id": 1, "context": "ci/build", "state": "pending", "created_at": "2024-03-10T15:42:00Z"}]`))
+		case fmt.Sprintf("/repos/%s/commits/abc/check-runs", repoMetadata.Name):
+			require.Equal(t, "all", r.URL.Query().Get("filter"))
+			w.Write([]byte(`{"total_count": 2, "check_runs": [
+				{"id": 8, "name": "test", "status": "completed", "conclusion": "failure", "started_at": "2024-03-10T15:42:00Z", "completed_at": "2024-03-10T15:45:00Z"},
+				{"id": 9, "name": "test", "status": "in_progress", "conclusion": null, "started_at": "2024-03-10T15:46:00Z"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	statuses, _, err := gitClient.FetchCommitStatuses(context.Background(), repoMetadata, "abc", 1, 100)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	require.Equal(t, domain.CheckPassed, statuses[0].Outcome())
+	require.Equal(t, domain.CheckPending, statuses[1].Outcome())
+	require.Equal(t, "ci/build", statuses[0].Name)
+
+	runs, _, err := gitClient.FetchCheckRuns(context.Background(), repoMetadata, "abc", 1, 100)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	require.Equal(t, domain.CheckFailed, runs[0].Outcome())
+	require.Equal(t, domain.CheckPending, runs[1].Outcome())
+	require.Equal(t, int64(9), runs[1].ExternalID)
+}
+
+func TestFetchCommitDetail(t *testing.T) {
+	repoMetadata := randomRepoMetadata()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, fmt.Sprintf("/repos/%s/commits/abc", repoMetadata.Name), r.URL.Path)
+		require.Equal(t, "2", r.URL.Query().Get("page"))
+		w.Write([]byte(`{"sha": "abc", "stats": {"additions": 12, "deletions": 3, "total": 15}, "files": [
+			{"filename": "pkg/new.go", "previous_filename": "pkg/old.go", "status": "renamed", "additions": 2, "deletions": 1},
+			{"filename": "README.md", "status": "modified", "additions": 10, "deletions": 2}]}`))
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	detail, morePages, err := gitClient.FetchCommitDetail(context.Background(), repoMetadata, "abc", 2, 100)
+	require.NoError(t, err)
+	require.False(t, morePages)
+	require.Equal(t, domain.CommitStats{Additions: 12, Deletions: 3}, detail.Stats)
+	require.Equal(t, []domain.FileChange{
+		{CommitID: "abc", Path: "pkg/new.go", PreviousPath: "pkg/old.go", Status: domain.FileRenamed, Additions: 2, Deletions: 1},
+		{CommitID: "abc", Path: "README.md", Status: domain.FileModified, Additions: 10, Deletions: 2},
+	}, detail.Files)
+}
+
+func TestFetchCommitDetailOfUnprocessableDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	_, _, err := gitClient.FetchCommitDetail(context.Background(), randomRepoMetadata(), "abc", 1, 100)
+	require.Equal(t, message.ErrCommitDetailUnavailable, err)
+}
+
+func TestFetchCommitsOfUnknownBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/name":
+			w.Write([]byte(`{"id": 7, "full_name": "owner/name"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	// the repository still exists upstream, only its branch is missing
+	_, _, err := gitClient.FetchCommits(context.Background(), domain.RepoMetadata{Name: "owner/name", Branch: "deleted"}, time.Time{}, time.Time{}, "", 1, 100)
+	require.ErrorIs(t, err, message.ErrUnknownRef)
+
+	_, _, err = gitClient.FetchCommits(context.Background(), domain.RepoMetadata{Name: "owner/deleted"}, time.Time{}, time.Time{}, "", 1, 100)
+	require.ErrorIs(t, err, message.ErrRepoGone)
+}
+
+func TestFetchRepoMetadataLifecycle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/archived":
+			w.Write([]byte(`{"id": 7, "full_name": "owner/archived", "archived": true, "visibility": "public", "pushed_at": "2023-05-01T10:00:00Z"}`))
+		case "/repos/owner/dmca":
+			w.WriteHeader(http.StatusUnavailableForLegalReasons)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	gitClient := git.NewGitHubClient(server.URL, "", time.Hour)
+
+	repoMetadata, err := gitClient.FetchRepoMetadata(context.Background(), "owner/archived")
+	require.NoError(t, err)
+	require.True(t, repoMetadata.Archived)
+	require.Equal(t, "public", repoMetadata.Visibility)
+	require.Equal(t, time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC), *repoMetadata.PushedAt)
+	require.Equal(t, domain.UpstreamStateArchived, repoMetadata.UpstreamState)
+
+	_, err = gitClient.FetchRepoMetadata(context.Background(), "owner/dmca")
+	require.ErrorIs(t, err, message.ErrRepoGone)
+
+	_, err = gitClient.FetchRepoMetadataByID(context.Background(), 8)
+	require.ErrorIs(t, err, message.ErrRepoGone)
+}
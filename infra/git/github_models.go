@@ -9,12 +9,23 @@ type (
 		Commit  Commit `json:"commit"`
 		URL     string `json:"url"`
 		HtmlURL string `json:"html_url"`
+		Parents []struct {
+			SHA string `json:"sha"`
+		} `json:"parents"`
+		// Author and Committer are the platform accounts linked to the author and committer emails, null when unlinked
+		Author    *GithubUser `json:"author"`
+		Committer *GithubUser `json:"committer"`
+	}
+
+	GithubUser struct {
+		Login string `json:"login"`
 	}
 
 	Commit struct {
-		Author  Author `json:"author"`
-		Message string `json:"message"`
-		URL     string `json:"url"`
+		Author    Author `json:"author"`
+		Committer Author `json:"committer"`
+		Message   string `json:"message"`
+		URL       string `json:"url"`
 	}
 
 	Author struct {
@@ -22,11 +33,28 @@ type (
 		Email string    `json:"email"`
 		Date  time.Time `json:"date"`
 	}
+
+	// GithubCommitDetailResponse is a single commit with its stats and a page of its changed files
+	GithubCommitDetailResponse struct {
+		SHA   string `json:"sha"`
+		Stats struct {
+			Additions int `json:"additions"`
+			Deletions int `json:"deletions"`
+			Total     int `json:"total"`
+		} `json:"stats"`
+		Files []struct {
+			Filename         string `json:"filename"`
+			PreviousFilename string `json:"previous_filename"`
+			Status           string `json:"status"`
+			Additions        int    `json:"additions"`
+			Deletions        int    `json:"deletions"`
+		} `json:"files"`
+	}
 )
 
 type (
 	GitHubRepoMetadataResponse struct {
-		Id          int    `json:"id"`
+		Id          int64  `json:"id"`
 		Name        string `json:"name"`
 		FullName    string `json:"full_name"`
 		HtmlUrl     string `json:"html_url"`
@@ -36,10 +64,133 @@ type (
 			Url     string `json:"url"`
 			HtmlUrl string `json:"html_url"`
 		} `json:"owner"`
-		StargazersCount int    `json:"stargazers_count"`
-		WatchersCount   int    `json:"watchers_count"`
-		Language        string `json:"language"`
-		ForksCount      int    `json:"forks_count"`
-		OpenIssues      int    `json:"open_issues"`
+		StargazersCount int        `json:"stargazers_count"`
+		WatchersCount   int        `json:"watchers_count"`
+		Language        string     `json:"language"`
+		ForksCount      int        `json:"forks_count"`
+		OpenIssues      int        `json:"open_issues"`
+		Archived        bool       `json:"archived"`
+		Disabled        bool       `json:"disabled"`
+		Visibility      string     `json:"visibility"`
+		PushedAt        *time.Time `json:"pushed_at"`
+		Fork            bool       `json:"fork"`
+		Topics          []string   `json:"topics"`
+		DefaultBranch   string     `json:"default_branch"`
+	}
+)
+
+type (
+	GitHubStargazerResponse struct {
+		StarredAt time.Time `json:"starred_at"`
+		User      struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	}
+)
+
+type (
+	GitHubRateLimitResponse struct {
+		Resources struct {
+			Core GitHubRateLimit `json:"core"`
+		} `json:"resources"`
+	}
+
+	GitHubRateLimit struct {
+		Limit     int   `json:"limit"`
+		Remaining int   `json:"remaining"`
+		Reset     int64 `json:"reset"`
+	}
+)
+
+type (
+	GitHubBranchResponse struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+
+	GitHubTagResponse struct {
+		Name   string `json:"name"`
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+
+	GitHubReleaseResponse struct {
+		TagName     string     `json:"tag_name"`
+		Name        string     `json:"name"`
+		Body        string     `json:"body"`
+		Draft       bool       `json:"draft"`
+		Prerelease  bool       `json:"prerelease"`
+		PublishedAt *time.Time `json:"published_at"`
+	}
+
+	GitHubPullRequestResponse struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		State   string `json:"state"`
+		HtmlUrl string `json:"html_url"`
+		User    struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		MergeCommitSHA string `json:"merge_commit_sha"`
+		Labels         []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		MergedAt  *time.Time `json:"merged_at"`
+		ClosedAt  *time.Time `json:"closed_at"`
+	}
+
+	GitHubIssueResponse struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		State   string `json:"state"`
+		HtmlUrl string `json:"html_url"`
+		User    struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+		Assignees []struct {
+			Login string `json:"login"`
+		} `json:"assignees"`
+		// PullRequest is only set on the pull requests the issues endpoint also lists
+		PullRequest *struct {
+			URL string `json:"url"`
+		} `json:"pull_request"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		ClosedAt  *time.Time `json:"closed_at"`
+	}
+
+	GitHubCommitStatusResponse struct {
+		ID        int64     `json:"id"`
+		Context   string    `json:"context"`
+		State     string    `json:"state"`
+		TargetUrl string    `json:"target_url"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	GitHubCheckRunsResponse struct {
+		TotalCount int `json:"total_count"`
+		CheckRuns  []struct {
+			ID          int64      `json:"id"`
+			Name        string     `json:"name"`
+			Status      string     `json:"status"`
+			Conclusion  string     `json:"conclusion"`
+			HtmlUrl     string     `json:"html_url"`
+			StartedAt   *time.Time `json:"started_at"`
+			CompletedAt *time.Time `json:"completed_at"`
+		} `json:"check_runs"`
 	}
 )
@@ -41,6 +41,100 @@ func (m *MockGitManagerClient) EXPECT() *MockGitManagerClientMockRecorder {
 	return m.recorder
 }
 
+// CountCommits mocks base method.
+func (m *MockGitManagerClient) CountCommits(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCommits", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCommits indicates an expected call of CountCommits.
+func (mr *MockGitManagerClientMockRecorder) CountCommits(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCommits", reflect.TypeOf((*MockGitManagerClient)(nil).CountCommits), arg0, arg1, arg2, arg3)
+}
+
+// CountPullRequestReviews mocks base method.
+func (m *MockGitManagerClient) CountPullRequestReviews(arg0 context.Context, arg1 domain.RepoMetadata, arg2 int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPullRequestReviews", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPullRequestReviews indicates an expected call of CountPullRequestReviews.
+func (mr *MockGitManagerClientMockRecorder) CountPullRequestReviews(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPullRequestReviews", reflect.TypeOf((*MockGitManagerClient)(nil).CountPullRequestReviews), arg0, arg1, arg2)
+}
+
+// FetchBranches mocks base method.
+func (m *MockGitManagerClient) FetchBranches(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3 int) ([]domain.Branch, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchBranches", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Branch)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchBranches indicates an expected call of FetchBranches.
+func (mr *MockGitManagerClientMockRecorder) FetchBranches(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBranches", reflect.TypeOf((*MockGitManagerClient)(nil).FetchBranches), arg0, arg1, arg2, arg3)
+}
+
+// FetchCheckRuns mocks base method.
+func (m *MockGitManagerClient) FetchCheckRuns(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string, arg3, arg4 int) ([]domain.CheckRun, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCheckRuns", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]domain.CheckRun)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchCheckRuns indicates an expected call of FetchCheckRuns.
+func (mr *MockGitManagerClientMockRecorder) FetchCheckRuns(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCheckRuns", reflect.TypeOf((*MockGitManagerClient)(nil).FetchCheckRuns), arg0, arg1, arg2, arg3, arg4)
+}
+
+// FetchCommitDetail mocks base method.
+func (m *MockGitManagerClient) FetchCommitDetail(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string, arg3, arg4 int) (*domain.CommitDetail, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCommitDetail", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.CommitDetail)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchCommitDetail indicates an expected call of FetchCommitDetail.
+func (mr *MockGitManagerClientMockRecorder) FetchCommitDetail(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCommitDetail", reflect.TypeOf((*MockGitManagerClient)(nil).FetchCommitDetail), arg0, arg1, arg2, arg3, arg4)
+}
+
+// FetchCommitStatuses mocks base method.
+func (m *MockGitManagerClient) FetchCommitStatuses(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string, arg3, arg4 int) ([]domain.CheckRun, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCommitStatuses", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]domain.CheckRun)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchCommitStatuses indicates an expected call of FetchCommitStatuses.
+func (mr *MockGitManagerClientMockRecorder) FetchCommitStatuses(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCommitStatuses", reflect.TypeOf((*MockGitManagerClient)(nil).FetchCommitStatuses), arg0, arg1, arg2, arg3, arg4)
+}
+
 // FetchCommits mocks base method.
 func (m *MockGitManagerClient) FetchCommits(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3 time.Time, arg4 string, arg5, arg6 int) ([]domain.Commit, bool, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +151,116 @@ func (mr *MockGitManagerClientMockRecorder) FetchCommits(arg0, arg1, arg2, arg3,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCommits", reflect.TypeOf((*MockGitManagerClient)(nil).FetchCommits), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// FetchIssues mocks base method.
+func (m *MockGitManagerClient) FetchIssues(arg0 context.Context, arg1 domain.RepoMetadata, arg2 time.Time, arg3, arg4 int) ([]domain.Issue, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchIssues", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]domain.Issue)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchIssues indicates an expected call of FetchIssues.
+func (mr *MockGitManagerClientMockRecorder) FetchIssues(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchIssues", reflect.TypeOf((*MockGitManagerClient)(nil).FetchIssues), arg0, arg1, arg2, arg3, arg4)
+}
+
+// FetchMailmap mocks base method.
+func (m *MockGitManagerClient) FetchMailmap(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string) (*domain.Mailmap, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchMailmap", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Mailmap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchMailmap indicates an expected call of FetchMailmap.
+func (mr *MockGitManagerClientMockRecorder) FetchMailmap(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchMailmap", reflect.TypeOf((*MockGitManagerClient)(nil).FetchMailmap), arg0, arg1, arg2)
+}
+
+// FetchOwnerRepos mocks base method.
+func (m *MockGitManagerClient) FetchOwnerRepos(arg0 context.Context, arg1 string, arg2, arg3 int) ([]domain.OwnerRepo, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchOwnerRepos", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.OwnerRepo)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchOwnerRepos indicates an expected call of FetchOwnerRepos.
+func (mr *MockGitManagerClientMockRecorder) FetchOwnerRepos(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchOwnerRepos", reflect.TypeOf((*MockGitManagerClient)(nil).FetchOwnerRepos), arg0, arg1, arg2, arg3)
+}
+
+// FetchPullRequestCommits mocks base method.
+func (m *MockGitManagerClient) FetchPullRequestCommits(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3, arg4 int) ([]string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPullRequestCommits", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchPullRequestCommits indicates an expected call of FetchPullRequestCommits.
+func (mr *MockGitManagerClientMockRecorder) FetchPullRequestCommits(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPullRequestCommits", reflect.TypeOf((*MockGitManagerClient)(nil).FetchPullRequestCommits), arg0, arg1, arg2, arg3, arg4)
+}
+
+// FetchPullRequests mocks base method.
+func (m *MockGitManagerClient) FetchPullRequests(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3 int) ([]domain.PullRequest, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPullRequests", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.PullRequest)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchPullRequests indicates an expected call of FetchPullRequests.
+func (mr *MockGitManagerClientMockRecorder) FetchPullRequests(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPullRequests", reflect.TypeOf((*MockGitManagerClient)(nil).FetchPullRequests), arg0, arg1, arg2, arg3)
+}
+
+// FetchRateLimit mocks base method.
+func (m *MockGitManagerClient) FetchRateLimit(arg0 context.Context) (*domain.RateLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRateLimit", arg0)
+	ret0, _ := ret[0].(*domain.RateLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRateLimit indicates an expected call of FetchRateLimit.
+func (mr *MockGitManagerClientMockRecorder) FetchRateLimit(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRateLimit", reflect.TypeOf((*MockGitManagerClient)(nil).FetchRateLimit), arg0)
+}
+
+// FetchReleases mocks base method.
+func (m *MockGitManagerClient) FetchReleases(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3 int) ([]domain.Release, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchReleases", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Release)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchReleases indicates an expected call of FetchReleases.
+func (mr *MockGitManagerClientMockRecorder) FetchReleases(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchReleases", reflect.TypeOf((*MockGitManagerClient)(nil).FetchReleases), arg0, arg1, arg2, arg3)
+}
+
 // FetchRepoMetadata mocks base method.
 func (m *MockGitManagerClient) FetchRepoMetadata(arg0 context.Context, arg1 string) (*domain.RepoMetadata, error) {
 	m.ctrl.T.Helper()
@@ -71,3 +275,50 @@ func (mr *MockGitManagerClientMockRecorder) FetchRepoMetadata(arg0, arg1 any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRepoMetadata", reflect.TypeOf((*MockGitManagerClient)(nil).FetchRepoMetadata), arg0, arg1)
 }
+
+// FetchRepoMetadataByID mocks base method.
+func (m *MockGitManagerClient) FetchRepoMetadataByID(arg0 context.Context, arg1 int64) (*domain.RepoMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRepoMetadataByID", arg0, arg1)
+	ret0, _ := ret[0].(*domain.RepoMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRepoMetadataByID indicates an expected call of FetchRepoMetadataByID.
+func (mr *MockGitManagerClientMockRecorder) FetchRepoMetadataByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRepoMetadataByID", reflect.TypeOf((*MockGitManagerClient)(nil).FetchRepoMetadataByID), arg0, arg1)
+}
+
+// FetchStargazers mocks base method.
+func (m *MockGitManagerClient) FetchStargazers(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3 int) ([]domain.Stargazer, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchStargazers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Stargazer)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchStargazers indicates an expected call of FetchStargazers.
+func (mr *MockGitManagerClientMockRecorder) FetchStargazers(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchStargazers", reflect.TypeOf((*MockGitManagerClient)(nil).FetchStargazers), arg0, arg1, arg2, arg3)
+}
+
+// FetchTags mocks base method.
+func (m *MockGitManagerClient) FetchTags(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3 int) ([]domain.Tag, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchTags", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Tag)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FetchTags indicates an expected call of FetchTags.
+func (mr *MockGitManagerClientMockRecorder) FetchTags(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchTags", reflect.TypeOf((*MockGitManagerClient)(nil).FetchTags), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	BackfillStatusPending   = "pending"
+	BackfillStatusRunning   = "running"
+	BackfillStatusCompleted = "completed"
+	BackfillStatusFailed    = "failed"
+)
+
+// BackfillMaxAttempts is the number of times a failing backfill job is run before it is left failed
+const BackfillMaxAttempts = 5
+
+// SyncRange is a commit date range of a repository whose commits have been fetched,
+// a zero Since means the range starts at the first commit of the repository
+type SyncRange struct {
+	Since time.Time
+	Until time.Time
+}
+
+// BackfillJob fetches the commits of a date range missing from a repository after its tracking window grew
+type BackfillJob struct {
+	ID           uint
+	RepositoryID uint
+	Since        time.Time
+	Until        time.Time
+	Status       string
+	Page         int
+	CommitsSaved int
+	Error        string
+	// Attempts is the number of times the job failed, RetryAt is when a failed job is retried
+	Attempts    int
+	RetryAt     *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// IsActive reports whether the job still has to fill its range
+func (j BackfillJob) IsActive() bool {
+	return j.Status == BackfillStatusPending || j.Status == BackfillStatusRunning
+}
+
+// IsOpen reports whether the job still has to fill its range, now or once retried
+func (j BackfillJob) IsOpen() bool {
+	return j.IsActive() || (j.Status == BackfillStatusFailed && j.Attempts < BackfillMaxAttempts)
+}
+
+// IsRetryable reports whether a failed job is due to be retried at now
+func (j BackfillJob) IsRetryable(now time.Time) bool {
+	return j.Status == BackfillStatusFailed && j.Attempts < BackfillMaxAttempts && j.RetryAt != nil && !now.Before(*j.RetryAt)
+}
+
+// MergeSyncRanges sorts ranges and merges the overlapping or adjacent ones
+func MergeSyncRanges(ranges []SyncRange) []SyncRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sorted := make([]SyncRange, len(ranges))
+	copy(sorted, ranges)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Since.Before(sorted[j].Since)
+	})
+
+	merged := []SyncRange{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if r.Since.After(last.Until) {
+			merged = append(merged, r)
+			continue
+		}
+		if r.Until.After(last.Until) {
+			last.Until = r.Until
+		}
+	}
+
+	return merged
+}
+
+// MissingSyncRanges returns the parts of the window which none of the covered ranges contains
+func MissingSyncRanges(window SyncRange, covered []SyncRange) []SyncRange {
+	var missing []SyncRange
+
+	cursor := window.Since
+	for _, r := range MergeSyncRanges(covered) {
+		if !r.Until.After(cursor) {
+			continue
+		}
+		if !r.Since.Before(window.Until) {
+			break
+		}
+		if r.Since.After(cursor) {
+			missing = append(missing, SyncRange{Since: cursor, Until: r.Since})
+		}
+		cursor = r.Until
+	}
+
+	if window.Until.After(cursor) {
+		missing = append(missing, SyncRange{Since: cursor, Until: window.Until})
+	}
+
+	return missing
+}
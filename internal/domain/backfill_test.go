@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSyncRanges(t *testing.T) {
+	d := func(month int) time.Time { return time.Date(2024, time.Month(month), 1, 0, 0, 0, 0, time.UTC) }
+
+	merged := domain.MergeSyncRanges([]domain.SyncRange{
+		{Since: d(5), Until: d(7)},
+		{Since: d(1), Until: d(3)},
+		{Since: d(2), Until: d(4)},
+		{Since: d(4), Until: d(5)},
+		{Since: d(9), Until: d(10)},
+	})
+
+	require.Equal(t, []domain.SyncRange{
+		{Since: d(1), Until: d(7)},
+		{Since: d(9), Until: d(10)},
+	}, merged)
+}
+
+func TestMissingSyncRanges(t *testing.T) {
+	d := func(month int) time.Time { return time.Date(2024, time.Month(month), 1, 0, 0, 0, 0, time.UTC) }
+
+	covered := []domain.SyncRange{
+		{Since: d(3), Until: d(5)},
+		{Since: d(7), Until: d(9)},
+	}
+
+	require.Equal(t, []domain.SyncRange{
+		{Since: d(1), Until: d(3)},
+		{Since: d(5), Until: d(7)},
+		{Since: d(9), Until: d(12)},
+	}, domain.MissingSyncRanges(domain.SyncRange{Since: d(1), Until: d(12)}, covered))
+
+	// a window starting at the first commit misses everything before the oldest covered range
+	require.Equal(t, []domain.SyncRange{
+		{Until: d(3)},
+	}, domain.MissingSyncRanges(domain.SyncRange{Until: d(4)}, covered))
+
+	require.Empty(t, domain.MissingSyncRanges(domain.SyncRange{Since: d(3), Until: d(5)}, covered))
+}
+
+func TestBackfillJobIsRetryable(t *testing.T) {
+	now := time.Now()
+	due, later := now.Add(-time.Minute), now.Add(time.Minute)
+
+	require.True(t, domain.BackfillJob{Status: domain.BackfillStatusFailed, Attempts: 1, RetryAt: &due}.IsRetryable(now))
+	require.False(t, domain.BackfillJob{Status: domain.BackfillStatusFailed, Attempts: 1, RetryAt: &later}.IsRetryable(now))
+	require.False(t, domain.BackfillJob{Status: domain.BackfillStatusFailed, Attempts: domain.BackfillMaxAttempts, RetryAt: &due}.IsRetryable(now))
+	require.False(t, domain.BackfillJob{Status: domain.BackfillStatusRunning, RetryAt: &due}.IsRetryable(now))
+}
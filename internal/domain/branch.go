@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"path"
+	"time"
+)
+
+// Branch is a tracked branch of a repository
+type Branch struct {
+	RepositoryID uint
+	Name         string
+	// HeadSHA is the latest head of the branch seen at the git provider
+	HeadSHA string
+	// SyncedSHA is the head the commits of the branch were last ingested up to
+	SyncedSHA   string
+	IsDefault   bool
+	CommitCount int
+	UpdatedAt   time.Time
+}
+
+// IsValidBranchPattern reports whether a branch name or glob pattern, eg release/*, is well formed
+func IsValidBranchPattern(pattern string) bool {
+	if pattern == "" {
+		return false
+	}
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+// PrimaryBranch returns the branch the commit history of the repository is fetched from
+func (r RepoMetadata) PrimaryBranch() string {
+	if r.Branch != "" {
+		return r.Branch
+	}
+	return r.DefaultBranch
+}
+
+// TracksBranch reports whether a branch is the primary branch of the repository or matches one of its
+// tracked branch patterns
+func (r RepoMetadata) TracksBranch(name string) bool {
+	if primary := r.PrimaryBranch(); primary != "" && name == primary {
+		return true
+	}
+	for _, pattern := range r.TrackedBranches {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoMetadataTracksBranch(t *testing.T) {
+	repo := domain.RepoMetadata{DefaultBranch: "main", TrackedBranches: []string{"release/*", "develop"}}
+
+	require.Equal(t, "main", repo.PrimaryBranch())
+	require.True(t, repo.TracksBranch("main"))
+	require.True(t, repo.TracksBranch("develop"))
+	require.True(t, repo.TracksBranch("release/1.2"))
+	require.False(t, repo.TracksBranch("release/1.2/hotfix"))
+	require.False(t, repo.TracksBranch("feature/x"))
+
+	repo.Branch = "stable"
+	require.Equal(t, "stable", repo.PrimaryBranch())
+	require.False(t, repo.TracksBranch("main"))
+}
+
+func TestIsValidBranchPattern(t *testing.T) {
+	require.True(t, domain.IsValidBranchPattern("release/*"))
+	require.True(t, domain.IsValidBranchPattern("v[0-9].x"))
+	require.False(t, domain.IsValidBranchPattern(""))
+	require.False(t, domain.IsValidBranchPattern("release/["))
+}
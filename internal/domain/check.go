@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// CheckKindStatus is a commit status, every state a status context is set to is kept as a run
+	CheckKindStatus = "status"
+	// CheckKindCheckRun is a check run of a check suite, a re-run of a check is a new run
+	CheckKindCheckRun = "check_run"
+)
+
+const (
+	CheckPassed  = "passed"
+	CheckFailed  = "failed"
+	CheckPending = "pending"
+	// CheckIgnored is the outcome of the runs that neither pass nor fail a commit, eg cancelled runs
+	CheckIgnored = "ignored"
+)
+
+// CheckRun is a run of a status context or of a check on a commit of a repository
+type CheckRun struct {
+	RepositoryID uint
+	CommitID     string
+	Kind         string
+	// ExternalID is the id of the status or check run at the git provider
+	ExternalID int64
+	// Name is the context of a status or the name of a check run
+	Name string
+	// Conclusion is the provider conclusion of the run, eg success, failure or timed_out, empty while it runs
+	Conclusion  string
+	URL         string
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+// Outcome returns whether the run passed, failed, is still pending or is ignored
+func (r CheckRun) Outcome() string {
+	switch r.Conclusion {
+	case "":
+		return CheckPending
+	case "success", "neutral", "skipped":
+		return CheckPassed
+	case "failure", "error", "timed_out", "action_required", "startup_failure":
+		return CheckFailed
+	default:
+		return CheckIgnored
+	}
+}
+
+// FlakyCheck is a check that both passed and failed on the same commit
+type FlakyCheck struct {
+	Name string
+	// CommitCount is the number of commits the check flaked on
+	CommitCount int
+}
+
+// CIHealth summarizes the check runs of the commits of a repository
+type CIHealth struct {
+	// Commits is the number of commits with a passed or failed outcome, commits with pending checks are left out
+	Commits      int
+	GreenCommits int
+	// PassRate is the share of the commits whose latest run of every check passed
+	PassRate    float64
+	FlakyChecks []FlakyCheck
+	// MeanTimeToGreen is the mean time from the first check starting on a green commit, re-runs included,
+	// to the completion of the last of its checks
+	MeanTimeToGreen time.Duration
+}
+
+// ComputeCIHealth computes the CI health of a repository from the check runs of its commits
+func ComputeCIHealth(runs []CheckRun) CIHealth {
+	byCommit := make(map[string][]CheckRun)
+	var order []string
+	for _, r := range runs {
+		if _, ok := byCommit[r.CommitID]; !ok {
+			order = append(order, r.CommitID)
+		}
+		byCommit[r.CommitID] = append(byCommit[r.CommitID], r)
+	}
+
+	var health CIHealth
+	var timeToGreen time.Duration
+	flaky := make(map[string]int)
+	for _, commitID := range order {
+		outcome, sinceStart := commitOutcome(byCommit[commitID])
+		switch outcome {
+		case CheckPassed:
+			health.Commits++
+			health.GreenCommits++
+			timeToGreen += sinceStart
+		case CheckFailed:
+			health.Commits++
+		}
+
+		for _, name := range flakyChecks(byCommit[commitID]) {
+			flaky[name]++
+		}
+	}
+
+	if health.Commits > 0 {
+		health.PassRate = float64(health.GreenCommits) / float64(health.Commits)
+	}
+	if health.GreenCommits > 0 {
+		health.MeanTimeToGreen = timeToGreen / time.Duration(health.GreenCommits)
+	}
+
+	health.FlakyChecks = make([]FlakyCheck, 0, len(flaky))
+	for name, count := range flaky {
+		health.FlakyChecks = append(health.FlakyChecks, FlakyCheck{Name: name, CommitCount: count})
+	}
+	sort.Slice(health.FlakyChecks, func(i, j int) bool {
+		if health.FlakyChecks[i].CommitCount != health.FlakyChecks[j].CommitCount {
+			return health.FlakyChecks[i].CommitCount > health.FlakyChecks[j].CommitCount
+		}
+		return health.FlakyChecks[i].Name < health.FlakyChecks[j].Name
+	})
+	return health
+}
+
+// commitOutcome returns the outcome of a commit from the latest run of each of its checks, and for a green commit
+// the time from its first check starting to the completion of its last one
+func commitOutcome(runs []CheckRun) (string, time.Duration) {
+	latest := make(map[string]CheckRun)
+	var firstStart time.Time
+	for _, r := range runs {
+		key := r.Kind + " " + r.Name
+		if l, ok := latest[key]; !ok || r.runAt().After(l.runAt()) || (r.runAt().Equal(l.runAt()) && r.ExternalID > l.ExternalID) {
+			latest[key] = r
+		}
+		if start := r.runAt(); !start.IsZero() && (firstStart.IsZero() || start.Before(firstStart)) {
+			firstStart = start
+		}
+	}
+
+	outcome := CheckIgnored
+	var lastCompletion time.Time
+	for _, r := range latest {
+		switch r.Outcome() {
+		case CheckFailed:
+			return CheckFailed, 0
+		case CheckPending:
+			outcome = CheckPending
+		case CheckPassed:
+			if outcome == CheckIgnored {
+				outcome = CheckPassed
+			}
+			if r.CompletedAt != nil && r.CompletedAt.After(lastCompletion) {
+				lastCompletion = *r.CompletedAt
+			}
+		}
+	}
+
+	if outcome != CheckPassed || firstStart.IsZero() || lastCompletion.Before(firstStart) {
+		return outcome, 0
+	}
+	return outcome, lastCompletion.Sub(firstStart)
+}
+
+// flakyChecks returns the names of the checks of a commit that both passed and failed
+func flakyChecks(runs []CheckRun) []string {
+	outcomes := make(map[string]map[string]bool)
+	for _, r := range runs {
+		if outcomes[r.Name] == nil {
+			outcomes[r.Name] = make(map[string]bool)
+		}
+		outcomes[r.Name][r.Outcome()] = true
+	}
+
+	var names []string
+	for name, o := range outcomes {
+		if o[CheckPassed] && o[CheckFailed] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// runAt returns the date a run started at, falling back to its completion date
+func (r CheckRun) runAt() time.Time {
+	if r.StartedAt != nil {
+		return *r.StartedAt
+	}
+	if r.CompletedAt != nil {
+		return *r.CompletedAt
+	}
+	return time.Time{}
+}
@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeCIHealth(t *testing.T) {
+	start := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time {
+		t := start.Add(time.Duration(minutes) * time.Minute)
+		return &t
+	}
+
+	runs := []domain.CheckRun{
+		// a is green after its test check is re-run, its build status went from pending to success
+		{CommitID: "a", Kind: domain.CheckKindCheckRun, ExternalID: 1, Name: "test", Conclusion: "failure", StartedAt: at(0), CompletedAt: at(5)},
+		{CommitID: "a", Kind: domain.CheckKindCheckRun, ExternalID: 2, Name: "test", Conclusion: "success", StartedAt: at(10), CompletedAt: at(20)},
+		{CommitID: "a", Kind: domain.CheckKindStatus, ExternalID: 3, Name: "build", StartedAt: at(0)},
+		{CommitID: "a", Kind: domain.CheckKindStatus, ExternalID: 4, Name: "build", Conclusion: "success", StartedAt: at(8), CompletedAt: at(8)},
+		// b fails
+		{CommitID: "b", Kind: domain.CheckKindCheckRun, ExternalID: 5, Name: "test", Conclusion: "timed_out", StartedAt: at(30), CompletedAt: at(60)},
+		{CommitID: "b", Kind: domain.CheckKindCheckRun, ExternalID: 6, Name: "lint", Conclusion: "success", StartedAt: at(30), CompletedAt: at(31)},
+		// c is green in 10 minutes, its cancelled run is ignored
+		{CommitID: "c", Kind: domain.CheckKindCheckRun, ExternalID: 7, Name: "test", Conclusion: "success", StartedAt: at(40), CompletedAt: at(50)},
+		{CommitID: "c", Kind: domain.CheckKindCheckRun, ExternalID: 8, Name: "deploy", Conclusion: "cancelled", StartedAt: at(40), CompletedAt: at(41)},
+		// d still runs
+		{CommitID: "d", Kind: domain.CheckKindCheckRun, ExternalID: 9, Name: "test", StartedAt: at(70)},
+	}
+
+	health := domain.ComputeCIHealth(runs)
+
+	require.Equal(t, 3, health.Commits)
+	require.Equal(t, 2, health.GreenCommits)
+	require.InDelta(t, 2.0/3.0, health.PassRate, 0.0001)
+	require.Equal(t, []domain.FlakyCheck{{Name: "test", CommitCount: 1}}, health.FlakyChecks)
+	require.Equal(t, 15*time.Minute, health.MeanTimeToGreen)
+}
@@ -1,21 +1,108 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
 type Commit struct {
-	CommitID       string
-	Message        string
-	Author         string
-	Date           time.Time
+	CommitID string
+	Message  string
+	// Author is the name of the author of the commit
+	Author      string
+	AuthorEmail string
+	// AuthorLogin is the platform account of the author, empty when the author email is not linked to one
+	AuthorLogin string
+	// Date is the author date of the commit
+	Date time.Time
+	// Committer is the name of the committer of the commit
+	Committer      string
+	CommitterEmail string
+	// CommitterLogin is the platform account of the committer, empty when the committer email is not linked to one
+	CommitterLogin string
+	CommittedAt    time.Time
 	URL            string
+	RepositoryID   uint
 	RepositoryName string
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	ParentSHAs     []string
+	// Branches are the tracked branches the commit is reachable from, only set when listing commits
+	Branches []string
+	// FirstReleasedIn is the tag of the earliest release of the repository containing the commit, empty when unreleased
+	FirstReleasedIn string
+	// PullRequest is the merged pull request that introduced the commit, nil when unknown
+	PullRequest *PullRequest
+	// Stats are the line stats of the commit, nil until it is enriched with its file changes
+	Stats     *CommitStats
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CommitFilter narrows the commits of a repository, empty fields match every commit
+type CommitFilter struct {
+	// Branch only matches the commits reachable from a tracked branch
+	Branch string
+	// Release only matches the commits first released in the release with this tag
+	Release string
+}
+
+// Identity is the author or the committer of a commit
+type Identity struct {
+	Name  string
+	Email string
+	// Login is the platform account the email is linked to, empty when it is not linked to one
+	Login string
+	Date  time.Time
+}
+
+// Key returns a stable key identifying the person behind the identity, from their platform login, else their email,
+// else their name, case and whitespace insensitive
+func (i Identity) Key() string {
+	if login := strings.ToLower(strings.TrimSpace(i.Login)); login != "" {
+		return "login:" + login
+	}
+	if email := strings.ToLower(strings.TrimSpace(i.Email)); email != "" {
+		return "email:" + email
+	}
+	return "name:" + strings.ToLower(strings.Join(strings.Fields(i.Name), " "))
 }
 
 type AuthorCommitCount struct {
+	// AuthorKey is the identity key the commits are grouped on
+	AuthorKey string
+	// Author, AuthorEmail and AuthorLogin are the identity of the latest commit of the author
 	Author      string
+	AuthorEmail string
+	AuthorLogin string
 	CommitCount int
 }
+
+// CommitDiff holds the differences between the stored commits of a repository and a fresh copy fetched from the git provider
+type CommitDiff struct {
+	Added   []Commit
+	Removed []Commit
+	Changed []Commit
+}
+
+// IsEmpty reports whether the diff holds no change
+func (d CommitDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// CommitDate returns the committer date of the commit, falling back to its author date for commits
+// stored before committer dates were recorded
+func (c Commit) CommitDate() time.Time {
+	if c.CommittedAt.IsZero() {
+		return c.Date
+	}
+	return c.CommittedAt
+}
+
+// AuthorIdentity returns the identity of the author of the commit
+func (c Commit) AuthorIdentity() Identity {
+	return Identity{Name: c.Author, Email: c.AuthorEmail, Login: c.AuthorLogin, Date: c.Date}
+}
+
+// CommitterIdentity returns the identity of the committer of the commit
+func (c Commit) CommitterIdentity() Identity {
+	return Identity{Name: c.Committer, Email: c.CommitterEmail, Login: c.CommitterLogin, Date: c.CommitDate()}
+}
@@ -0,0 +1,15 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityKey(t *testing.T) {
+	require.Equal(t, "login:janedoe", domain.Identity{Name: "Jane Doe", Email: "jane@example.com", Login: "JaneDoe"}.Key())
+	require.Equal(t, "email:jane@example.com", domain.Identity{Name: "Jane Doe", Email: " Jane@Example.com"}.Key())
+	require.Equal(t, "name:jane doe", domain.Identity{Name: " Jane   doe "}.Key())
+	require.Equal(t, domain.Identity{Name: "Jane Doe"}.Key(), domain.Identity{Name: "jane doe"}.Key())
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// IdentitySourceCommit is an identity authoring stored commits
+	IdentitySourceCommit = "commit"
+	// IdentitySourceLogin is an email used in the commits of an author with a platform login
+	IdentitySourceLogin = "login"
+	// IdentitySourceMailmap is an email mapped to a contributor by a .mailmap file
+	IdentitySourceMailmap = "mailmap"
+	// IdentitySourceMerge is an identity merged into a contributor by an admin
+	IdentitySourceMerge = "merge"
+)
+
+// ContributorKeyPrefix prefixes the author key the statistics of a contributor are aggregated under
+const ContributorKeyPrefix = "contributor:"
+
+// Contributor is a person authoring commits under one or more identities
+type Contributor struct {
+	ID       uint
+	PublicID string
+	// Name, Email and Login are the canonical identity of the contributor
+	Name       string
+	Email      string
+	Login      string
+	Identities []ContributorIdentity
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ContributorIdentity is an identity key resolved to a contributor
+type ContributorIdentity struct {
+	Key   string
+	Name  string
+	Email string
+	Login string
+	// Source is how the identity was resolved to its contributor
+	Source string
+}
+
+// Key returns the author key the statistics of the contributor are aggregated under
+func (c Contributor) Key() string {
+	return ContributorKeyPrefix + c.PublicID
+}
+
+// ParseIdentityKey returns the identity an identity key was built from, eg Identity{Login: "jane"} for login:jane,
+// false when it is not an identity key
+func ParseIdentityKey(key string) (Identity, bool) {
+	kind, value, ok := strings.Cut(key, ":")
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		return Identity{}, false
+	}
+	switch kind {
+	case "login":
+		return Identity{Login: value}, true
+	case "email":
+		return Identity{Email: value}, true
+	case "name":
+		return Identity{Name: value}, true
+	}
+	return Identity{}, false
+}
+
+// Mailmap is the .mailmap file of a repository, ETag identifies its content at the git provider
+type Mailmap struct {
+	Content string
+	ETag    string
+}
+
+// MailmapEntry maps the commits of an email, and of a name when CommitName is set, to a proper name and email
+type MailmapEntry struct {
+	ProperName  string
+	ProperEmail string
+	CommitName  string
+	CommitEmail string
+}
+
+// Matches reports whether the entry maps an identity
+func (e MailmapEntry) Matches(i Identity) bool {
+	return strings.EqualFold(e.CommitEmail, i.Email) && (e.CommitName == "" || strings.EqualFold(e.CommitName, i.Name))
+}
+
+// ParseMailmap parses the entries of a .mailmap file, in any of the forms
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+//
+// blank lines, comments and malformed lines are skipped
+func ParseMailmap(content string) []MailmapEntry {
+	var entries []MailmapEntry
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var names, emails []string
+		for rest := line; len(emails) < 2; {
+			open := strings.IndexByte(rest, '<')
+			if open < 0 {
+				break
+			}
+			end := strings.IndexByte(rest[open:], '>')
+			if end < 0 {
+				break
+			}
+			names = append(names, strings.TrimSpace(rest[:open]))
+			emails = append(emails, strings.TrimSpace(rest[open+1:open+end]))
+			rest = rest[open+end+1:]
+		}
+
+		var entry MailmapEntry
+		switch len(emails) {
+		case 1:
+			entry = MailmapEntry{ProperName: names[0], CommitEmail: emails[0]}
+		case 2:
+			entry = MailmapEntry{ProperName: names[0], ProperEmail: emails[0], CommitName: names[1], CommitEmail: emails[1]}
+		default:
+			continue
+		}
+		if entry.CommitEmail == "" || (entry.ProperName == "" && entry.ProperEmail == "") {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
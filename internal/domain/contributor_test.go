@@ -0,0 +1,52 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMailmap(t *testing.T) {
+	content := `# mailmap of the project
+Jane Doe <jane@old.example.com>
+<jane@example.com> <jane@laptop.local>
+Jane Doe <jane@example.com> <jdoe@corp.example.com> # work email
+John Roe <john@example.com> Johnny <john@old.example.com>
+
+not an entry
+<> <orphan@example.com>
+`
+
+	require.Equal(t, []domain.MailmapEntry{
+		{ProperName: "Jane Doe", CommitEmail: "jane@old.example.com"},
+		{ProperEmail: "jane@example.com", CommitEmail: "jane@laptop.local"},
+		{ProperName: "Jane Doe", ProperEmail: "jane@example.com", CommitEmail: "jdoe@corp.example.com"},
+		{ProperName: "John Roe", ProperEmail: "john@example.com", CommitName: "Johnny", CommitEmail: "john@old.example.com"},
+	}, domain.ParseMailmap(content))
+}
+
+func TestMailmapEntryMatches(t *testing.T) {
+	entry := domain.MailmapEntry{ProperName: "John Roe", CommitName: "Johnny", CommitEmail: "john@old.example.com"}
+
+	require.True(t, entry.Matches(domain.Identity{Name: "johnny", Email: "John@Old.example.com"}))
+	require.False(t, entry.Matches(domain.Identity{Name: "John", Email: "john@old.example.com"}))
+	require.True(t, domain.MailmapEntry{ProperName: "John Roe", CommitEmail: "john@old.example.com"}.
+		Matches(domain.Identity{Name: "John", Email: "john@old.example.com"}))
+}
+
+func TestParseIdentityKey(t *testing.T) {
+	identity, ok := domain.ParseIdentityKey("email:Jane@Example.com")
+	require.True(t, ok)
+	require.Equal(t, domain.Identity{Email: "Jane@Example.com"}, identity)
+	require.Equal(t, "email:jane@example.com", identity.Key())
+
+	identity, ok = domain.ParseIdentityKey("name:Jane  Doe")
+	require.True(t, ok)
+	require.Equal(t, "name:jane doe", identity.Key())
+
+	_, ok = domain.ParseIdentityKey("contributor:1b2c")
+	require.False(t, ok)
+	_, ok = domain.ParseIdentityKey("login:")
+	require.False(t, ok)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+const (
+	FileAdded    = "added"
+	FileRemoved  = "removed"
+	FileModified = "modified"
+	FileRenamed  = "renamed"
+)
+
+// CommitStats holds the line stats of a commit
+type CommitStats struct {
+	Additions    int
+	Deletions    int
+	FilesChanged int
+}
+
+// FileChange is a change of a file in a commit
+type FileChange struct {
+	CommitID string
+	Path     string
+	// PreviousPath is the path of a renamed file before the commit, empty otherwise
+	PreviousPath string
+	// Status is added, removed, modified, renamed, copied, changed or unchanged
+	Status    string
+	Additions int
+	Deletions int
+}
+
+// CommitDetail holds the stats and the file changes of a commit
+type CommitDetail struct {
+	Stats CommitStats
+	Files []FileChange
+}
+
+// EnrichmentState is the progress of the enrichment of the commits of a repository with their file changes
+type EnrichmentState struct {
+	RepositoryID    uint
+	TotalCommits    int
+	EnrichedCommits int
+	// FailedCommits are the commits whose detail could not be fetched, they are not retried
+	FailedCommits int
+	LastRunAt     *time.Time
+	LastError     string
+}
+
+// PendingCommits returns the number of commits left to enrich
+func (s EnrichmentState) PendingCommits() int {
+	return max(s.TotalCommits-s.EnrichedCommits-s.FailedCommits, 0)
+}
+
+// EnrichmentBudget returns the number of requests an enrichment run may send, at most budget and leaving
+// reserve of the requests remaining in the rate limit window for the indexing jobs
+func EnrichmentBudget(budget int, rateLimit RateLimit, reserve float64) int {
+	available := rateLimit.Remaining - int(float64(rateLimit.Limit)*reserve)
+	return max(min(budget, available), 0)
+}
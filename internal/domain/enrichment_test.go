@@ -0,0 +1,20 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichmentBudget(t *testing.T) {
+	// a quarter of the rate limit is left to the indexing jobs
+	require.Equal(t, 500, domain.EnrichmentBudget(500, domain.RateLimit{Limit: 5000, Remaining: 4000}, 0.25))
+	require.Equal(t, 250, domain.EnrichmentBudget(500, domain.RateLimit{Limit: 5000, Remaining: 1500}, 0.25))
+	require.Equal(t, 0, domain.EnrichmentBudget(500, domain.RateLimit{Limit: 5000, Remaining: 1000}, 0.25))
+}
+
+func TestEnrichmentStatePendingCommits(t *testing.T) {
+	require.Equal(t, 3, domain.EnrichmentState{TotalCommits: 10, EnrichedCommits: 6, FailedCommits: 1}.PendingCommits())
+	require.Equal(t, 0, domain.EnrichmentState{TotalCommits: 2, EnrichedCommits: 3}.PendingCommits())
+}
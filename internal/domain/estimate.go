@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// RateLimit holds the request budget of the git provider
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// IndexingEstimate holds the cost of indexing a repository within its tracking window
+type IndexingEstimate struct {
+	RepositoryName    string
+	Since             *time.Time
+	Until             *time.Time
+	Branch            string
+	CommitsPerPage    int
+	Commits           int
+	Requests          int
+	EstimatedDuration time.Duration
+	StorageBytes      int64
+	RateLimit         RateLimit
+}
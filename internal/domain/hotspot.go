@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	HotspotSortScore   = "score"
+	HotspotSortChanges = "changes"
+	HotspotSortChurn   = "churn"
+	HotspotSortAuthors = "authors"
+	HotspotSortRecency = "recency"
+)
+
+// hotspotRecencyHalfLife is the age at which a change counts half as much towards the recency of a path
+const hotspotRecencyHalfLife = 30 * 24 * time.Hour
+
+// IsValidHotspotSort reports whether sort is one of the hotspot rankings
+func IsValidHotspotSort(sort string) bool {
+	switch sort {
+	case HotspotSortScore, HotspotSortChanges, HotspotSortChurn, HotspotSortAuthors, HotspotSortRecency:
+		return true
+	}
+	return false
+}
+
+// Hotspot is a file or a directory of a repository ranked by how much and how recently it changed
+type Hotspot struct {
+	Path  string
+	IsDir bool
+	// Changes is the number of commits that changed the path
+	Changes int
+	// Churn is the number of lines added and deleted in the path
+	Churn         int
+	Authors       int
+	LastChangedAt time.Time
+	// Recency decays from 1 for a change made now, halving every 30 days
+	Recency float64
+	// Score adds up the changes, churn and authors of the path, each relative to the highest of its kind, and its recency
+	Score float64
+}
+
+// FileRevision is a change of a file by a commit
+type FileRevision struct {
+	Commit Commit
+	Change FileChange
+}
+
+// FilePathSpan is a path a file had over a span of its history, changed by the commits committed from From on and
+// before Until, a zero date leaving the span open
+type FilePathSpan struct {
+	Path  string
+	From  time.Time
+	Until time.Time
+}
+
+// FileHistory is a page of the changes of a file of a repository, with the enrichment progress of the commits of the
+// repository, as the changes by commits not enriched yet are missing from it
+type FileHistory struct {
+	Revisions  []FileRevision
+	PagingInfo PagingInfo
+	Enrichment EnrichmentState
+}
+
+// Hotspots ranks the files and the directories of the path stats by sort, at most limit of each, now is the date
+// recency is measured from
+func Hotspots(stats []Hotspot, sortBy string, limit int, now time.Time) (files []Hotspot, dirs []Hotspot) {
+	for _, h := range stats {
+		age := now.Sub(h.LastChangedAt)
+		h.Recency = math.Pow(0.5, max(age, 0).Hours()/hotspotRecencyHalfLife.Hours())
+		if h.IsDir {
+			dirs = append(dirs, h)
+		} else {
+			files = append(files, h)
+		}
+	}
+
+	return rankHotspots(files, sortBy, limit), rankHotspots(dirs, sortBy, limit)
+}
+
+// rankHotspots scores the hotspots and returns the top limit of them by sort
+func rankHotspots(hotspots []Hotspot, sortBy string, limit int) []Hotspot {
+	var maxChanges, maxChurn, maxAuthors int
+	for _, h := range hotspots {
+		maxChanges = max(maxChanges, h.Changes)
+		maxChurn = max(maxChurn, h.Churn)
+		maxAuthors = max(maxAuthors, h.Authors)
+	}
+	ratio := func(v, max int) float64 {
+		if max == 0 {
+			return 0
+		}
+		return float64(v) / float64(max)
+	}
+	for i := range hotspots {
+		h := &hotspots[i]
+		h.Score = ratio(h.Changes, maxChanges) + ratio(h.Churn, maxChurn) + ratio(h.Authors, maxAuthors) + h.Recency
+	}
+
+	key := func(h Hotspot) float64 {
+		switch sortBy {
+		case HotspotSortChanges:
+			return float64(h.Changes)
+		case HotspotSortChurn:
+			return float64(h.Churn)
+		case HotspotSortAuthors:
+			return float64(h.Authors)
+		case HotspotSortRecency:
+			return h.Recency
+		default:
+			return h.Score
+		}
+	}
+	sort.Slice(hotspots, func(i, j int) bool {
+		if ki, kj := key(hotspots[i]), key(hotspots[j]); ki != kj {
+			return ki > kj
+		}
+		return hotspots[i].Path < hotspots[j].Path
+	})
+
+	if limit > 0 && len(hotspots) > limit {
+		hotspots = hotspots[:limit]
+	}
+	return hotspots
+}
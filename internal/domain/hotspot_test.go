@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHotspots(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	stats := []domain.Hotspot{
+		{Path: "pkg/api/server.go", Changes: 2, Churn: 16, Authors: 2, LastChangedAt: now},
+		{Path: "pkg/api/routes.go", Changes: 1, Churn: 150, Authors: 1, LastChangedAt: now.AddDate(0, 0, -30)},
+		{Path: "README.md", Changes: 1, Churn: 2, Authors: 1, LastChangedAt: now.AddDate(0, 0, -60)},
+		{Path: "pkg/", IsDir: true, Changes: 2, Churn: 166, Authors: 2, LastChangedAt: now},
+		{Path: "pkg/api/", IsDir: true, Changes: 2, Churn: 166, Authors: 2, LastChangedAt: now},
+	}
+
+	files, dirs := domain.Hotspots(stats, domain.HotspotSortChanges, 2, now)
+
+	require.Len(t, files, 2)
+	require.Equal(t, "pkg/api/server.go", files[0].Path)
+	require.InDelta(t, 1, files[0].Recency, 0.0001)
+	require.Equal(t, "README.md", files[1].Path)
+
+	// directories are ranked apart from the files
+	require.Equal(t, []string{"pkg/", "pkg/api/"}, []string{dirs[0].Path, dirs[1].Path})
+	require.True(t, dirs[0].IsDir)
+
+	files, _ = domain.Hotspots(stats, domain.HotspotSortChurn, 0, now)
+	require.Len(t, files, 3)
+	require.Equal(t, "pkg/api/routes.go", files[0].Path)
+	require.InDelta(t, 0.5, files[0].Recency, 0.0001)
+}
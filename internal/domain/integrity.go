@@ -0,0 +1,95 @@
+package domain
+
+import "time"
+
+// IntegrityBucket compares the stored commits of a repository in a committer date range with the provider's count
+type IntegrityBucket struct {
+	// a zero Since means the bucket starts at the first commit of the repository
+	Since         time.Time
+	Until         time.Time
+	StoredCount   int
+	ProviderCount int
+}
+
+// IsIncomplete reports whether the provider holds commits of the bucket that are not stored
+func (b IntegrityBucket) IsIncomplete() bool {
+	return b.StoredCount < b.ProviderCount
+}
+
+// IntegrityReport is the result of verifying the stored commit history of a repository
+type IntegrityReport struct {
+	RepositoryID  uint
+	Completeness  float64
+	StoredCount   int
+	ProviderCount int
+	Buckets       []IntegrityBucket
+	// MissingRanges are the date ranges whose stored commits fall short of the provider's count
+	MissingRanges []SyncRange
+	// OrphanedCommits are stored commits with a parent that is not stored
+	OrphanedCommits []string
+	RefetchJobs     int
+	VerifiedAt      time.Time
+}
+
+// MonthlyBuckets splits a committer date range into calendar month buckets, a zero since makes
+// the first bucket start at the first commit of the repository and end with the month of first
+func MonthlyBuckets(since, first, until time.Time) []IntegrityBucket {
+	start := since
+	if start.IsZero() {
+		start = first
+	}
+	if start.IsZero() || !start.Before(until) {
+		return nil
+	}
+
+	var buckets []IntegrityBucket
+	bucketSince := since
+	month := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+	for bucketSince.Before(until) {
+		bucketUntil := month.AddDate(0, 1, 0)
+		if bucketUntil.After(until) {
+			bucketUntil = until
+		}
+		buckets = append(buckets, IntegrityBucket{Since: bucketSince, Until: bucketUntil})
+		bucketSince = bucketUntil
+		month = bucketUntil
+	}
+	return buckets
+}
+
+// Completeness returns the share of the provider's commits in the buckets that are stored,
+// a repository without commits upstream is complete
+func Completeness(buckets []IntegrityBucket) float64 {
+	stored, provider := 0, 0
+	for _, b := range buckets {
+		provider += b.ProviderCount
+		stored += min(b.StoredCount, b.ProviderCount)
+	}
+	if provider == 0 {
+		return 1
+	}
+	return float64(stored) / float64(provider)
+}
+
+// OrphanedCommits returns the shas of the commits with a parent missing from commits, commits committed
+// before boundary are skipped as their parents naturally fall outside the tracked window
+func OrphanedCommits(commits []Commit, boundary time.Time) []string {
+	stored := make(map[string]bool, len(commits))
+	for _, c := range commits {
+		stored[c.CommitID] = true
+	}
+
+	var orphans []string
+	for _, c := range commits {
+		if c.CommitDate().Before(boundary) {
+			continue
+		}
+		for _, parent := range c.ParentSHAs {
+			if !stored[parent] {
+				orphans = append(orphans, c.CommitID)
+				break
+			}
+		}
+	}
+	return orphans
+}
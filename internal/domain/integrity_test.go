@@ -0,0 +1,48 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthlyBuckets(t *testing.T) {
+	since := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, []domain.IntegrityBucket{
+		{Since: since, Until: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{Since: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Until: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Since: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Until: until},
+	}, domain.MonthlyBuckets(since, time.Time{}, until))
+
+	// full history starts the first bucket at the first commit and ends it with the month of the oldest stored one
+	buckets := domain.MonthlyBuckets(time.Time{}, since, until)
+	require.Len(t, buckets, 3)
+	require.True(t, buckets[0].Since.IsZero())
+	require.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), buckets[0].Until)
+
+	require.Empty(t, domain.MonthlyBuckets(time.Time{}, time.Time{}, until))
+}
+
+func TestCompleteness(t *testing.T) {
+	require.Equal(t, 1.0, domain.Completeness(nil))
+	require.Equal(t, 0.75, domain.Completeness([]domain.IntegrityBucket{
+		{StoredCount: 2, ProviderCount: 4},
+		{StoredCount: 6, ProviderCount: 4},
+	}))
+}
+
+func TestOrphanedCommits(t *testing.T) {
+	boundary := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	commits := []domain.Commit{
+		{CommitID: "c4", CommittedAt: boundary.AddDate(0, 0, 3), ParentSHAs: []string{"c3", "gone"}},
+		{CommitID: "c3", CommittedAt: boundary.AddDate(0, 0, 2), ParentSHAs: []string{"c2"}},
+		{CommitID: "c2", CommittedAt: boundary.AddDate(0, 0, 1), ParentSHAs: []string{"c1"}},
+		{CommitID: "c1", CommittedAt: boundary.AddDate(0, 0, -1), ParentSHAs: []string{"c0"}},
+	}
+
+	require.Equal(t, []string{"c4"}, domain.OrphanedCommits(commits, boundary))
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	IssueOpen   = "open"
+	IssueClosed = "closed"
+)
+
+const (
+	// ReferenceIssue is a reference to an issue of the git provider, eg #123 or owner/repo#123
+	ReferenceIssue = "issue"
+	// ReferenceTicket is a reference to a ticket of an external tracker, eg JIRA-789
+	ReferenceTicket = "ticket"
+)
+
+// Issue is an issue of a repository
+type Issue struct {
+	RepositoryID uint
+	Number       int
+	Title        string
+	Author       string
+	State        string
+	Labels       []string
+	Assignees    []string
+	URL          string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	ClosedAt     *time.Time
+}
+
+// CommitReference is a reference to an issue or a ticket found in the message of a commit
+type CommitReference struct {
+	RepositoryID   uint
+	RepositoryName string
+	CommitID       string
+	// Kind is ReferenceIssue or ReferenceTicket
+	Kind string
+	// Key identifies the referenced issue or ticket, eg owner/repo#123 or JIRA-789
+	Key string
+	// IssueNumber is the number of the referenced issue when it belongs to the repository of the commit, 0 otherwise
+	IssueNumber int
+	// Closing reports whether the reference is preceded by a closing keyword, eg "fixes #123"
+	Closing bool
+}
+
+// ReferencingCommit is a commit referencing an issue
+type ReferencingCommit struct {
+	Commit  Commit
+	Closing bool
+}
+
+var (
+	issueReferencePattern    = regexp.MustCompile(`(?:^|[^\w/.#-])((?:([\w.-]+/[\w.-]+))?#(\d+))\b`)
+	closingKeywordPattern    = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+$`)
+	closingKeywordLookbehind = 32
+)
+
+// ParseReferences returns the issue and ticket references found in the message of a commit of the repository repoName,
+// a reference found several times is returned once and is closing when any of its occurrences is
+func ParseReferences(message, repoName string, ticketPatterns []*regexp.Regexp) []CommitReference {
+	var refs []CommitReference
+	seen := make(map[string]int)
+
+	add := func(ref CommitReference, start int) {
+		ref.Closing = isClosing(message[:start])
+		key := ref.Kind + " " + ref.Key
+		if i, ok := seen[key]; ok {
+			refs[i].Closing = refs[i].Closing || ref.Closing
+			return
+		}
+		seen[key] = len(refs)
+		refs = append(refs, ref)
+	}
+
+	for _, m := range issueReferencePattern.FindAllStringSubmatchIndex(message, -1) {
+		number, err := strconv.Atoi(message[m[6]:m[7]])
+		if err != nil || number == 0 {
+			continue
+		}
+		owner := repoName
+		if m[4] >= 0 {
+			owner = message[m[4]:m[5]]
+		}
+		ref := CommitReference{Kind: ReferenceIssue, Key: owner + "#" + strconv.Itoa(number)}
+		if strings.EqualFold(owner, repoName) {
+			ref.Key = repoName + "#" + strconv.Itoa(number)
+			ref.IssueNumber = number
+		}
+		add(ref, m[2])
+	}
+
+	for _, pattern := range ticketPatterns {
+		for _, m := range pattern.FindAllStringIndex(message, -1) {
+			if !isWordBoundary(message, m[0], m[1]) {
+				continue
+			}
+			add(CommitReference{Kind: ReferenceTicket, Key: message[m[0]:m[1]]}, m[0])
+		}
+	}
+	return refs
+}
+
+// isClosing reports whether the text preceding a reference ends with a closing keyword
+func isClosing(prefix string) bool {
+	if len(prefix) > closingKeywordLookbehind {
+		prefix = prefix[len(prefix)-closingKeywordLookbehind:]
+	}
+	return closingKeywordPattern.MatchString(prefix)
+}
+
+// isWordBoundary reports whether message[start:end] is not part of a longer word
+func isWordBoundary(message string, start, end int) bool {
+	return (start == 0 || !isWordChar(message[start-1])) && (end == len(message) || !isWordChar(message[end]))
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
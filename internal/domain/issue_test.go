@@ -0,0 +1,31 @@
+package domain_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReferences(t *testing.T) {
+	tickets := []*regexp.Regexp{regexp.MustCompile(`[A-Z][A-Z0-9]+-[0-9]+`)}
+	message := "Fixes #12, refs #7 and kenmobility/other#3\n\nPart of JIRA-789, closes #7 (not #0, abc#5 or PR-1a)"
+
+	refs := domain.ParseReferences(message, "kenmobility/git-api-service", tickets)
+
+	require.Equal(t, []domain.CommitReference{
+		{Kind: domain.ReferenceIssue, Key: "kenmobility/git-api-service#12", IssueNumber: 12, Closing: true},
+		{Kind: domain.ReferenceIssue, Key: "kenmobility/git-api-service#7", IssueNumber: 7, Closing: true},
+		{Kind: domain.ReferenceIssue, Key: "kenmobility/other#3"},
+		{Kind: domain.ReferenceTicket, Key: "JIRA-789"},
+	}, refs)
+}
+
+func TestParseReferencesSameRepository(t *testing.T) {
+	refs := domain.ParseReferences("resolved: Kenmobility/Git-Api-Service#4", "kenmobility/git-api-service", nil)
+
+	require.Equal(t, []domain.CommitReference{
+		{Kind: domain.ReferenceIssue, Key: "kenmobility/git-api-service#4", IssueNumber: 4, Closing: true},
+	}, refs)
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "time"
+
+// RepoMetadataSnapshot holds the provider metadata of a repository as it was at CapturedAt
+type RepoMetadataSnapshot struct {
+	RepositoryID    uint
+	StarsCount      int
+	ForksCount      int
+	WatchersCount   int
+	OpenIssuesCount int
+	Language        string
+	CapturedAt      time.Time
+}
+
+// NewRepoMetadataSnapshot captures the current metadata of a repository
+func NewRepoMetadataSnapshot(r RepoMetadata, capturedAt time.Time) RepoMetadataSnapshot {
+	return RepoMetadataSnapshot{
+		RepositoryID:    r.ID,
+		StarsCount:      r.StarsCount,
+		ForksCount:      r.ForksCount,
+		WatchersCount:   r.WatchersCount,
+		OpenIssuesCount: r.OpenIssuesCount,
+		Language:        r.Language,
+		CapturedAt:      capturedAt,
+	}
+}
+
+// SnapshotInterval is the period a metadata history is downsampled to
+type SnapshotInterval string
+
+const (
+	SnapshotIntervalHour  SnapshotInterval = "hour"
+	SnapshotIntervalDay   SnapshotInterval = "day"
+	SnapshotIntervalWeek  SnapshotInterval = "week"
+	SnapshotIntervalMonth SnapshotInterval = "month"
+)
+
+// IsValid reports whether the interval is one of the supported intervals
+func (i SnapshotInterval) IsValid() bool {
+	switch i {
+	case SnapshotIntervalHour, SnapshotIntervalDay, SnapshotIntervalWeek, SnapshotIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// Start returns the start of the interval t falls in, weeks start on Monday
+func (i SnapshotInterval) Start(t time.Time) time.Time {
+	switch i {
+	case SnapshotIntervalHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case SnapshotIntervalWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case SnapshotIntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// DownsampleSnapshots keeps the latest snapshot of every interval of snapshots ordered by capture time,
+// each kept snapshot is dated at the start of its interval
+func DownsampleSnapshots(snapshots []RepoMetadataSnapshot, interval SnapshotInterval) []RepoMetadataSnapshot {
+	points := make([]RepoMetadataSnapshot, 0, len(snapshots))
+	for _, s := range snapshots {
+		s.CapturedAt = interval.Start(s.CapturedAt)
+		if n := len(points); n > 0 && points[n-1].CapturedAt.Equal(s.CapturedAt) {
+			points[n-1] = s
+			continue
+		}
+		points = append(points, s)
+	}
+	return points
+}
@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotIntervalStart(t *testing.T) {
+	// a Sunday afternoon
+	at := time.Date(2024, 3, 10, 15, 42, 0, 0, time.UTC)
+
+	require.Equal(t, time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC), domain.SnapshotIntervalHour.Start(at))
+	require.Equal(t, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), domain.SnapshotIntervalDay.Start(at))
+	require.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), domain.SnapshotIntervalWeek.Start(at))
+	require.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), domain.SnapshotIntervalMonth.Start(at))
+	require.False(t, domain.SnapshotInterval("year").IsValid())
+}
+
+func TestDownsampleSnapshots(t *testing.T) {
+	day := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	snapshots := []domain.RepoMetadataSnapshot{
+		{StarsCount: 10, CapturedAt: day.Add(2 * time.Hour)},
+		{StarsCount: 12, CapturedAt: day.Add(8 * time.Hour)},
+		{StarsCount: 15, CapturedAt: day.Add(26 * time.Hour)},
+	}
+
+	require.Equal(t, []domain.RepoMetadataSnapshot{
+		{StarsCount: 12, CapturedAt: day},
+		{StarsCount: 15, CapturedAt: day.AddDate(0, 0, 1)},
+	}, domain.DownsampleSnapshots(snapshots, domain.SnapshotIntervalDay))
+
+	require.Len(t, domain.DownsampleSnapshots(snapshots, domain.SnapshotIntervalHour), 3)
+}
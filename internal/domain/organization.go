@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// OwnerRepo is a repository listed for an organization or user, with the listing fields the import filters on
+type OwnerRepo struct {
+	Metadata RepoMetadata
+	Fork     bool
+	Topics   []string
+}
+
+// OrgImportFilter selects the repositories of an organization or user to track, the zero filter selects
+// every repository that is neither a fork nor archived
+type OrgImportFilter struct {
+	IncludeForks    bool
+	IncludeArchived bool
+	Topic           string
+	Language        string
+	// NamePattern is matched against the repository name without its owner, empty matches every name
+	NamePattern string
+}
+
+// Matches reports whether a listed repository is selected by the filter and the reason it is not,
+// namePattern is the compiled NamePattern of the filter
+func (f OrgImportFilter) Matches(r OwnerRepo, namePattern *regexp.Regexp) (bool, string) {
+	if r.Metadata.Disabled {
+		return false, "repository is disabled"
+	}
+	if r.Fork && !f.IncludeForks {
+		return false, "repository is a fork"
+	}
+	if r.Metadata.Archived && !f.IncludeArchived {
+		return false, "repository is archived"
+	}
+	if f.Language != "" && !strings.EqualFold(r.Metadata.Language, f.Language) {
+		return false, "language does not match"
+	}
+	if f.Topic != "" && !hasTopic(r.Topics, f.Topic) {
+		return false, "topic does not match"
+	}
+	if namePattern != nil {
+		_, name, _ := strings.Cut(r.Metadata.Name, "/")
+		if !namePattern.MatchString(name) {
+			return false, "name does not match"
+		}
+	}
+	return true, ""
+}
+
+func hasTopic(topics []string, topic string) bool {
+	for _, t := range topics {
+		if strings.EqualFold(t, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// OrgMembership is an organization or user whose repositories are watched, the repositories added
+// upstream that match its filter are tracked automatically
+type OrgMembership struct {
+	ID           uint
+	Owner        string
+	Filter       OrgImportFilter
+	LastSyncedAt *time.Time
+	CreatedAt    time.Time
+}
+
+const (
+	OrgImportAdded        = "added"
+	OrgImportAlreadyAdded = "already_added"
+	OrgImportSkipped      = "skipped"
+	OrgImportFailed       = "failed"
+)
+
+// OrgImportResult is the outcome of importing a listed repository
+type OrgImportResult struct {
+	Name   string
+	Status string
+	Reason string
+	// Repository is the tracked repository when it was added
+	Repository *RepoMetadata
+}
+
+const (
+	OrgImportStatusRunning   = "running"
+	OrgImportStatusCompleted = "completed"
+	OrgImportStatusFailed    = "failed"
+)
+
+// OrgImport is the background import of the repositories of an organization or user, Results grows as the
+// listed repositories are imported
+type OrgImport struct {
+	ID             uint
+	Owner          string
+	Filter         OrgImportFilter
+	SyncMembership bool
+	Status         string
+	Error          string
+	Results        []OrgImportResult
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CompletedAt    *time.Time
+}
+
+// Count returns the number of imported repositories with a status
+func (i OrgImport) Count(status string) int {
+	count := 0
+	for _, r := range i.Results {
+		if r.Status == status {
+			count++
+		}
+	}
+	return count
+}
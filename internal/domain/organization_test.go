@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgImportFilterMatches(t *testing.T) {
+	repo := domain.OwnerRepo{
+		Metadata: domain.RepoMetadata{Name: "owner/api-service", Language: "Go"},
+		Topics:   []string{"backend", "grpc"},
+	}
+
+	ok, _ := domain.OrgImportFilter{}.Matches(repo, nil)
+	require.True(t, ok)
+
+	ok, _ = domain.OrgImportFilter{Language: "go", Topic: "GRPC"}.Matches(repo, regexp.MustCompile("^api-"))
+	require.True(t, ok)
+
+	ok, reason := domain.OrgImportFilter{}.Matches(repo, regexp.MustCompile("^owner/"))
+	require.False(t, ok)
+	require.Equal(t, "name does not match", reason)
+
+	ok, _ = domain.OrgImportFilter{Topic: "frontend"}.Matches(repo, nil)
+	require.False(t, ok)
+
+	fork := repo
+	fork.Fork = true
+	ok, _ = domain.OrgImportFilter{}.Matches(fork, nil)
+	require.False(t, ok)
+	ok, _ = domain.OrgImportFilter{IncludeForks: true}.Matches(fork, nil)
+	require.True(t, ok)
+
+	archived := repo
+	archived.Metadata.Archived = true
+	ok, _ = domain.OrgImportFilter{}.Matches(archived, nil)
+	require.False(t, ok)
+	ok, _ = domain.OrgImportFilter{IncludeArchived: true}.Matches(archived, nil)
+	require.True(t, ok)
+}
@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OwnershipPolicy tunes how ownership is inferred from the file changes of a repository and which owners make it
+// to a suggested CODEOWNERS file
+type OwnershipPolicy struct {
+	// HalfLife is the age at which a change counts half as much towards ownership, zero disables the decay
+	HalfLife time.Duration
+	// MinShare is the share of the weighted changes of a directory an author needs to own it
+	MinShare float64
+	// MaxOwners caps the owners listed for a directory, zero lists them all
+	MaxOwners int
+	// MinChanges is the number of commits a directory needs to get a CODEOWNERS entry
+	MinChanges int
+	// Handles maps lower cased author emails or names to their GitHub handles, an author without a mapping is
+	// suggested under their platform login
+	Handles map[string]string
+}
+
+// Owner is an author of the changes of a directory
+type Owner struct {
+	// Author is the name of the author in their latest change
+	Author    string
+	AuthorKey string
+	// Handle is the GitHub handle of the author, empty when it is neither mapped nor known from their login
+	Handle string
+	// Changes is the number of commits of the author that changed the directory
+	Changes int
+	// Lines is the number of lines the author added and deleted in the directory
+	Lines int
+	// Weight adds up the lines of each change of the author, at least one per change, decayed by its age
+	Weight float64
+	// Share is the weight of the author relative to the weight of every author of the directory
+	Share float64
+}
+
+// AuthorChanges is the changes of a directory of a repository by one of its authors
+type AuthorChanges struct {
+	// Dir is the directory, empty for the root of the repository
+	Dir string
+	// Author, AuthorEmail and AuthorLogin are the identity of the author in their latest change of the directory
+	Author      string
+	AuthorEmail string
+	AuthorLogin string
+	AuthorKey   string
+	// Commits is the number of commits of the author that changed the directory
+	Commits int
+	// Lines is the number of lines the author added and deleted in the directory
+	Lines int
+	// Weight adds up the lines of each change of the author, at least one per change, decayed by its age
+	Weight        float64
+	LastChangedAt time.Time
+}
+
+// Ownership is the owners of a directory of a repository, highest share first
+type Ownership struct {
+	// Path is the directory, empty for the root of the repository
+	Path    string
+	Changes int
+	Owners  []Owner
+}
+
+// CleanOwnershipPath normalizes a directory path to the form ownership is computed for, without leading and trailing
+// slashes and empty for the root of the repository
+func CleanOwnershipPath(dir string) string {
+	dir = strings.Trim(dir, "/")
+	if dir == "" {
+		return ""
+	}
+	return path.Clean(dir)
+}
+
+// ComputeOwnership computes the ownership of every directory changed by the authors, the root included, sorted by path
+func ComputeOwnership(changes []AuthorChanges, policy OwnershipPolicy) []Ownership {
+	byDir := make(map[string]*Ownership)
+	// latest is the latest changes of each author, their owner entries show their name and handle from it
+	latest := make(map[string]AuthorChanges)
+	for _, c := range changes {
+		if l, ok := latest[c.AuthorKey]; !ok || c.LastChangedAt.After(l.LastChangedAt) {
+			latest[c.AuthorKey] = c
+		}
+
+		o, ok := byDir[c.Dir]
+		if !ok {
+			o = &Ownership{Path: c.Dir}
+			byDir[c.Dir] = o
+		}
+		// a commit has a single author, so the commits of the authors of a directory add up to its own
+		o.Changes += c.Commits
+		o.Owners = append(o.Owners, Owner{AuthorKey: c.AuthorKey, Changes: c.Commits, Lines: c.Lines, Weight: c.Weight})
+	}
+
+	ownerships := make([]Ownership, 0, len(byDir))
+	for _, o := range byDir {
+		var total float64
+		for _, owner := range o.Owners {
+			total += owner.Weight
+		}
+		for i := range o.Owners {
+			owner := &o.Owners[i]
+			owner.Author, owner.Handle = latest[owner.AuthorKey].Author, policy.handle(latest[owner.AuthorKey])
+			if total > 0 {
+				owner.Share = owner.Weight / total
+			}
+		}
+		sort.Slice(o.Owners, func(i, j int) bool {
+			if o.Owners[i].Weight != o.Owners[j].Weight {
+				return o.Owners[i].Weight > o.Owners[j].Weight
+			}
+			return o.Owners[i].Author < o.Owners[j].Author
+		})
+		ownerships = append(ownerships, *o)
+	}
+	sort.Slice(ownerships, func(i, j int) bool { return ownerships[i].Path < ownerships[j].Path })
+	return ownerships
+}
+
+// RenderCodeowners renders a suggested CODEOWNERS file from the ownership of the directories of a repository, sorted
+// by path, listing for each directory with enough changes its owners with enough share and a handle. A directory
+// owned by the same handles as its closest listed parent is left to inherit them. It also returns the authors that
+// would have been listed but have no handle.
+func RenderCodeowners(ownerships []Ownership, policy OwnershipPolicy) (string, []string) {
+	var b strings.Builder
+	b.WriteString("# Suggested from the commit history, review before committing\n")
+
+	listed := make(map[string]string)
+	unmapped := make(map[string]bool)
+	for _, o := range ownerships {
+		if o.Changes < policy.MinChanges {
+			continue
+		}
+
+		var handles []string
+		for _, owner := range o.Owners {
+			if owner.Share < policy.MinShare || (policy.MaxOwners > 0 && len(handles) >= policy.MaxOwners) {
+				break
+			}
+			if owner.Handle == "" {
+				unmapped[owner.Author] = true
+				continue
+			}
+			handles = append(handles, owner.Handle)
+		}
+		if len(handles) == 0 {
+			continue
+		}
+
+		entry := strings.Join(handles, " ")
+		if inherited, ok := closestListedParent(listed, o.Path); ok && inherited == entry {
+			continue
+		}
+		listed[o.Path] = entry
+
+		pattern := "*"
+		if o.Path != "" {
+			pattern = "/" + o.Path + "/"
+		}
+		b.WriteString(pattern + " " + entry + "\n")
+	}
+
+	authors := make([]string, 0, len(unmapped))
+	for author := range unmapped {
+		authors = append(authors, author)
+	}
+	sort.Strings(authors)
+	return b.String(), authors
+}
+
+// closestListedParent returns the owners listed for the closest parent of a directory
+func closestListedParent(listed map[string]string, dir string) (string, bool) {
+	for dir != "" {
+		dir = path.Dir(dir)
+		if dir == "." {
+			dir = ""
+		}
+		if entry, ok := listed[dir]; ok {
+			return entry, true
+		}
+	}
+	return "", false
+}
+
+// handle returns the GitHub handle of an author, mapped from their email or name, else their login, prefixed with @
+// unless it is an email, empty when it is neither mapped nor known
+func (p OwnershipPolicy) handle(t AuthorChanges) string {
+	h := p.Handles[strings.ToLower(t.AuthorEmail)]
+	if h == "" {
+		h = p.Handles[strings.ToLower(strings.Join(strings.Fields(t.Author), " "))]
+	}
+	if h == "" {
+		h = t.AuthorLogin
+	}
+	if h == "" || strings.Contains(h, "@") {
+		return h
+	}
+	return "@" + h
+}
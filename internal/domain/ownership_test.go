@@ -0,0 +1,71 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeOwnership(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	jane := domain.AuthorChanges{Author: "Jane Doe", AuthorKey: "email:jane@example.com", AuthorEmail: "jane@example.com", LastChangedAt: now}
+	john := domain.AuthorChanges{Author: "John Roe", AuthorKey: "login:jroe", AuthorLogin: "jroe", LastChangedAt: now.AddDate(0, 0, -90)}
+	johnny := domain.AuthorChanges{Author: "Johnny Roe", AuthorKey: "login:jroe", AuthorLogin: "jroe", LastChangedAt: now}
+	changes := func(c domain.AuthorChanges, dir string, commits, lines int, weight float64) domain.AuthorChanges {
+		c.Dir, c.Commits, c.Lines, c.Weight = dir, commits, lines, weight
+		return c
+	}
+	policy := domain.OwnershipPolicy{Handles: map[string]string{"jane@example.com": "jane"}}
+
+	ownerships := domain.ComputeOwnership([]domain.AuthorChanges{
+		changes(jane, "pkg/api", 1, 40, 40),
+		changes(john, "pkg/api", 1, 80, 40),
+		changes(jane, "", 1, 40, 40),
+		changes(johnny, "", 3, 80, 42),
+	}, policy)
+
+	require.Equal(t, []string{"", "pkg/api"}, []string{ownerships[0].Path, ownerships[1].Path})
+
+	api := ownerships[1]
+	require.Equal(t, 2, api.Changes)
+	require.Equal(t, "Jane Doe", api.Owners[0].Author)
+	require.Equal(t, "@jane", api.Owners[0].Handle)
+	require.InDelta(t, 0.5, api.Owners[0].Share, 0.0001)
+	require.Equal(t, 80, api.Owners[1].Lines)
+	// an author without a mapping is suggested under their login, and named after their latest change
+	require.Equal(t, "@jroe", api.Owners[1].Handle)
+	require.Equal(t, "Johnny Roe", api.Owners[1].Author)
+
+	// the commits of the authors of a directory add up to its own
+	root := ownerships[0]
+	require.Equal(t, 4, root.Changes)
+	require.Equal(t, "login:jroe", root.Owners[0].AuthorKey)
+	require.Equal(t, 3, root.Owners[0].Changes)
+}
+
+func TestRenderCodeowners(t *testing.T) {
+	ownerships := []domain.Ownership{
+		{Path: "", Changes: 10, Owners: []domain.Owner{
+			{Author: "Jane Doe", Handle: "@jane", Share: 0.6},
+			{Author: "John Roe", Share: 0.3},
+			{Author: "Max Poe", Handle: "@max", Share: 0.1},
+		}},
+		{Path: "docs", Changes: 2, Owners: []domain.Owner{{Author: "Max Poe", Handle: "@max", Share: 1}}},
+		{Path: "pkg", Changes: 8, Owners: []domain.Owner{{Author: "Jane Doe", Handle: "@jane", Share: 0.9}}},
+		{Path: "pkg/api", Changes: 5, Owners: []domain.Owner{
+			{Author: "Max Poe", Handle: "@max", Share: 0.5},
+			{Author: "Jane Doe", Handle: "@jane", Share: 0.4},
+			{Author: "Ann Loe", Handle: "ann@example.com", Share: 0.1},
+		}},
+	}
+	policy := domain.OwnershipPolicy{MinShare: 0.2, MaxOwners: 2, MinChanges: 5}
+
+	content, unmapped := domain.RenderCodeowners(ownerships, policy)
+
+	require.Equal(t, "# Suggested from the commit history, review before committing\n"+
+		"* @jane\n"+
+		"/pkg/api/ @max @jane\n", content)
+	require.Equal(t, []string{"John Roe"}, unmapped)
+}
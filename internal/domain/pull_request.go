@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+const (
+	PullRequestOpen   = "open"
+	PullRequestClosed = "closed"
+	PullRequestMerged = "merged"
+)
+
+// IsValidPullRequestState reports whether state is one of the pull request states
+func IsValidPullRequestState(state string) bool {
+	return state == PullRequestOpen || state == PullRequestClosed || state == PullRequestMerged
+}
+
+// PullRequest is a pull request of a repository
+type PullRequest struct {
+	RepositoryID uint
+	Number       int
+	Title        string
+	Author       string
+	// State is open, closed without being merged or merged
+	State          string
+	BaseRef        string
+	HeadRef        string
+	MergeCommitSHA string
+	ReviewCount    int
+	Labels         []string
+	URL            string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	MergedAt       *time.Time
+	ClosedAt       *time.Time
+}
+
+// PullRequestState returns the state of a pull request from its provider state and merge date
+func PullRequestState(providerState string, mergedAt *time.Time) string {
+	if mergedAt != nil {
+		return PullRequestMerged
+	}
+	if providerState == PullRequestOpen {
+		return PullRequestOpen
+	}
+	return PullRequestClosed
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+const (
+	ReindexStatusRunning   = "running"
+	ReindexStatusCompleted = "completed"
+	ReindexStatusFailed    = "failed"
+)
+
+// ReindexJob rebuilds the commit history of a repository in the background, it records how many commits the
+// reindex added, removed and changed once completed
+type ReindexJob struct {
+	ID             uint
+	RepositoryID   uint
+	Status         string
+	Page           int
+	CommitsFetched int
+	Added          int
+	Removed        int
+	Changed        int
+	Error          string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CompletedAt    *time.Time
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// Tag is a tag of a repository and the commit it points to
+type Tag struct {
+	RepositoryID uint
+	Name         string
+	SHA          string
+}
+
+// Release is a release of a repository published from a tag
+type Release struct {
+	RepositoryID uint
+	Tag          string
+	// TagSHA is the commit the tag of the release points to, empty while the tag is not ingested
+	TagSHA      string
+	Name        string
+	Notes       string
+	Prerelease  bool
+	Draft       bool
+	PublishedAt *time.Time
+	// CommitCount is the number of commits first released in the release
+	CommitCount int
+	// CommitsSynced is set once the commits first released in the release are recorded
+	CommitsSynced bool
+}
+
+// FirstReleases maps the id of every commit reachable from the tag of a published release to the tag of the
+// earliest such release, walking the parents of the commits; commits missing from commits end the walk. The walk of
+// a release also starts from the parents of the releasedChildren first released in it, the released commits whose
+// parents were stored after their release was synced, eg older commits backfilled or commits of a newly tracked branch.
+func FirstReleases(commits []Commit, releases []Release, releasedChildren []Commit) map[string]string {
+	byID := make(map[string]Commit, len(commits))
+	for _, c := range commits {
+		byID[c.CommitID] = c
+	}
+
+	parentsByTag := make(map[string][]string)
+	for _, c := range releasedChildren {
+		parentsByTag[c.FirstReleasedIn] = append(parentsByTag[c.FirstReleasedIn], c.ParentSHAs...)
+	}
+
+	published := make([]Release, 0, len(releases))
+	for _, r := range releases {
+		if r.Draft || r.PublishedAt == nil || r.TagSHA == "" {
+			continue
+		}
+		published = append(published, r)
+	}
+	sort.SliceStable(published, func(i, j int) bool { return published[i].PublishedAt.Before(*published[j].PublishedAt) })
+
+	first := make(map[string]string, len(commits))
+	for _, r := range published {
+		// the ancestors of a commit released earlier were released at the latest with it
+		queue := append([]string{r.TagSHA}, parentsByTag[r.Tag]...)
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+
+			c, ok := byID[id]
+			if !ok {
+				continue
+			}
+			if _, released := first[id]; released {
+				continue
+			}
+			first[id] = r.Tag
+			queue = append(queue, c.ParentSHAs...)
+		}
+	}
+	return first
+}
@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstReleases(t *testing.T) {
+	// a <- b <- c <- d, with c and d on a release branch and e merged from b
+	commits := []domain.Commit{
+		{CommitID: "a"},
+		{CommitID: "b", ParentSHAs: []string{"a"}},
+		{CommitID: "c", ParentSHAs: []string{"b"}},
+		{CommitID: "d", ParentSHAs: []string{"c"}},
+		{CommitID: "e", ParentSHAs: []string{"b"}},
+	}
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := jan.AddDate(0, 1, 0)
+	mar := jan.AddDate(0, 2, 0)
+
+	releases := []domain.Release{
+		{Tag: "v2.0", TagSHA: "d", PublishedAt: &mar},
+		{Tag: "v1.0", TagSHA: "b", PublishedAt: &jan},
+		{Tag: "v1.1", TagSHA: "c", PublishedAt: &feb},
+		{Tag: "v3.0-draft", TagSHA: "e", Draft: true},
+	}
+
+	first := domain.FirstReleases(commits, releases, nil)
+
+	require.Equal(t, map[string]string{"a": "v1.0", "b": "v1.0", "c": "v1.1", "d": "v2.0"}, first)
+}
+
+func TestFirstReleasesOfCommitsStoredAfterTheirRelease(t *testing.T) {
+	// a and b were backfilled after v1.0, released from c, was synced; e was merged from a in v2.0, released from f
+	commits := []domain.Commit{
+		{CommitID: "a"},
+		{CommitID: "b", ParentSHAs: []string{"a"}},
+		{CommitID: "e", ParentSHAs: []string{"a"}},
+	}
+	releasedChildren := []domain.Commit{
+		{CommitID: "c", ParentSHAs: []string{"b"}, FirstReleasedIn: "v1.0"},
+		{CommitID: "f", ParentSHAs: []string{"d", "e"}, FirstReleasedIn: "v2.0"},
+	}
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := jan.AddDate(0, 1, 0)
+
+	releases := []domain.Release{
+		{Tag: "v2.0", TagSHA: "g", PublishedAt: &feb},
+		{Tag: "v1.0", TagSHA: "c", PublishedAt: &jan},
+	}
+
+	first := domain.FirstReleases(commits, releases, releasedChildren)
+
+	require.Equal(t, map[string]string{"a": "v1.0", "b": "v1.0", "e": "v2.0"}, first)
+}
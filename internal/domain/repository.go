@@ -1,10 +1,15 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
 type RepoMetadata struct {
+	// ID is the internal identifier of the repository, it is never exposed by the API
+	ID uint
+	// ProviderID is the numeric identifier of the repository at the git provider, it survives renames and transfers
+	ProviderID        int64
 	PublicID          string
 	Name              string
 	Description       string
@@ -19,4 +24,146 @@ type RepoMetadata struct {
 	LastFetchedCommit string
 	LastFetchedPage   int32
 	IsFetching        bool
+	Paused            bool
+	// TrackSince is the start of the tracked commit window, nil tracks the full history
+	TrackSince *time.Time
+	// TrackUntil is the end of the tracked commit window, nil tracks up to the latest commit
+	TrackUntil *time.Time
+	// Branch is the branch the commit history is fetched from, empty fetches the default branch
+	Branch string
+	// DefaultBranch is the default branch of the repository at the git provider
+	DefaultBranch string
+	// TrackedBranches are the names or glob patterns, eg release/*, of the branches tracked besides Branch
+	TrackedBranches []string
+	FetchInterval   time.Duration
+	CommitsPerPage  int
+	// Aliases are the previous names of the repository, oldest first
+	Aliases []RepoAlias
+	// Archived, Disabled, Visibility and PushedAt are the upstream lifecycle fields of the repository
+	Archived   bool
+	Disabled   bool
+	Visibility string
+	PushedAt   *time.Time
+	// UpstreamState is the lifecycle state of the repository at the git provider, empty for an active repository
+	UpstreamState          string
+	UpstreamStateChangedAt *time.Time
+	// IdentitiesPending is set while commits stored before author emails and logins were recorded await a reindex
+	IdentitiesPending bool
+	// MailmapETag identifies the content of the .mailmap file last applied to the contributors of the repository
+	MailmapETag string
+}
+
+const (
+	// UpstreamStateActive repositories are polled for new commits
+	UpstreamStateActive = "active"
+	// UpstreamStateArchived repositories are read-only upstream, their metadata is still refreshed but no commits are polled
+	UpstreamStateArchived = "archived"
+	// UpstreamStateGone repositories were deleted, made private, disabled or taken down upstream, only their
+	// metadata is checked, at the metadata refresh interval, to detect their restoration
+	UpstreamStateGone = "gone"
+)
+
+// IsValidUpstreamState reports whether state is one of the upstream lifecycle states
+func IsValidUpstreamState(state string) bool {
+	return state == UpstreamStateActive || state == UpstreamStateArchived || state == UpstreamStateGone
+}
+
+// State returns the upstream lifecycle state of the repository
+func (r RepoMetadata) State() string {
+	if r.UpstreamState == "" {
+		return UpstreamStateActive
+	}
+	return r.UpstreamState
+}
+
+// UpstreamStateOf returns the lifecycle state of a repository from its upstream metadata, a disabled
+// repository cannot be read and is gone
+func UpstreamStateOf(upstream RepoMetadata) string {
+	switch {
+	case upstream.Disabled:
+		return UpstreamStateGone
+	case upstream.Archived:
+		return UpstreamStateArchived
+	}
+	return UpstreamStateActive
+}
+
+const (
+	RepoAliasRenamed     = "renamed"
+	RepoAliasTransferred = "transferred"
+)
+
+// RepoAlias is a previous name of a repository renamed or transferred upstream
+type RepoAlias struct {
+	Name      string
+	Kind      string
+	CreatedAt time.Time
+}
+
+// NewRepoAlias returns the alias left behind by a repository moving from oldName to newName,
+// a change of owner is a transfer while a change of the repository name alone is a rename
+func NewRepoAlias(oldName, newName string) RepoAlias {
+	kind := RepoAliasRenamed
+	oldOwner, _, _ := strings.Cut(oldName, "/")
+	newOwner, _, _ := strings.Cut(newName, "/")
+	if !strings.EqualFold(oldOwner, newOwner) {
+		kind = RepoAliasTransferred
+	}
+	return RepoAlias{Name: oldName, Kind: kind, CreatedAt: time.Now()}
+}
+
+// TrackingWindow returns the since and until dates commits are fetched within,
+// a zero time means the window is unbounded on that side
+func (r RepoMetadata) TrackingWindow() (since time.Time, until time.Time) {
+	if r.TrackSince != nil {
+		since = *r.TrackSince
+	}
+	if r.TrackUntil != nil {
+		until = *r.TrackUntil
+	}
+	return since, until
+}
+
+// TrackingOptions holds the per repository tracking settings set when adding or updating a repository,
+// unset fields leave the current setting unchanged
+type TrackingOptions struct {
+	Since         *time.Time
+	FullHistory   bool
+	Until         *time.Time
+	OpenEnded     bool
+	Branch        *string
+	Branches      *[]string
+	FetchInterval time.Duration
+	PerPage       int
+}
+
+// Apply sets the tracking options on a repository
+func (o TrackingOptions) Apply(r *RepoMetadata) {
+	if o.FullHistory {
+		r.TrackSince = nil
+	} else if o.Since != nil {
+		r.TrackSince = o.Since
+	}
+
+	if o.OpenEnded {
+		r.TrackUntil = nil
+	} else if o.Until != nil {
+		r.TrackUntil = o.Until
+	}
+
+	if o.Branch != nil {
+		r.Branch = *o.Branch
+	}
+
+	if o.Branches != nil {
+		r.TrackedBranches = *o.Branches
+	}
+
+	if o.FetchInterval > 0 {
+		r.FetchInterval = o.FetchInterval
+	}
+
+	if o.PerPage > 0 {
+		r.CommitsPerPage = o.PerPage
+	}
 }
@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+// Stargazer is a user who starred a repository
+type Stargazer struct {
+	RepositoryID uint
+	Login        string
+	StarredAt    time.Time
+}
+
+// StarCount is the number of stars given to a repository in the week starting at Week, or the
+// number of stars it had at the end of that week in a cumulative series
+type StarCount struct {
+	Week  time.Time
+	Stars int
+}
+
+// StarHistory is the star growth curve of a repository, Total counts the stored stars and StarsCount
+// the stars the repository has upstream
+type StarHistory struct {
+	Total      int
+	StarsCount int
+	Partial    bool
+	Weekly     []StarCount
+	Cumulative []StarCount
+}
+
+// StarGrowth is the number of stars a repository gained since a date
+type StarGrowth struct {
+	Repository RepoMetadata
+	Gained     int
+	Total      int
+}
+
+// GrowthRate returns the stars gained relative to the stars the repository had before, a repository
+// without earlier stars grows by its gained stars
+func (g StarGrowth) GrowthRate() float64 {
+	before := g.Total - g.Gained
+	if before <= 0 {
+		return float64(g.Gained)
+	}
+	return float64(g.Gained) / float64(before)
+}
+
+// WeeklyStars fills the weeks without stars between the first counted week and the week of until with
+// zero counts, weekly holds the counts of the weeks with stars ordered by week
+func WeeklyStars(weekly []StarCount, until time.Time) []StarCount {
+	if len(weekly) == 0 {
+		return []StarCount{}
+	}
+
+	last := SnapshotIntervalWeek.Start(until)
+	if end := weekly[len(weekly)-1].Week; end.After(last) {
+		last = end
+	}
+
+	series := make([]StarCount, 0, len(weekly))
+	next := 0
+	for week := SnapshotIntervalWeek.Start(weekly[0].Week); !week.After(last); week = week.AddDate(0, 0, 7) {
+		count := StarCount{Week: week}
+		for next < len(weekly) && !weekly[next].Week.After(week) {
+			count.Stars += weekly[next].Stars
+			next++
+		}
+		series = append(series, count)
+	}
+	return series
+}
+
+// CumulativeStars returns the number of stars a repository had at the end of every week of a weekly series
+func CumulativeStars(weekly []StarCount) []StarCount {
+	series := make([]StarCount, 0, len(weekly))
+	total := 0
+	for _, w := range weekly {
+		total += w.Stars
+		series = append(series, StarCount{Week: w.Week, Stars: total})
+	}
+	return series
+}
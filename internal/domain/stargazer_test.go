@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeeklyStars(t *testing.T) {
+	// Mondays
+	first := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	third := first.AddDate(0, 0, 14)
+
+	weekly := domain.WeeklyStars([]domain.StarCount{
+		{Week: first, Stars: 3},
+		{Week: third, Stars: 2},
+	}, third.AddDate(0, 0, 9))
+
+	require.Equal(t, []domain.StarCount{
+		{Week: first, Stars: 3},
+		{Week: first.AddDate(0, 0, 7), Stars: 0},
+		{Week: third, Stars: 2},
+		{Week: third.AddDate(0, 0, 7), Stars: 0},
+	}, weekly)
+
+	require.Equal(t, []int{3, 3, 5, 5}, starsOf(domain.CumulativeStars(weekly)))
+	require.Empty(t, domain.WeeklyStars(nil, third))
+}
+
+func TestStarGrowthRate(t *testing.T) {
+	require.Equal(t, 0.5, domain.StarGrowth{Gained: 10, Total: 30}.GrowthRate())
+	require.Equal(t, 4.0, domain.StarGrowth{Gained: 4, Total: 4}.GrowthRate())
+}
+
+func starsOf(series []domain.StarCount) []int {
+	stars := make([]int, 0, len(series))
+	for _, s := range series {
+		stars = append(stars, s.Stars)
+	}
+	return stars
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// maxKnownHeads is the number of previous head shas a sync cursor remembers
+const maxKnownHeads = 20
+
+// SyncCursor is the high-water mark of the live reconciliation of a repository
+type SyncCursor struct {
+	RepositoryID uint
+	// HeadSHA is the head of the tracked branch at the last reconciliation
+	HeadSHA string
+	// HighWaterMark is the newest committer date of the stored commits
+	HighWaterMark time.Time
+	// KnownHeads holds the latest head shas, newest first, reaching any of them ends a reconciliation
+	KnownHeads []string
+	UpdatedAt  time.Time
+}
+
+// IsKnownHead reports whether sha was a head of the tracked branch at a previous reconciliation
+func (c SyncCursor) IsKnownHead(sha string) bool {
+	for _, head := range c.KnownHeads {
+		if head == sha {
+			return true
+		}
+	}
+	return false
+}
+
+// Advance moves the cursor to a new head and raises the high-water mark to the given committer date
+func (c *SyncCursor) Advance(headSHA string, committedAt time.Time) {
+	if committedAt.After(c.HighWaterMark) {
+		c.HighWaterMark = committedAt
+	}
+
+	if headSHA == "" || headSHA == c.HeadSHA {
+		return
+	}
+	c.HeadSHA = headSHA
+
+	heads := []string{headSHA}
+	for _, head := range c.KnownHeads {
+		if head != headSHA && len(heads) < maxKnownHeads {
+			heads = append(heads, head)
+		}
+	}
+	c.KnownHeads = heads
+}
@@ -0,0 +1,126 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type BackfillStatusResponseDto struct {
+	CoveredRanges []SyncRangeDto   `json:"covered_ranges"`
+	Jobs          []BackfillJobDto `json:"jobs"`
+}
+
+// SyncRangeDto holds a fetched commit date range, a null since means the range starts at the first commit
+type SyncRangeDto struct {
+	Since *time.Time `json:"since"`
+	Until time.Time  `json:"until"`
+}
+
+type BackfillJobDto struct {
+	Id           uint       `json:"id"`
+	Since        *time.Time `json:"since"`
+	Until        time.Time  `json:"until"`
+	Status       string     `json:"status"`
+	Page         int        `json:"page"`
+	CommitsSaved int        `json:"commits_saved"`
+	Error        string     `json:"error,omitempty"`
+	Attempts     int        `json:"attempts"`
+	RetryAt      *time.Time `json:"retry_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at"`
+}
+
+// BackfillStatusResponse maps the fetched ranges and backfill jobs of a repository to their dto response
+func BackfillStatusResponse(ranges []domain.SyncRange, jobs []domain.BackfillJob) BackfillStatusResponseDto {
+	resp := BackfillStatusResponseDto{
+		CoveredRanges: make([]SyncRangeDto, 0, len(ranges)),
+		Jobs:          make([]BackfillJobDto, 0, len(jobs)),
+	}
+
+	for _, r := range ranges {
+		resp.CoveredRanges = append(resp.CoveredRanges, SyncRangeDto{
+			Since: optionalTime(r.Since),
+			Until: r.Until,
+		})
+	}
+
+	for _, j := range jobs {
+		resp.Jobs = append(resp.Jobs, BackfillJobDto{
+			Id:           j.ID,
+			Since:        optionalTime(j.Since),
+			Until:        j.Until,
+			Status:       j.Status,
+			Page:         j.Page,
+			CommitsSaved: j.CommitsSaved,
+			Error:        j.Error,
+			Attempts:     j.Attempts,
+			RetryAt:      j.RetryAt,
+			CreatedAt:    j.CreatedAt,
+			CompletedAt:  j.CompletedAt,
+		})
+	}
+
+	return resp
+}
+
+type IntegrityReportResponseDto struct {
+	Completeness    float64              `json:"completeness"`
+	StoredCount     int                  `json:"stored_count"`
+	ProviderCount   int                  `json:"provider_count"`
+	Buckets         []IntegrityBucketDto `json:"buckets"`
+	MissingRanges   []SyncRangeDto       `json:"missing_ranges"`
+	OrphanedCommits []string             `json:"orphaned_commits"`
+	RefetchJobs     int                  `json:"refetch_jobs"`
+	VerifiedAt      time.Time            `json:"verified_at"`
+}
+
+// IntegrityBucketDto holds the stored and upstream commit counts of a date range, a null since means
+// the range starts at the first commit
+type IntegrityBucketDto struct {
+	Since         *time.Time `json:"since"`
+	Until         time.Time  `json:"until"`
+	StoredCount   int        `json:"stored_count"`
+	ProviderCount int        `json:"provider_count"`
+}
+
+// IntegrityReportResponse maps a history verification of a repository to its dto response
+func IntegrityReportResponse(report domain.IntegrityReport) IntegrityReportResponseDto {
+	resp := IntegrityReportResponseDto{
+		Completeness:    report.Completeness,
+		StoredCount:     report.StoredCount,
+		ProviderCount:   report.ProviderCount,
+		Buckets:         make([]IntegrityBucketDto, 0, len(report.Buckets)),
+		MissingRanges:   make([]SyncRangeDto, 0, len(report.MissingRanges)),
+		OrphanedCommits: make([]string, 0, len(report.OrphanedCommits)),
+		RefetchJobs:     report.RefetchJobs,
+		VerifiedAt:      report.VerifiedAt,
+	}
+
+	for _, b := range report.Buckets {
+		resp.Buckets = append(resp.Buckets, IntegrityBucketDto{
+			Since:         optionalTime(b.Since),
+			Until:         b.Until,
+			StoredCount:   b.StoredCount,
+			ProviderCount: b.ProviderCount,
+		})
+	}
+
+	for _, r := range report.MissingRanges {
+		resp.MissingRanges = append(resp.MissingRanges, SyncRangeDto{
+			Since: optionalTime(r.Since),
+			Until: r.Until,
+		})
+	}
+	resp.OrphanedCommits = append(resp.OrphanedCommits, report.OrphanedCommits...)
+
+	return resp
+}
+
+// optionalTime maps a zero time to nil so that it is rendered as null
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,30 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type BranchResponseDto struct {
+	Name        string    `json:"name"`
+	HeadSHA     string    `json:"head_sha"`
+	IsDefault   bool      `json:"is_default"`
+	CommitCount int       `json:"commit_count"`
+	SyncedAt    time.Time `json:"synced_at"`
+}
+
+// BranchesResponse maps the tracked branches of a repository to their dto response
+func BranchesResponse(branches []domain.Branch) []BranchResponseDto {
+	resp := make([]BranchResponseDto, 0, len(branches))
+	for _, b := range branches {
+		resp = append(resp, BranchResponseDto{
+			Name:        b.Name,
+			HeadSHA:     b.SyncedSHA,
+			IsDefault:   b.IsDefault,
+			CommitCount: b.CommitCount,
+			SyncedAt:    b.UpdatedAt,
+		})
+	}
+	return resp
+}
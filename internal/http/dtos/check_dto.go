@@ -0,0 +1,63 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type CheckRunResponseDto struct {
+	Kind        string     `json:"kind"`
+	Name        string     `json:"name"`
+	Conclusion  string     `json:"conclusion"`
+	Outcome     string     `json:"outcome"`
+	URL         string     `json:"url"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type FlakyCheckDto struct {
+	Name        string `json:"name"`
+	CommitCount int    `json:"commit_count"`
+}
+
+type CIHealthResponseDto struct {
+	Commits      int             `json:"commits"`
+	GreenCommits int             `json:"green_commits"`
+	PassRate     float64         `json:"pass_rate"`
+	FlakyChecks  []FlakyCheckDto `json:"flaky_checks"`
+	// MeanTimeToGreenSeconds is the mean time to green in seconds
+	MeanTimeToGreenSeconds float64 `json:"mean_time_to_green_seconds"`
+}
+
+// CheckRunsResponse is a mapper of check run response dto from an array of check run domain entity
+func CheckRunsResponse(runs []domain.CheckRun) []CheckRunResponseDto {
+	resp := make([]CheckRunResponseDto, 0, len(runs))
+	for _, r := range runs {
+		resp = append(resp, CheckRunResponseDto{
+			Kind:        r.Kind,
+			Name:        r.Name,
+			Conclusion:  r.Conclusion,
+			Outcome:     r.Outcome(),
+			URL:         r.URL,
+			StartedAt:   r.StartedAt,
+			CompletedAt: r.CompletedAt,
+		})
+	}
+	return resp
+}
+
+// CIHealthResponse is a mapper of dto CI health response from a CI health domain entity
+func CIHealthResponse(h domain.CIHealth) CIHealthResponseDto {
+	flaky := make([]FlakyCheckDto, 0, len(h.FlakyChecks))
+	for _, f := range h.FlakyChecks {
+		flaky = append(flaky, FlakyCheckDto{Name: f.Name, CommitCount: f.CommitCount})
+	}
+	return CIHealthResponseDto{
+		Commits:                h.Commits,
+		GreenCommits:           h.GreenCommits,
+		PassRate:               h.PassRate,
+		FlakyChecks:            flaky,
+		MeanTimeToGreenSeconds: h.MeanTimeToGreen.Seconds(),
+	}
+}
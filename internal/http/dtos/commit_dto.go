@@ -12,26 +12,42 @@ type AllCommitResponse struct {
 }
 
 type CommitResponseDto struct {
-	CommitID   string    `json:"commit_id"`
-	Message    string    `json:"message"`
-	Author     string    `json:"author"`
-	Date       time.Time `json:"date"`
-	URL        string    `json:"url"`
-	Repository string    `json:"repository"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	CommitID        string                  `json:"commit_id"`
+	Message         string                  `json:"message"`
+	Author          string                  `json:"author"`
+	AuthorEmail     string                  `json:"author_email"`
+	AuthorLogin     string                  `json:"author_login"`
+	Date            time.Time               `json:"date"`
+	Committer       string                  `json:"committer"`
+	CommitterEmail  string                  `json:"committer_email"`
+	CommitterLogin  string                  `json:"committer_login"`
+	CommittedAt     time.Time               `json:"committed_at"`
+	URL             string                  `json:"url"`
+	Repository      string                  `json:"repository"`
+	Branches        []string                `json:"branches"`
+	FirstReleasedIn string                  `json:"first_released_in"`
+	PullRequest     *PullRequestResponseDto `json:"pull_request"`
+	Stats           *CommitStatsDto         `json:"stats"`
+	CreatedAt       time.Time               `json:"created_at"`
+	UpdatedAt       time.Time               `json:"updated_at"`
 }
 
 // AuthorCommitCountDto holds the result with author and count of commits
 type AuthorCommitCountDto struct {
+	AuthorKey   string `json:"author_key"`
 	Author      string `json:"author"`
+	AuthorEmail string `json:"author_email"`
+	AuthorLogin string `json:"author_login"`
 	CommitCount int    `json:"commit_count"`
 }
 
 // AuthorCommitCountResponse maps to dto response from AuthorCommitCount domain object
 func AuthorCommitCountResponse(a domain.AuthorCommitCount) AuthorCommitCountDto {
 	return AuthorCommitCountDto{
+		AuthorKey:   a.AuthorKey,
 		Author:      a.Author,
+		AuthorEmail: a.AuthorEmail,
+		AuthorLogin: a.AuthorLogin,
 		CommitCount: a.CommitCount,
 	}
 }
@@ -46,7 +62,10 @@ func AllAuthorCommitCountResponse(authors []domain.AuthorCommitCount) []AuthorCo
 
 	for _, a := range authors {
 		acDto := AuthorCommitCountDto{
+			AuthorKey:   a.AuthorKey,
 			Author:      a.Author,
+			AuthorEmail: a.AuthorEmail,
+			AuthorLogin: a.AuthorLogin,
 			CommitCount: a.CommitCount,
 		}
 
@@ -59,14 +78,24 @@ func AllAuthorCommitCountResponse(authors []domain.AuthorCommitCount) []AuthorCo
 // CommitResponse is a mapper of dto commit response from a commit domain entity
 func CommitResponse(c domain.Commit) CommitResponseDto {
 	return CommitResponseDto{
-		CommitID:   c.CommitID,
-		Message:    c.Message,
-		Author:     c.Author,
-		Date:       c.Date,
-		URL:        c.URL,
-		Repository: c.RepositoryName,
-		CreatedAt:  c.CreatedAt,
-		UpdatedAt:  c.UpdatedAt,
+		CommitID:        c.CommitID,
+		Message:         c.Message,
+		Author:          c.Author,
+		AuthorEmail:     c.AuthorEmail,
+		AuthorLogin:     c.AuthorLogin,
+		Date:            c.Date,
+		Committer:       c.Committer,
+		CommitterEmail:  c.CommitterEmail,
+		CommitterLogin:  c.CommitterLogin,
+		CommittedAt:     c.CommittedAt,
+		URL:             c.URL,
+		Repository:      c.RepositoryName,
+		Branches:        emptyIfNil(c.Branches),
+		FirstReleasedIn: c.FirstReleasedIn,
+		PullRequest:     commitPullRequest(c.PullRequest),
+		Stats:           commitStats(c.Stats),
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
 	}
 }
 
@@ -80,14 +109,24 @@ func CommitsResponse(commits []domain.Commit) []CommitResponseDto {
 
 	for _, c := range commits {
 		cr := CommitResponseDto{
-			CommitID:   c.CommitID,
-			Message:    c.Message,
-			Author:     c.Author,
-			Date:       c.Date,
-			URL:        c.URL,
-			Repository: c.RepositoryName,
-			CreatedAt:  c.CreatedAt,
-			UpdatedAt:  c.UpdatedAt,
+			CommitID:        c.CommitID,
+			Message:         c.Message,
+			Author:          c.Author,
+			AuthorEmail:     c.AuthorEmail,
+			AuthorLogin:     c.AuthorLogin,
+			Date:            c.Date,
+			Committer:       c.Committer,
+			CommitterEmail:  c.CommitterEmail,
+			CommitterLogin:  c.CommitterLogin,
+			CommittedAt:     c.CommittedAt,
+			URL:             c.URL,
+			Repository:      c.RepositoryName,
+			Branches:        emptyIfNil(c.Branches),
+			FirstReleasedIn: c.FirstReleasedIn,
+			PullRequest:     commitPullRequest(c.PullRequest),
+			Stats:           commitStats(c.Stats),
+			CreatedAt:       c.CreatedAt,
+			UpdatedAt:       c.UpdatedAt,
 		}
 
 		commitsResponse = append(commitsResponse, cr)
@@ -0,0 +1,57 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type MergeContributorsRequestDto struct {
+	// Into is the author key of the contributor the others are merged into, eg contributor:{id} or email:{email}
+	Into string `json:"into" validate:"required"`
+	// Merge are the author keys of the contributors and identities merged
+	Merge []string `json:"merge" validate:"required,min=1"`
+}
+
+type ContributorResponseDto struct {
+	Id         string                           `json:"id"`
+	AuthorKey  string                           `json:"author_key"`
+	Name       string                           `json:"name"`
+	Email      string                           `json:"email"`
+	Login      string                           `json:"login"`
+	Identities []ContributorIdentityResponseDto `json:"identities"`
+	CreatedAt  time.Time                        `json:"created_at"`
+	UpdatedAt  time.Time                        `json:"updated_at"`
+}
+
+type ContributorIdentityResponseDto struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Login  string `json:"login"`
+	Source string `json:"source"`
+}
+
+// ContributorResponse is a mapper of dto contributor response from a contributor domain entity
+func ContributorResponse(c domain.Contributor) ContributorResponseDto {
+	identities := make([]ContributorIdentityResponseDto, 0, len(c.Identities))
+	for _, i := range c.Identities {
+		identities = append(identities, ContributorIdentityResponseDto{
+			Key:    i.Key,
+			Name:   i.Name,
+			Email:  i.Email,
+			Login:  i.Login,
+			Source: i.Source,
+		})
+	}
+	return ContributorResponseDto{
+		Id:         c.PublicID,
+		AuthorKey:  c.Key(),
+		Name:       c.Name,
+		Email:      c.Email,
+		Login:      c.Login,
+		Identities: identities,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type EnrichmentStateResponseDto struct {
+	TotalCommits    int        `json:"total_commits"`
+	EnrichedCommits int        `json:"enriched_commits"`
+	FailedCommits   int        `json:"failed_commits"`
+	PendingCommits  int        `json:"pending_commits"`
+	LastRunAt       *time.Time `json:"last_run_at"`
+	LastError       string     `json:"last_error"`
+}
+
+type CommitStatsDto struct {
+	Additions    int `json:"additions"`
+	Deletions    int `json:"deletions"`
+	FilesChanged int `json:"files_changed"`
+}
+
+// EnrichmentStateResponse maps the enrichment progress of a repository to its dto response
+func EnrichmentStateResponse(s domain.EnrichmentState) EnrichmentStateResponseDto {
+	return EnrichmentStateResponseDto{
+		TotalCommits:    s.TotalCommits,
+		EnrichedCommits: s.EnrichedCommits,
+		FailedCommits:   s.FailedCommits,
+		PendingCommits:  s.PendingCommits(),
+		LastRunAt:       s.LastRunAt,
+		LastError:       s.LastError,
+	}
+}
+
+func commitStats(s *domain.CommitStats) *CommitStatsDto {
+	if s == nil {
+		return nil
+	}
+	return &CommitStatsDto{Additions: s.Additions, Deletions: s.Deletions, FilesChanged: s.FilesChanged}
+}
@@ -0,0 +1,85 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type HotspotsResponseDto struct {
+	Files       []HotspotResponseDto `json:"files"`
+	Directories []HotspotResponseDto `json:"directories"`
+}
+
+type HotspotResponseDto struct {
+	Path          string    `json:"path"`
+	Changes       int       `json:"changes"`
+	Churn         int       `json:"churn"`
+	Authors       int       `json:"authors"`
+	LastChangedAt time.Time `json:"last_changed_at"`
+	Recency       float64   `json:"recency"`
+	Score         float64   `json:"score"`
+}
+
+type FileHistoryResponseDto struct {
+	Changes         []FileRevisionResponseDto `json:"changes"`
+	EnrichedCommits int                       `json:"enriched_commits"`
+	TotalCommits    int                       `json:"total_commits"`
+	PageInfo        PagingInfoDto             `json:"page_info"`
+}
+
+type FileRevisionResponseDto struct {
+	Path         string            `json:"path"`
+	PreviousPath string            `json:"previous_path"`
+	Status       string            `json:"status"`
+	Additions    int               `json:"additions"`
+	Deletions    int               `json:"deletions"`
+	Commit       CommitResponseDto `json:"commit"`
+}
+
+// HotspotsResponse is a mapper of dto hotspots response from the ranked files and directories of a repository
+func HotspotsResponse(files, dirs []domain.Hotspot) HotspotsResponseDto {
+	return HotspotsResponseDto{Files: hotspotsResponse(files), Directories: hotspotsResponse(dirs)}
+}
+
+func hotspotsResponse(hotspots []domain.Hotspot) []HotspotResponseDto {
+	resp := make([]HotspotResponseDto, 0, len(hotspots))
+	for _, h := range hotspots {
+		resp = append(resp, HotspotResponseDto{
+			Path:          h.Path,
+			Changes:       h.Changes,
+			Churn:         h.Churn,
+			Authors:       h.Authors,
+			LastChangedAt: h.LastChangedAt,
+			Recency:       h.Recency,
+			Score:         h.Score,
+		})
+	}
+	return resp
+}
+
+// FileHistoryResponse is a mapper of file history response dto from a page of the history of a file, with the
+// enrichment coverage of its repository
+func FileHistoryResponse(h domain.FileHistory) FileHistoryResponseDto {
+	return FileHistoryResponseDto{
+		Changes:         fileRevisionsResponse(h.Revisions),
+		EnrichedCommits: h.Enrichment.EnrichedCommits,
+		TotalCommits:    h.Enrichment.TotalCommits,
+		PageInfo:        PagingInfoResponse(h.PagingInfo),
+	}
+}
+
+func fileRevisionsResponse(revisions []domain.FileRevision) []FileRevisionResponseDto {
+	resp := make([]FileRevisionResponseDto, 0, len(revisions))
+	for _, r := range revisions {
+		resp = append(resp, FileRevisionResponseDto{
+			Path:         r.Change.Path,
+			PreviousPath: r.Change.PreviousPath,
+			Status:       r.Change.Status,
+			Additions:    r.Change.Additions,
+			Deletions:    r.Change.Deletions,
+			Commit:       CommitResponse(r.Commit),
+		})
+	}
+	return resp
+}
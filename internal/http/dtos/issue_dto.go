@@ -0,0 +1,87 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type IssueCommitsResponse struct {
+	// Issue is null when the issue was not synced, eg when it was last updated before the tracking window
+	Issue   *IssueResponseDto              `json:"issue"`
+	Commits []ReferencingCommitResponseDto `json:"commits"`
+}
+
+type IssueResponseDto struct {
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	Author    string     `json:"author"`
+	State     string     `json:"state"`
+	Labels    []string   `json:"labels"`
+	Assignees []string   `json:"assignees"`
+	URL       string     `json:"url"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+}
+
+type ReferencingCommitResponseDto struct {
+	CommitResponseDto
+	Closing bool `json:"closing"`
+}
+
+type CommitReferenceResponseDto struct {
+	Repository  string `json:"repository"`
+	Kind        string `json:"kind"`
+	Key         string `json:"key"`
+	IssueNumber *int   `json:"issue_number"`
+	Closing     bool   `json:"closing"`
+}
+
+// IssueResponse is a mapper of dto issue response from an issue domain entity
+func IssueResponse(i *domain.Issue) *IssueResponseDto {
+	if i == nil {
+		return nil
+	}
+	return &IssueResponseDto{
+		Number:    i.Number,
+		Title:     i.Title,
+		Author:    i.Author,
+		State:     i.State,
+		Labels:    emptyIfNil(i.Labels),
+		Assignees: emptyIfNil(i.Assignees),
+		URL:       i.URL,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+		ClosedAt:  i.ClosedAt,
+	}
+}
+
+// ReferencingCommitsResponse is a mapper of referencing commit response dto from an array of referencing commit domain entity
+func ReferencingCommitsResponse(commits []domain.ReferencingCommit) []ReferencingCommitResponseDto {
+	resp := make([]ReferencingCommitResponseDto, 0, len(commits))
+	for _, c := range commits {
+		resp = append(resp, ReferencingCommitResponseDto{CommitResponseDto: CommitResponse(c.Commit), Closing: c.Closing})
+	}
+	return resp
+}
+
+// CommitReferencesResponse is a mapper of commit reference response dto from an array of commit reference domain entity,
+// the issue number is only set for the issues of the repository of the commit
+func CommitReferencesResponse(refs []domain.CommitReference) []CommitReferenceResponseDto {
+	resp := make([]CommitReferenceResponseDto, 0, len(refs))
+	for _, r := range refs {
+		ref := CommitReferenceResponseDto{
+			Repository: r.RepositoryName,
+			Kind:       r.Kind,
+			Key:        r.Key,
+			Closing:    r.Closing,
+		}
+		if r.IssueNumber > 0 {
+			number := r.IssueNumber
+			ref.IssueNumber = &number
+		}
+		resp = append(resp, ref)
+	}
+	return resp
+}
@@ -0,0 +1,45 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type MetadataHistoryResponseDto struct {
+	Interval string                `json:"interval"`
+	Points   []MetadataSnapshotDto `json:"points"`
+}
+
+// MetadataSnapshotDto holds the metadata of a repository at the start of an interval
+type MetadataSnapshotDto struct {
+	Time            time.Time `json:"time"`
+	StarsCount      int       `json:"stars_count"`
+	ForksCount      int       `json:"forks_count"`
+	WatchersCount   int       `json:"watchers_count"`
+	OpenIssuesCount int       `json:"open_issues_count"`
+	Language        string    `json:"language"`
+}
+
+// MetadataHistoryResponse maps the downsampled metadata history of a repository to its dto response
+func MetadataHistoryResponse(interval string, snapshots []domain.RepoMetadataSnapshot) MetadataHistoryResponseDto {
+	if interval == "" {
+		interval = string(domain.SnapshotIntervalDay)
+	}
+
+	resp := MetadataHistoryResponseDto{
+		Interval: interval,
+		Points:   make([]MetadataSnapshotDto, 0, len(snapshots)),
+	}
+	for _, s := range snapshots {
+		resp.Points = append(resp.Points, MetadataSnapshotDto{
+			Time:            s.CapturedAt,
+			StarsCount:      s.StarsCount,
+			ForksCount:      s.ForksCount,
+			WatchersCount:   s.WatchersCount,
+			OpenIssuesCount: s.OpenIssuesCount,
+			Language:        s.Language,
+		})
+	}
+	return resp
+}
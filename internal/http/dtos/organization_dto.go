@@ -0,0 +1,81 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type ImportOrganizationRequestDto struct {
+	// Owner is the name of the organization or user whose repositories are imported
+	Owner           string `json:"owner" validate:"required"`
+	IncludeForks    bool   `json:"include_forks"`
+	IncludeArchived bool   `json:"include_archived"`
+	Topic           string `json:"topic"`
+	Language        string `json:"language"`
+	NamePattern     string `json:"name_pattern"`
+	SyncMembership  bool   `json:"sync_membership"`
+}
+
+type OrgImportResponseDto struct {
+	Id             uint                 `json:"id"`
+	Owner          string               `json:"owner"`
+	SyncMembership bool                 `json:"sync_membership"`
+	Status         string               `json:"status"`
+	Error          string               `json:"error,omitempty"`
+	Listed         int                  `json:"listed"`
+	Added          int                  `json:"added"`
+	AlreadyAdded   int                  `json:"already_added"`
+	Skipped        int                  `json:"skipped"`
+	Failed         int                  `json:"failed"`
+	Results        []OrgImportResultDto `json:"results"`
+	CreatedAt      time.Time            `json:"created_at"`
+	CompletedAt    *time.Time           `json:"completed_at"`
+}
+
+// OrgImportResultDto holds the outcome of importing a repository, Id is the repository id when it was added
+type OrgImportResultDto struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	Id     string `json:"id,omitempty"`
+}
+
+// OrgImportFilterFromDto is a mapper from ImportOrganizationRequestDto to domain entity OrgImportFilter
+func OrgImportFilterFromDto(input ImportOrganizationRequestDto) domain.OrgImportFilter {
+	return domain.OrgImportFilter{
+		IncludeForks:    input.IncludeForks,
+		IncludeArchived: input.IncludeArchived,
+		Topic:           input.Topic,
+		Language:        input.Language,
+		NamePattern:     input.NamePattern,
+	}
+}
+
+// OrgImportResponse maps the import of the repositories of an organization or user to its dto response
+func OrgImportResponse(orgImport domain.OrgImport) OrgImportResponseDto {
+	resp := OrgImportResponseDto{
+		Id:             orgImport.ID,
+		Owner:          orgImport.Owner,
+		SyncMembership: orgImport.SyncMembership,
+		Status:         orgImport.Status,
+		Error:          orgImport.Error,
+		Listed:         len(orgImport.Results),
+		Added:          orgImport.Count(domain.OrgImportAdded),
+		AlreadyAdded:   orgImport.Count(domain.OrgImportAlreadyAdded),
+		Skipped:        orgImport.Count(domain.OrgImportSkipped),
+		Failed:         orgImport.Count(domain.OrgImportFailed),
+		Results:        make([]OrgImportResultDto, 0, len(orgImport.Results)),
+		CreatedAt:      orgImport.CreatedAt,
+		CompletedAt:    orgImport.CompletedAt,
+	}
+
+	for _, r := range orgImport.Results {
+		result := OrgImportResultDto{Name: r.Name, Status: r.Status, Reason: r.Reason}
+		if r.Repository != nil {
+			result.Id = r.Repository.PublicID
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp
+}
@@ -0,0 +1,42 @@
+package dtos
+
+import "github.com/kenmobility/git-api-service/internal/domain"
+
+type OwnershipResponseDto struct {
+	Path    string             `json:"path"`
+	Changes int                `json:"changes"`
+	Owners  []OwnerResponseDto `json:"owners"`
+}
+
+type OwnerResponseDto struct {
+	Author    string  `json:"author"`
+	AuthorKey string  `json:"author_key"`
+	Handle    string  `json:"handle"`
+	Changes   int     `json:"changes"`
+	Lines     int     `json:"lines"`
+	Weight    float64 `json:"weight"`
+	Share     float64 `json:"share"`
+}
+
+type CodeownersResponseDto struct {
+	Content string `json:"content"`
+	// UnmappedAuthors are the authors that own a directory but have no GitHub handle mapped
+	UnmappedAuthors []string `json:"unmapped_authors"`
+}
+
+// OwnershipResponse is a mapper of dto ownership response from an ownership domain entity
+func OwnershipResponse(o domain.Ownership) OwnershipResponseDto {
+	owners := make([]OwnerResponseDto, 0, len(o.Owners))
+	for _, owner := range o.Owners {
+		owners = append(owners, OwnerResponseDto{
+			Author:    owner.Author,
+			AuthorKey: owner.AuthorKey,
+			Handle:    owner.Handle,
+			Changes:   owner.Changes,
+			Lines:     owner.Lines,
+			Weight:    owner.Weight,
+			Share:     owner.Share,
+		})
+	}
+	return OwnershipResponseDto{Path: o.Path, Changes: o.Changes, Owners: owners}
+}
@@ -0,0 +1,66 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type AllPullRequestResponse struct {
+	PullRequests []PullRequestResponseDto `json:"pull_requests"`
+	PageInfo     PagingInfoDto            `json:"page_info"`
+}
+
+type PullRequestResponseDto struct {
+	Number         int        `json:"number"`
+	Title          string     `json:"title"`
+	Author         string     `json:"author"`
+	State          string     `json:"state"`
+	BaseRef        string     `json:"base_ref"`
+	HeadRef        string     `json:"head_ref"`
+	MergeCommitSHA string     `json:"merge_commit_sha"`
+	ReviewCount    int        `json:"review_count"`
+	Labels         []string   `json:"labels"`
+	URL            string     `json:"url"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	MergedAt       *time.Time `json:"merged_at"`
+	ClosedAt       *time.Time `json:"closed_at"`
+}
+
+// PullRequestResponse is a mapper of dto pull request response from a pull request domain entity
+func PullRequestResponse(p domain.PullRequest) PullRequestResponseDto {
+	return PullRequestResponseDto{
+		Number:         p.Number,
+		Title:          p.Title,
+		Author:         p.Author,
+		State:          p.State,
+		BaseRef:        p.BaseRef,
+		HeadRef:        p.HeadRef,
+		MergeCommitSHA: p.MergeCommitSHA,
+		ReviewCount:    p.ReviewCount,
+		Labels:         emptyIfNil(p.Labels),
+		URL:            p.URL,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+		MergedAt:       p.MergedAt,
+		ClosedAt:       p.ClosedAt,
+	}
+}
+
+// PullRequestsResponse is a mapper of pull request response dto from an array of pull request domain entity
+func PullRequestsResponse(pullRequests []domain.PullRequest) []PullRequestResponseDto {
+	resp := make([]PullRequestResponseDto, 0, len(pullRequests))
+	for _, p := range pullRequests {
+		resp = append(resp, PullRequestResponse(p))
+	}
+	return resp
+}
+
+func commitPullRequest(p *domain.PullRequest) *PullRequestResponseDto {
+	if p == nil {
+		return nil
+	}
+	resp := PullRequestResponse(*p)
+	return &resp
+}
@@ -0,0 +1,36 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type ReleaseResponseDto struct {
+	Tag         string     `json:"tag"`
+	TagSHA      string     `json:"tag_sha"`
+	Name        string     `json:"name"`
+	Notes       string     `json:"notes"`
+	Prerelease  bool       `json:"prerelease"`
+	Draft       bool       `json:"draft"`
+	PublishedAt *time.Time `json:"published_at"`
+	CommitCount int        `json:"commit_count"`
+}
+
+// ReleasesResponse maps the releases of a repository to their dto response
+func ReleasesResponse(releases []domain.Release) []ReleaseResponseDto {
+	resp := make([]ReleaseResponseDto, 0, len(releases))
+	for _, r := range releases {
+		resp = append(resp, ReleaseResponseDto{
+			Tag:         r.Tag,
+			TagSHA:      r.TagSHA,
+			Name:        r.Name,
+			Notes:       r.Notes,
+			Prerelease:  r.Prerelease,
+			Draft:       r.Draft,
+			PublishedAt: r.PublishedAt,
+			CommitCount: r.CommitCount,
+		})
+	}
+	return resp
+}
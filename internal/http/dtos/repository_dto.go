@@ -1,33 +1,124 @@
 package dtos
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/pkg/message"
 )
 
 type AddRepositoryRequestDto struct {
 	Name string `json:"name" validate:"required"`
+	TrackingSettingsDto
+}
+
+type UpdateRepositoryRequestDto struct {
+	TrackingSettingsDto
+}
+
+// TrackingSettingsDto holds the per repository tracking settings, absent fields are left unchanged
+type TrackingSettingsDto struct {
+	// Since is an RFC3339 date or "full_history" to track every commit of the repository
+	Since *string `json:"since,omitempty"`
+	// Until is an RFC3339 date, an empty value tracks up to the latest commit
+	Until  *string `json:"until,omitempty"`
+	Branch *string `json:"branch,omitempty"`
+	// Branches are the names or glob patterns, eg release/*, of the branches tracked besides branch
+	Branches      *[]string `json:"branches,omitempty"`
+	FetchInterval *string   `json:"fetch_interval,omitempty"`
+	PerPage       *int      `json:"per_page,omitempty"`
+}
+
+// FullHistory is the since value used to track the full history of a repository
+const FullHistory = "full_history"
+
+// TrackingOptionsFromDto is a mapper from TrackingSettingsDto to domain entity TrackingOptions
+func TrackingOptionsFromDto(t TrackingSettingsDto) (domain.TrackingOptions, error) {
+	var opts domain.TrackingOptions
+
+	if t.Since != nil {
+		if *t.Since == FullHistory {
+			opts.FullHistory = true
+		} else {
+			since, err := time.Parse(time.RFC3339, *t.Since)
+			if err != nil {
+				return opts, fmt.Errorf("invalid since date [%s], use RFC3339 format or %s", *t.Since, FullHistory)
+			}
+			opts.Since = &since
+		}
+	}
+
+	if t.Until != nil {
+		if *t.Until == "" {
+			opts.OpenEnded = true
+		} else {
+			until, err := time.Parse(time.RFC3339, *t.Until)
+			if err != nil {
+				return opts, fmt.Errorf("invalid until date [%s], use RFC3339 format", *t.Until)
+			}
+			opts.Until = &until
+		}
+	}
+
+	opts.Branch = t.Branch
+	opts.Branches = t.Branches
+
+	if t.FetchInterval != nil {
+		interval, err := time.ParseDuration(*t.FetchInterval)
+		if err != nil || interval <= 0 {
+			return opts, fmt.Errorf("invalid fetch_interval [%s], eg format is 30m or 1h", *t.FetchInterval)
+		}
+		opts.FetchInterval = interval
+	}
+
+	if t.PerPage != nil {
+		if *t.PerPage <= 0 {
+			return opts, message.ErrInvalidCommitsPerPage
+		}
+		opts.PerPage = *t.PerPage
+	}
+
+	return opts, nil
 }
 
 type GitRepoMetadataResponseDto struct {
-	Id              string `json:"id"`
-	Name            string `json:"name"`
-	Description     string `json:"description"`
-	URL             string `json:"url"`
-	Language        string `json:"language"`
-	ForksCount      int    `json:"forks_count"`
-	StarsCount      int    `json:"stars_count"`
-	OpenIssuesCount int    `json:"open_issues_count"`
-	WatchersCount   int    `json:"watchers_count"`
-	CreatedAt       string `json:"added_at"`
-	UpdatedAt       string `json:"last_updated_at"`
+	Id              string     `json:"id"`
+	ProviderID      int64      `json:"provider_id"`
+	Name            string     `json:"name"`
+	PreviousNames   []string   `json:"previous_names"`
+	Description     string     `json:"description"`
+	URL             string     `json:"url"`
+	Language        string     `json:"language"`
+	ForksCount      int        `json:"forks_count"`
+	StarsCount      int        `json:"stars_count"`
+	OpenIssuesCount int        `json:"open_issues_count"`
+	WatchersCount   int        `json:"watchers_count"`
+	Paused          bool       `json:"paused"`
+	State           string     `json:"state"`
+	StateChangedAt  *time.Time `json:"state_changed_at"`
+	Archived        bool       `json:"archived"`
+	Disabled        bool       `json:"disabled"`
+	Visibility      string     `json:"visibility"`
+	PushedAt        *time.Time `json:"pushed_at"`
+	Since           *time.Time `json:"since"`
+	FullHistory     bool       `json:"full_history"`
+	Until           *time.Time `json:"until"`
+	Branch          string     `json:"branch"`
+	DefaultBranch   string     `json:"default_branch"`
+	TrackedBranches []string   `json:"tracked_branches"`
+	FetchInterval   string     `json:"fetch_interval"`
+	PerPage         int        `json:"per_page"`
+	CreatedAt       string     `json:"added_at"`
+	UpdatedAt       string     `json:"last_updated_at"`
 }
 
 func RepoMetadataResponse(r domain.RepoMetadata) GitRepoMetadataResponseDto {
 	return GitRepoMetadataResponseDto{
 		Id:              r.PublicID,
+		ProviderID:      r.ProviderID,
 		Name:            r.Name,
+		PreviousNames:   previousNames(r.Aliases),
 		Description:     r.Description,
 		URL:             r.URL,
 		Language:        r.Language,
@@ -35,11 +126,41 @@ func RepoMetadataResponse(r domain.RepoMetadata) GitRepoMetadataResponseDto {
 		StarsCount:      r.StarsCount,
 		OpenIssuesCount: r.OpenIssuesCount,
 		WatchersCount:   r.WatchersCount,
+		Paused:          r.Paused,
+		State:           r.State(),
+		StateChangedAt:  r.UpstreamStateChangedAt,
+		Archived:        r.Archived,
+		Disabled:        r.Disabled,
+		Visibility:      r.Visibility,
+		PushedAt:        r.PushedAt,
+		Since:           r.TrackSince,
+		FullHistory:     r.TrackSince == nil,
+		Until:           r.TrackUntil,
+		Branch:          r.Branch,
+		DefaultBranch:   r.DefaultBranch,
+		TrackedBranches: emptyIfNil(r.TrackedBranches),
+		FetchInterval:   r.FetchInterval.String(),
+		PerPage:         r.CommitsPerPage,
 		CreatedAt:       r.CreatedAt.Format(time.RFC850),
 		UpdatedAt:       r.UpdatedAt.Format(time.RFC850),
 	}
 }
 
+func emptyIfNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func previousNames(aliases []domain.RepoAlias) []string {
+	names := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		names = append(names, a.Name)
+	}
+	return names
+}
+
 func AllRepoMetadataResponse(repos []domain.RepoMetadata) []GitRepoMetadataResponseDto {
 	if len(repos) == 0 {
 		return []GitRepoMetadataResponseDto{}
@@ -48,22 +169,74 @@ func AllRepoMetadataResponse(repos []domain.RepoMetadata) []GitRepoMetadataRespo
 	reposResponse := make([]GitRepoMetadataResponseDto, 0, len(repos))
 
 	for _, r := range repos {
-		rr := GitRepoMetadataResponseDto{
-			Id:              r.PublicID,
-			Name:            r.Name,
-			Description:     r.Description,
-			URL:             r.URL,
-			Language:        r.Language,
-			ForksCount:      r.ForksCount,
-			StarsCount:      r.StarsCount,
-			OpenIssuesCount: r.OpenIssuesCount,
-			WatchersCount:   r.WatchersCount,
-			CreatedAt:       r.CreatedAt.Format(time.RFC850),
-			UpdatedAt:       r.UpdatedAt.Format(time.RFC850),
-		}
-
-		reposResponse = append(reposResponse, rr)
+		reposResponse = append(reposResponse, RepoMetadataResponse(r))
 	}
 
 	return reposResponse
 }
+
+type ReindexJobResponseDto struct {
+	Id             uint       `json:"id"`
+	Status         string     `json:"status"`
+	Page           int        `json:"page"`
+	CommitsFetched int        `json:"commits_fetched"`
+	AddedCount     int        `json:"added_count"`
+	RemovedCount   int        `json:"removed_count"`
+	ChangedCount   int        `json:"changed_count"`
+	Error          string     `json:"error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at"`
+}
+
+// ReindexJobResponse maps a reindex job to its dto response
+func ReindexJobResponse(j domain.ReindexJob) ReindexJobResponseDto {
+	return ReindexJobResponseDto{
+		Id:             j.ID,
+		Status:         j.Status,
+		Page:           j.Page,
+		CommitsFetched: j.CommitsFetched,
+		AddedCount:     j.Added,
+		RemovedCount:   j.Removed,
+		ChangedCount:   j.Changed,
+		Error:          j.Error,
+		CreatedAt:      j.CreatedAt,
+		CompletedAt:    j.CompletedAt,
+	}
+}
+
+type IndexingEstimateResponseDto struct {
+	Name                     string     `json:"name"`
+	Since                    *time.Time `json:"since"`
+	FullHistory              bool       `json:"full_history"`
+	Until                    *time.Time `json:"until"`
+	Branch                   string     `json:"branch"`
+	PerPage                  int        `json:"per_page"`
+	EstimatedCommits         int        `json:"estimated_commits"`
+	EstimatedRequests        int        `json:"estimated_requests"`
+	EstimatedDuration        string     `json:"estimated_duration"`
+	EstimatedDurationSeconds int64      `json:"estimated_duration_seconds"`
+	EstimatedStorageBytes    int64      `json:"estimated_storage_bytes"`
+	RateLimitLimit           int        `json:"rate_limit_limit"`
+	RateLimitRemaining       int        `json:"rate_limit_remaining"`
+	RateLimitReset           time.Time  `json:"rate_limit_reset"`
+}
+
+// IndexingEstimateResponse maps an indexing estimate to its dto response
+func IndexingEstimateResponse(e domain.IndexingEstimate) IndexingEstimateResponseDto {
+	return IndexingEstimateResponseDto{
+		Name:                     e.RepositoryName,
+		Since:                    e.Since,
+		FullHistory:              e.Since == nil,
+		Until:                    e.Until,
+		Branch:                   e.Branch,
+		PerPage:                  e.CommitsPerPage,
+		EstimatedCommits:         e.Commits,
+		EstimatedRequests:        e.Requests,
+		EstimatedDuration:        e.EstimatedDuration.Round(time.Second).String(),
+		EstimatedDurationSeconds: int64(e.EstimatedDuration.Seconds()),
+		EstimatedStorageBytes:    e.StorageBytes,
+		RateLimitLimit:           e.RateLimit.Limit,
+		RateLimitRemaining:       e.RateLimit.Remaining,
+		RateLimitReset:           e.RateLimit.Reset,
+	}
+}
@@ -0,0 +1,63 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type StarHistoryResponseDto struct {
+	TotalStars int            `json:"total_stars"`
+	StarsCount int            `json:"stars_count"`
+	Partial    bool           `json:"partial"`
+	Cumulative []StarCountDto `json:"cumulative"`
+	Weekly     []StarCountDto `json:"weekly"`
+}
+
+// StarCountDto holds the stars of the week starting at Week
+type StarCountDto struct {
+	Week  time.Time `json:"week"`
+	Stars int       `json:"stars"`
+}
+
+type StarGrowthResponseDto struct {
+	Id         string  `json:"id"`
+	Name       string  `json:"name"`
+	StarsCount int     `json:"stars_count"`
+	Gained     int     `json:"stars_gained"`
+	GrowthRate float64 `json:"growth_rate"`
+}
+
+// StarHistoryResponse maps the star history of a repository to its dto response
+func StarHistoryResponse(history domain.StarHistory) StarHistoryResponseDto {
+	return StarHistoryResponseDto{
+		TotalStars: history.Total,
+		StarsCount: history.StarsCount,
+		Partial:    history.Partial,
+		Cumulative: starCounts(history.Cumulative),
+		Weekly:     starCounts(history.Weekly),
+	}
+}
+
+// FastestGrowingResponse maps the star growth ranking of the tracked repositories to its dto response
+func FastestGrowingResponse(growth []domain.StarGrowth) []StarGrowthResponseDto {
+	resp := make([]StarGrowthResponseDto, 0, len(growth))
+	for _, g := range growth {
+		resp = append(resp, StarGrowthResponseDto{
+			Id:         g.Repository.PublicID,
+			Name:       g.Repository.Name,
+			StarsCount: g.Total,
+			Gained:     g.Gained,
+			GrowthRate: g.GrowthRate(),
+		})
+	}
+	return resp
+}
+
+func starCounts(counts []domain.StarCount) []StarCountDto {
+	dtos := make([]StarCountDto, 0, len(counts))
+	for _, c := range counts {
+		dtos = append(dtos, StarCountDto{Week: c.Week, Stars: c.Stars})
+	}
+	return dtos
+}
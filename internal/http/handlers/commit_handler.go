@@ -3,8 +3,11 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kenmobility/git-api-service/internal/domain"
 	"github.com/kenmobility/git-api-service/internal/http/dtos"
 	"github.com/kenmobility/git-api-service/internal/usecases"
 	"github.com/kenmobility/git-api-service/pkg/message"
@@ -22,6 +25,21 @@ func NewCommitHandler(manageGitCommitUsecase usecases.ManageGitCommitUsecase) *C
 }
 
 func (ch CommitHandlers) GetCommitsByRepositoryId(ctx *gin.Context) {
+	ch.getCommits(ctx, domain.CommitFilter{Branch: ctx.Query("branch")})
+}
+
+func (ch CommitHandlers) GetCommitsByRelease(ctx *gin.Context) {
+	tag := ctx.Param("tag")
+
+	if tag == "" {
+		response.Failure(ctx, http.StatusBadRequest, "tag is required", nil)
+		return
+	}
+
+	ch.getCommits(ctx, domain.CommitFilter{Release: tag})
+}
+
+func (ch CommitHandlers) getCommits(ctx *gin.Context, filter domain.CommitFilter) {
 	query := getPagingInfo(ctx)
 
 	repositoryId := ctx.Param("repoId")
@@ -31,12 +49,20 @@ func (ch CommitHandlers) GetCommitsByRepositoryId(ctx *gin.Context) {
 		return
 	}
 
-	repoName, commits, pagingInfo, err := ch.manageGitCommitUsecase.GetAllCommitsByRepository(ctx, repositoryId, dtos.PagingDataFromPagingDto(query))
+	repoName, commits, pagingInfo, err := ch.manageGitCommitUsecase.GetAllCommitsByRepository(ctx, repositoryId, filter, dtos.PagingDataFromPagingDto(query))
 	if err != nil {
 		if err == message.ErrNoRecordFound {
 			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
 			return
 		}
+		if err == message.ErrBranchNotTracked {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+		if err == message.ErrReleaseNotFound {
+			response.Failure(ctx, http.StatusNotFound, err.Error(), err.Error())
+			return
+		}
 		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
 		return
 	}
@@ -83,3 +109,363 @@ func (ch CommitHandlers) GetTopCommitAuthors(ctx *gin.Context) {
 
 	response.Success(ctx, http.StatusOK, msg, dtos.AllAuthorCommitCountResponse(authors))
 }
+
+func (ch CommitHandlers) GetBranchesByRepositoryId(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	repoName, branches, err := ch.manageGitCommitUsecase.GetBranchesByRepository(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("%v tracked branches of %s repository fetched successfully", len(branches), *repoName)
+
+	response.Success(ctx, http.StatusOK, msg, dtos.BranchesResponse(branches))
+}
+
+func (ch CommitHandlers) GetReleasesByRepositoryId(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	repoName, releases, err := ch.manageGitCommitUsecase.GetReleasesByRepository(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("%v releases of %s repository fetched successfully", len(releases), *repoName)
+
+	response.Success(ctx, http.StatusOK, msg, dtos.ReleasesResponse(releases))
+}
+
+func (ch CommitHandlers) GetPullRequestsByRepositoryId(ctx *gin.Context) {
+	query := getPagingInfo(ctx)
+
+	repositoryId := ctx.Param("repoId")
+
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	repoName, pullRequests, pagingInfo, err := ch.manageGitCommitUsecase.GetPullRequestsByRepository(ctx, repositoryId, ctx.Query("state"), dtos.PagingDataFromPagingDto(query))
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		if err == message.ErrInvalidPullRequestState {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	pullRequestsResp := dtos.AllPullRequestResponse{
+		PullRequests: dtos.PullRequestsResponse(pullRequests),
+		PageInfo:     dtos.PagingInfoResponse(*pagingInfo),
+	}
+
+	msg := fmt.Sprintf("%s repository pull requests fetched successfully", *repoName)
+
+	response.Success(ctx, http.StatusOK, msg, pullRequestsResp)
+}
+
+func (ch CommitHandlers) GetCommitsByIssue(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	number, err := strconv.Atoi(ctx.Param("number"))
+	if err != nil {
+		response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidIssueNumber.Error(), message.ErrInvalidIssueNumber.Error())
+		return
+	}
+
+	repoName, issue, commits, err := ch.manageGitCommitUsecase.GetCommitsByIssue(ctx, repositoryId, number)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		if err == message.ErrInvalidIssueNumber {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	issueResp := dtos.IssueCommitsResponse{
+		Issue:   dtos.IssueResponse(issue),
+		Commits: dtos.ReferencingCommitsResponse(commits),
+	}
+
+	msg := fmt.Sprintf("%s repository commits referencing issue #%d fetched successfully", *repoName, number)
+
+	response.Success(ctx, http.StatusOK, msg, issueResp)
+}
+
+func (ch CommitHandlers) GetCheckRunsByCommit(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	commitID := ctx.Param("sha")
+
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	repoName, runs, err := ch.manageGitCommitUsecase.GetCheckRunsByCommit(ctx, repositoryId, commitID)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("%v checks of commit %s of %s repository fetched successfully", len(runs), commitID, *repoName)
+
+	response.Success(ctx, http.StatusOK, msg, dtos.CheckRunsResponse(runs))
+}
+
+func (ch CommitHandlers) GetCIHealth(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	var from, to time.Time
+	if v := ctx.Query("from"); v != "" {
+		var err error
+		from, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			response.Failure(ctx, http.StatusBadRequest, "from must be an RFC3339 date", err.Error())
+			return
+		}
+	}
+	if v := ctx.Query("to"); v != "" {
+		var err error
+		to, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			response.Failure(ctx, http.StatusBadRequest, "to must be an RFC3339 date", err.Error())
+			return
+		}
+	}
+
+	repoName, health, err := ch.manageGitCommitUsecase.GetCIHealth(ctx, repositoryId, from, to)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		if err == message.ErrInvalidHistoryWindow {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("%s repository CI health fetched successfully", *repoName)
+
+	response.Success(ctx, http.StatusOK, msg, dtos.CIHealthResponse(*health))
+}
+
+func (ch CommitHandlers) GetHotspots(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	var from, to time.Time
+	if v := ctx.Query("from"); v != "" {
+		var err error
+		from, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			response.Failure(ctx, http.StatusBadRequest, "from must be an RFC3339 date", err.Error())
+			return
+		}
+	}
+	if v := ctx.Query("to"); v != "" {
+		var err error
+		to, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			response.Failure(ctx, http.StatusBadRequest, "to must be an RFC3339 date", err.Error())
+			return
+		}
+	}
+
+	paging := getPagingInfo(ctx)
+	repoName, files, dirs, err := ch.manageGitCommitUsecase.GetHotspots(ctx, repositoryId, from, to, ctx.Query("path_prefix"), paging.Sort, paging.Limit)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		if err == message.ErrInvalidHistoryWindow || err == message.ErrInvalidHotspotSort {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("%v file and %v directory hotspots of %s repository fetched successfully", len(files), len(dirs), *repoName)
+
+	response.Success(ctx, http.StatusOK, msg, dtos.HotspotsResponse(files, dirs))
+}
+
+func (ch CommitHandlers) GetFileHistory(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	path := ctx.Query("path")
+	query := getPagingInfo(ctx)
+	repoName, history, err := ch.manageGitCommitUsecase.GetFileHistory(ctx, repositoryId, path, dtos.PagingDataFromPagingDto(query))
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		if err == message.ErrPathRequired {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("%v changes of %s in %s repository fetched successfully", len(history.Revisions), path, *repoName)
+
+	response.Success(ctx, http.StatusOK, msg, dtos.FileHistoryResponse(*history))
+}
+
+func (ch CommitHandlers) GetOwnership(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	repoName, ownership, err := ch.manageGitCommitUsecase.GetOwnership(ctx, repositoryId, ctx.Query("path"))
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("%v owners of /%s in %s repository fetched successfully", len(ownership.Owners), ownership.Path, *repoName)
+
+	response.Success(ctx, http.StatusOK, msg, dtos.OwnershipResponse(*ownership))
+}
+
+// GetCodeowners responds with the suggested CODEOWNERS file of a repository, as plain text when format is text
+func (ch CommitHandlers) GetCodeowners(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	repoName, content, unmapped, err := ch.manageGitCommitUsecase.GetCodeowners(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	if ctx.Query("format") == "text" {
+		ctx.String(http.StatusOK, content)
+		return
+	}
+
+	msg := fmt.Sprintf("%s repository CODEOWNERS suggested successfully", *repoName)
+
+	response.Success(ctx, http.StatusOK, msg, dtos.CodeownersResponseDto{Content: content, UnmappedAuthors: unmapped})
+}
+
+func (ch CommitHandlers) GetRepositoriesByCommit(ctx *gin.Context) {
+	commitID := ctx.Param("sha")
+
+	if commitID == "" {
+		response.Failure(ctx, http.StatusBadRequest, "sha is required", nil)
+		return
+	}
+
+	repos, err := ch.manageGitCommitUsecase.GetRepositoriesByCommit(ctx, commitID)
+	if err != nil {
+		if err == message.ErrCommitNotFound {
+			response.Failure(ctx, http.StatusNotFound, err.Error(), err.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("commit %s found in %v tracked repositories", commitID, len(repos))
+
+	response.Success(ctx, http.StatusOK, msg, dtos.AllRepoMetadataResponse(repos))
+}
+
+func (ch CommitHandlers) GetReferencesByCommit(ctx *gin.Context) {
+	commitID := ctx.Param("sha")
+
+	if commitID == "" {
+		response.Failure(ctx, http.StatusBadRequest, "sha is required", nil)
+		return
+	}
+
+	refs, err := ch.manageGitCommitUsecase.GetReferencesByCommit(ctx, commitID)
+	if err != nil {
+		if err == message.ErrCommitNotFound {
+			response.Failure(ctx, http.StatusNotFound, err.Error(), err.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	msg := fmt.Sprintf("%v references found in commit %s", len(refs), commitID)
+
+	response.Success(ctx, http.StatusOK, msg, dtos.CommitReferencesResponse(refs))
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kenmobility/git-api-service/internal/http/dtos"
+	"github.com/kenmobility/git-api-service/internal/usecases"
+	"github.com/kenmobility/git-api-service/pkg/helpers"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/kenmobility/git-api-service/pkg/response"
+)
+
+type ContributorHandlers struct {
+	contributorUsecase usecases.ContributorUsecase
+}
+
+func NewContributorHandler(contributorUsecase usecases.ContributorUsecase) *ContributorHandlers {
+	return &ContributorHandlers{
+		contributorUsecase: contributorUsecase,
+	}
+}
+
+func (ch ContributorHandlers) GetContributor(ctx *gin.Context) {
+	contributorId := ctx.Param("contributorId")
+
+	if contributorId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "contributorId is required", nil)
+		return
+	}
+
+	contributor, err := ch.contributorUsecase.GetContributor(ctx, contributorId)
+	if err != nil {
+		if err == message.ErrContributorNotFound {
+			response.Failure(ctx, http.StatusNotFound, err.Error(), err.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "contributor fetched successfully", dtos.ContributorResponse(*contributor))
+}
+
+func (ch ContributorHandlers) MergeContributors(ctx *gin.Context) {
+	var input dtos.MergeContributorsRequestDto
+
+	err := ctx.BindJSON(&input)
+	if err != nil {
+		response.Failure(ctx, http.StatusBadRequest, "invalid input", err)
+		return
+	}
+
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidInput.Error(), inputErrors)
+		return
+	}
+
+	contributor, err := ch.contributorUsecase.Merge(ctx, input.Into, input.Merge)
+	if err != nil {
+		if err == message.ErrInvalidAuthorKey {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+		if err == message.ErrContributorNotFound {
+			response.Failure(ctx, http.StatusNotFound, err.Error(), err.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "successfully merged contributors", dtos.ContributorResponse(*contributor))
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kenmobility/git-api-service/internal/http/dtos"
+	"github.com/kenmobility/git-api-service/internal/usecases"
+	"github.com/kenmobility/git-api-service/pkg/helpers"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/kenmobility/git-api-service/pkg/response"
+)
+
+type OrganizationHandlers struct {
+	organizationUsecase usecases.OrganizationUsecase
+}
+
+func NewOrganizationHandler(organizationUsecase usecases.OrganizationUsecase) *OrganizationHandlers {
+	return &OrganizationHandlers{
+		organizationUsecase: organizationUsecase,
+	}
+}
+
+func (oh OrganizationHandlers) ImportOrganization(ctx *gin.Context) {
+	var input dtos.ImportOrganizationRequestDto
+
+	err := ctx.BindJSON(&input)
+	if err != nil {
+		response.Failure(ctx, http.StatusBadRequest, "invalid input", err)
+		return
+	}
+
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidInput.Error(), inputErrors)
+		return
+	}
+
+	orgImport, err := oh.organizationUsecase.Import(ctx, input.Owner, dtos.OrgImportFilterFromDto(input), input.SyncMembership)
+	if err != nil {
+		if err == message.ErrInvalidOwnerName || err == message.ErrInvalidNamePattern {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+
+		if err == message.ErrOrgImportInProgress {
+			response.Failure(ctx, http.StatusConflict, err.Error(), err.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusAccepted, "organization repositories import started", dtos.OrgImportResponse(*orgImport))
+}
+
+func (oh OrganizationHandlers) FetchImportStatus(ctx *gin.Context) {
+	owner := ctx.Param("owner")
+	if owner == "" {
+		response.Failure(ctx, http.StatusBadRequest, "owner is required", nil)
+		return
+	}
+
+	orgImport, err := oh.organizationUsecase.ImportStatus(ctx, owner)
+	if err != nil {
+		if err == message.ErrOrgNotImported {
+			response.Failure(ctx, http.StatusNotFound, err.Error(), err.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "successfully fetched organization import status", dtos.OrgImportResponse(*orgImport))
+}
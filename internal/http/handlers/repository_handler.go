@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kenmobility/git-api-service/internal/http/dtos"
@@ -36,9 +38,15 @@ func (rh RepositoryHandlers) AddRepository(ctx *gin.Context) {
 		return
 	}
 
-	repo, err := rh.gitRepositoryUsecase.StartIndexing(ctx, input.Name)
+	opts, err := dtos.TrackingOptionsFromDto(input.TrackingSettingsDto)
 	if err != nil {
-		if err == message.ErrRepoAlreadyAdded {
+		response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidInput.Error(), err.Error())
+		return
+	}
+
+	repo, err := rh.gitRepositoryUsecase.StartIndexing(ctx, input.Name, opts)
+	if err != nil {
+		if err == message.ErrRepoAlreadyAdded || isTrackingSettingsError(err) {
 			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
 			return
 		}
@@ -48,6 +56,11 @@ func (rh RepositoryHandlers) AddRepository(ctx *gin.Context) {
 			return
 		}
 
+		if err == message.ErrRepoGone {
+			response.Failure(ctx, http.StatusNotFound, err.Error(), err.Error())
+			return
+		}
+
 		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
 		return
 	}
@@ -56,8 +69,13 @@ func (rh RepositoryHandlers) AddRepository(ctx *gin.Context) {
 }
 
 func (rh RepositoryHandlers) FetchAllRepositories(ctx *gin.Context) {
-	repos, err := rh.gitRepositoryUsecase.GetAll(ctx)
+	repos, err := rh.gitRepositoryUsecase.GetAll(ctx, ctx.Query("state"))
 	if err != nil {
+		if err == message.ErrInvalidUpstreamState {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+
 		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err)
 		return
 	}
@@ -90,3 +108,401 @@ func (rh RepositoryHandlers) FetchRepository(ctx *gin.Context) {
 
 	response.Success(ctx, http.StatusOK, "successfully fetched repository", dtos.RepoMetadataResponse(*repo))
 }
+
+func (rh RepositoryHandlers) DeleteRepository(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	retainCommits := false
+	if retain := ctx.Query("retain_commits"); retain != "" {
+		var err error
+		retainCommits, err = strconv.ParseBool(retain)
+		if err != nil {
+			response.Failure(ctx, http.StatusBadRequest, "retain_commits must be a boolean", err.Error())
+			return
+		}
+	}
+
+	err := rh.gitRepositoryUsecase.Untrack(ctx, repositoryId, retainCommits)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "repository successfully untracked", nil)
+}
+
+func (rh RepositoryHandlers) PauseRepository(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	repo, err := rh.gitRepositoryUsecase.Pause(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "repository monitoring paused", dtos.RepoMetadataResponse(*repo))
+}
+
+func (rh RepositoryHandlers) ResumeRepository(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	repo, err := rh.gitRepositoryUsecase.Resume(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "repository monitoring resumed", dtos.RepoMetadataResponse(*repo))
+}
+
+func (rh RepositoryHandlers) ReindexRepository(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	job, err := rh.gitRepositoryUsecase.Reindex(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+
+		if err == message.ErrRepoFetchInProgress {
+			response.Failure(ctx, http.StatusConflict, err.Error(), err.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusAccepted, "repository reindex started", dtos.ReindexJobResponse(*job))
+}
+
+func (rh RepositoryHandlers) FetchReindexStatus(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	job, err := rh.gitRepositoryUsecase.ReindexStatus(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+
+		if err == message.ErrRepoNotReindexed {
+			response.Failure(ctx, http.StatusNotFound, err.Error(), err.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "successfully fetched repository reindex status", dtos.ReindexJobResponse(*job))
+}
+
+func (rh RepositoryHandlers) UpdateRepository(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	var input dtos.UpdateRepositoryRequestDto
+
+	err := ctx.BindJSON(&input)
+	if err != nil {
+		response.Failure(ctx, http.StatusBadRequest, "invalid input", err)
+		return
+	}
+
+	opts, err := dtos.TrackingOptionsFromDto(input.TrackingSettingsDto)
+	if err != nil {
+		response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidInput.Error(), err.Error())
+		return
+	}
+
+	repo, err := rh.gitRepositoryUsecase.UpdateTracking(ctx, repositoryId, opts)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+
+		if isTrackingSettingsError(err) {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "repository tracking settings successfully updated", dtos.RepoMetadataResponse(*repo))
+}
+
+func isTrackingSettingsError(err error) bool {
+	return err == message.ErrInvalidTrackingWindow || err == message.ErrInvalidFetchInterval || err == message.ErrInvalidCommitsPerPage ||
+		err == message.ErrInvalidBranchPattern
+}
+
+func (rh RepositoryHandlers) FetchBackfills(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	ranges, jobs, err := rh.gitRepositoryUsecase.BackfillJobs(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "successfully fetched repository backfills", dtos.BackfillStatusResponse(ranges, jobs))
+}
+
+func (rh RepositoryHandlers) VerifyRepository(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	report, err := rh.gitRepositoryUsecase.Verify(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+
+		if err == message.ErrRepoFetchInProgress {
+			response.Failure(ctx, http.StatusConflict, err.Error(), err.Error())
+			return
+		}
+
+		if err == message.ErrRateLimitExceeded {
+			response.Failure(ctx, http.StatusForbidden, err.Error(), err.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "repository history successfully verified", dtos.IntegrityReportResponse(*report))
+}
+
+func (rh RepositoryHandlers) FetchIntegrityReport(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	report, err := rh.gitRepositoryUsecase.IntegrityReport(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+
+		if err == message.ErrRepoNotVerified {
+			response.Failure(ctx, http.StatusNotFound, err.Error(), err.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "successfully fetched repository integrity report", dtos.IntegrityReportResponse(*report))
+}
+
+func (rh RepositoryHandlers) FetchMetadataHistory(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	var from, to time.Time
+	if v := ctx.Query("from"); v != "" {
+		var err error
+		from, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			response.Failure(ctx, http.StatusBadRequest, "from must be an RFC3339 date", err.Error())
+			return
+		}
+	}
+	if v := ctx.Query("to"); v != "" {
+		var err error
+		to, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			response.Failure(ctx, http.StatusBadRequest, "to must be an RFC3339 date", err.Error())
+			return
+		}
+	}
+	interval := ctx.Query("interval")
+
+	snapshots, err := rh.gitRepositoryUsecase.MetadataHistory(ctx, repositoryId, from, to, interval)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+
+		if err == message.ErrInvalidInterval || err == message.ErrInvalidHistoryWindow {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "successfully fetched repository metadata history", dtos.MetadataHistoryResponse(interval, snapshots))
+}
+
+func (rh RepositoryHandlers) FetchStarHistory(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	history, err := rh.gitRepositoryUsecase.StarHistory(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "successfully fetched repository star history", dtos.StarHistoryResponse(*history))
+}
+
+func (rh RepositoryHandlers) FetchFastestGrowing(ctx *gin.Context) {
+	days, err := strconv.Atoi(ctx.DefaultQuery("days", "30"))
+	if err != nil || days < 1 {
+		response.Failure(ctx, http.StatusBadRequest, "days must be a positive number", nil)
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 {
+		response.Failure(ctx, http.StatusBadRequest, "limit must be a positive number", nil)
+		return
+	}
+
+	growth, err := rh.gitRepositoryUsecase.FastestGrowing(ctx, time.Now().AddDate(0, 0, -days), limit)
+	if err != nil {
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "successfully fetched fastest growing repositories", dtos.FastestGrowingResponse(growth))
+}
+
+func (rh RepositoryHandlers) EstimateRepository(ctx *gin.Context) {
+	var input dtos.AddRepositoryRequestDto
+
+	err := ctx.BindJSON(&input)
+	if err != nil {
+		response.Failure(ctx, http.StatusBadRequest, "invalid input", err)
+		return
+	}
+
+	inputErrors := helpers.ValidateInput(input)
+	if inputErrors != nil {
+		response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidInput.Error(), inputErrors)
+		return
+	}
+
+	opts, err := dtos.TrackingOptionsFromDto(input.TrackingSettingsDto)
+	if err != nil {
+		response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidInput.Error(), err.Error())
+		return
+	}
+
+	estimate, err := rh.gitRepositoryUsecase.Estimate(ctx, input.Name, opts)
+	if err != nil {
+		if err == message.ErrRepoGone {
+			response.Failure(ctx, http.StatusNotFound, err.Error(), err.Error())
+			return
+		}
+
+		if err == message.ErrInvalidRepositoryName || err == message.ErrRepoMetaDataNotFetched || isTrackingSettingsError(err) {
+			response.Failure(ctx, http.StatusBadRequest, err.Error(), err.Error())
+			return
+		}
+
+		if err == message.ErrRateLimitExceeded {
+			response.Failure(ctx, http.StatusForbidden, err.Error(), err.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "repository indexing cost successfully estimated", dtos.IndexingEstimateResponse(*estimate))
+}
+
+func (rh RepositoryHandlers) FetchEnrichmentState(ctx *gin.Context) {
+	repositoryId := ctx.Param("repoId")
+	if repositoryId == "" {
+		response.Failure(ctx, http.StatusBadRequest, "repoId is required", nil)
+		return
+	}
+
+	state, err := rh.gitRepositoryUsecase.EnrichmentState(ctx, repositoryId)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			response.Failure(ctx, http.StatusBadRequest, message.ErrInvalidRepositoryId.Error(), message.ErrInvalidRepositoryId.Error())
+			return
+		}
+
+		response.Failure(ctx, http.StatusInternalServerError, err.Error(), err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "successfully fetched repository commits enrichment state", dtos.EnrichmentStateResponse(*state))
+}
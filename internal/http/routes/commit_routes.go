@@ -8,4 +8,17 @@ import (
 func CommitRoutes(r *gin.Engine, ch *handlers.CommitHandlers) {
 	r.GET("/repos/:repoId/commits", ch.GetCommitsByRepositoryId)
 	r.GET("/repos/:repoId/top-authors", ch.GetTopCommitAuthors)
+	r.GET("/repos/:repoId/branches", ch.GetBranchesByRepositoryId)
+	r.GET("/repos/:repoId/releases", ch.GetReleasesByRepositoryId)
+	r.GET("/repos/:repoId/releases/:tag/commits", ch.GetCommitsByRelease)
+	r.GET("/repos/:repoId/pulls", ch.GetPullRequestsByRepositoryId)
+	r.GET("/repos/:repoId/issues/:number/commits", ch.GetCommitsByIssue)
+	r.GET("/repos/:repoId/commits/:sha/checks", ch.GetCheckRunsByCommit)
+	r.GET("/repos/:repoId/ci-health", ch.GetCIHealth)
+	r.GET("/repos/:repoId/hotspots", ch.GetHotspots)
+	r.GET("/repos/:repoId/files/history", ch.GetFileHistory)
+	r.GET("/repos/:repoId/ownership", ch.GetOwnership)
+	r.GET("/repos/:repoId/codeowners", ch.GetCodeowners)
+	r.GET("/commits/:sha/repositories", ch.GetRepositoriesByCommit)
+	r.GET("/commits/:sha/references", ch.GetReferencesByCommit)
 }
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kenmobility/git-api-service/internal/http/handlers"
+)
+
+func ContributorRoutes(r *gin.Engine, ch *handlers.ContributorHandlers) {
+	r.POST("/contributors/merge", ch.MergeContributors)
+	r.GET("/contributors/:contributorId", ch.GetContributor)
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/kenmobility/git-api-service/internal/http/handlers"
+)
+
+func OrganizationRoutes(r *gin.Engine, oh *handlers.OrganizationHandlers) {
+	r.POST("/organizations/import", oh.ImportOrganization)
+	r.GET("/organizations/:owner/import", oh.FetchImportStatus)
+}
@@ -7,6 +7,20 @@ import (
 
 func RepositoryRoutes(r *gin.Engine, rh *handlers.RepositoryHandlers) {
 	r.POST("/repository", rh.AddRepository)
+	r.POST("/repository/estimate", rh.EstimateRepository)
 	r.GET("/repositories", rh.FetchAllRepositories)
+	r.GET("/repositories/fastest-growing", rh.FetchFastestGrowing)
 	r.GET("/repository/:repoId", rh.FetchRepository)
+	r.PATCH("/repository/:repoId", rh.UpdateRepository)
+	r.DELETE("/repository/:repoId", rh.DeleteRepository)
+	r.POST("/repository/:repoId/pause", rh.PauseRepository)
+	r.POST("/repository/:repoId/resume", rh.ResumeRepository)
+	r.POST("/repository/:repoId/reindex", rh.ReindexRepository)
+	r.GET("/repository/:repoId/reindex", rh.FetchReindexStatus)
+	r.GET("/repository/:repoId/backfills", rh.FetchBackfills)
+	r.POST("/repository/:repoId/verify", rh.VerifyRepository)
+	r.GET("/repository/:repoId/integrity", rh.FetchIntegrityReport)
+	r.GET("/repository/:repoId/metadata/history", rh.FetchMetadataHistory)
+	r.GET("/repository/:repoId/stars", rh.FetchStarHistory)
+	r.GET("/repository/:repoId/enrichment", rh.FetchEnrichmentState)
 }
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type BackfillRepository interface {
+	SyncRangesByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.SyncRange, error)
+	ReplaceSyncRanges(ctx context.Context, repo domain.RepoMetadata, ranges []domain.SyncRange) error
+	SaveBackfillJob(ctx context.Context, job domain.BackfillJob) (*domain.BackfillJob, error)
+	UpdateBackfillJob(ctx context.Context, job domain.BackfillJob) error
+	BackfillJobsByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.BackfillJob, error)
+	SaveReindexJob(ctx context.Context, job domain.ReindexJob) (*domain.ReindexJob, error)
+	UpdateReindexJob(ctx context.Context, job domain.ReindexJob) error
+	LatestReindexJob(ctx context.Context, repo domain.RepoMetadata) (*domain.ReindexJob, error)
+	FailRunningReindexJobs(ctx context.Context, reason string) error
+}
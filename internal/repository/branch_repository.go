@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type BranchRepository interface {
+	SaveBranch(ctx context.Context, branch domain.Branch) (*domain.Branch, error)
+	BranchByName(ctx context.Context, repo domain.RepoMetadata, name string) (*domain.Branch, error)
+	BranchesByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.Branch, error)
+	DeleteBranch(ctx context.Context, repo domain.RepoMetadata, name string) error
+	SaveBranchCommits(ctx context.Context, repo domain.RepoMetadata, branch string, commitIDs []string) (int, error)
+	PruneBranchCommits(ctx context.Context, repo domain.RepoMetadata, branch string, reachable []string) (int, error)
+	BranchesByCommitIDs(ctx context.Context, repo domain.RepoMetadata, commitIDs []string) (map[string][]string, error)
+}
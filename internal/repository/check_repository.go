@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type CheckRepository interface {
+	// CommitsPendingChecks fetches at most limit commits of the tracked branches of a repository committed since the
+	// given date whose checks are not settled yet, newest first
+	CommitsPendingChecks(ctx context.Context, repo domain.RepoMetadata, since time.Time, limit int) ([]domain.Commit, error)
+	// SaveCheckRuns stores the check runs of a commit of a repository and whether its checks are settled
+	SaveCheckRuns(ctx context.Context, repo domain.RepoMetadata, commitID string, runs []domain.CheckRun, settled bool) error
+	CheckRunsByCommitID(ctx context.Context, repo domain.RepoMetadata, commitID string) ([]domain.CheckRun, error)
+	// CheckRunsByRepository fetches the check runs of a repository started within the given window, a zero date leaves it open
+	CheckRunsByRepository(ctx context.Context, repo domain.RepoMetadata, from, to time.Time) ([]domain.CheckRun, error)
+}
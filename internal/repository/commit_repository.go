@@ -8,7 +8,16 @@ import (
 
 type CommitRepository interface {
 	SaveCommit(ctx context.Context, commit domain.Commit) (*domain.Commit, error)
+	SaveCommits(ctx context.Context, commits []domain.Commit) ([]domain.Commit, error)
 	GetByCommitID(ctx context.Context, commitID string) (*domain.Commit, error)
-	AllCommitsByRepository(ctx context.Context, repoMetadata domain.RepoMetadata, query domain.APIPagingData) ([]domain.Commit, *domain.PagingInfo, error)
+	// StoredCommitIDs returns which of commitIDs are stored for a repository
+	StoredCommitIDs(ctx context.Context, repo domain.RepoMetadata, commitIDs []string) ([]string, error)
+	AllCommitsByRepository(ctx context.Context, repoMetadata domain.RepoMetadata, filter domain.CommitFilter, query domain.APIPagingData) ([]domain.Commit, *domain.PagingInfo, error)
 	TopCommitAuthorsByRepository(ctx context.Context, repo domain.RepoMetadata, limit int) ([]domain.AuthorCommitCount, error)
+	LatestCommit(ctx context.Context, repo domain.RepoMetadata) (*domain.Commit, error)
+	CommitsByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.Commit, error)
+	UnreleasedCommits(ctx context.Context, repo domain.RepoMetadata) ([]domain.Commit, error)
+	// ReleasedChildren fetches the released commits of a repository with an unreleased parent
+	ReleasedChildren(ctx context.Context, repo domain.RepoMetadata) ([]domain.Commit, error)
+	ApplyCommitDiff(ctx context.Context, repo domain.RepoMetadata, diff domain.CommitDiff) error
 }
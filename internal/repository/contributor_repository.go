@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type ContributorRepository interface {
+	// UnresolvedAuthors fetches the distinct author identities of the commits of a repository whose key, or whose email
+	// for an author with a login, is not resolved to a contributor yet
+	UnresolvedAuthors(ctx context.Context, repo domain.RepoMetadata) ([]domain.Identity, error)
+	// AuthoredOutsideRepository reports whether commits of a repository other than repo are authored under one of keys
+	AuthoredOutsideRepository(ctx context.Context, repo domain.RepoMetadata, keys []string) (bool, error)
+	ContributorByIdentityKey(ctx context.Context, key string) (*domain.Contributor, error)
+	ContributorByPublicId(ctx context.Context, publicID string) (*domain.Contributor, error)
+	CreateContributor(ctx context.Context, contributor domain.Contributor, identities []domain.ContributorIdentity) (*domain.Contributor, error)
+	UpdateContributor(ctx context.Context, contributor domain.Contributor) error
+	// AddContributorIdentities resolves identities to a contributor, identities already resolved are left as they are
+	AddContributorIdentities(ctx context.Context, contributor domain.Contributor, identities []domain.ContributorIdentity) error
+	// MergeContributors moves the identities of the sources to the target contributor and deletes the sources
+	MergeContributors(ctx context.Context, target domain.Contributor, sources []domain.Contributor) error
+}
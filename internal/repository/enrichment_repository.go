@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type EnrichmentRepository interface {
+	// UnenrichedCommits fetches at most limit commits of a repository not enriched with their file changes yet, newest first
+	UnenrichedCommits(ctx context.Context, repo domain.RepoMetadata, limit int) ([]domain.Commit, error)
+	SaveCommitDetail(ctx context.Context, commitID string, detail domain.CommitDetail) error
+	// SaveEnrichmentFailure records the error the detail of a commit failed to be fetched with for good, the commit is not retried
+	SaveEnrichmentFailure(ctx context.Context, commitID string, reason string) error
+	// SaveEnrichmentRetry records a transient failure to fetch the detail of a commit, which is fetched again after retryAt,
+	// and records reason as its failure once it failed maxAttempts times
+	SaveEnrichmentRetry(ctx context.Context, commitID string, reason string, retryAt time.Time, maxAttempts int) error
+	EnrichmentStateByRepository(ctx context.Context, repo domain.RepoMetadata) (*domain.EnrichmentState, error)
+	// SaveEnrichmentRun records the date of an enrichment run of a repository and the error it stopped on, if any
+	SaveEnrichmentRun(ctx context.Context, repo domain.RepoMetadata, runAt time.Time, runErr string) error
+}
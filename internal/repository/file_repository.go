@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type FileRepository interface {
+	// PathStatsByRepository fetches the stats of the files and the directories changed by the enriched commits of a
+	// repository committed within the given window under a path prefix, aggregated per path, a zero date leaves the
+	// window open and an empty prefix matches every path
+	PathStatsByRepository(ctx context.Context, repo domain.RepoMetadata, from, to time.Time, pathPrefix string) ([]domain.Hotspot, error)
+	// AuthorChangesByRepository fetches the changes of the directories, the root included, changed by the enriched
+	// commits of a repository under a path prefix, aggregated per directory and author, each change weighted by its
+	// lines and halved every halfLife before now, zero disabling the decay
+	AuthorChangesByRepository(ctx context.Context, repo domain.RepoMetadata, pathPrefix string, halfLife time.Duration, now time.Time) ([]domain.AuthorChanges, error)
+	// LatestFileRename fetches the newest change renaming a file to path by the commits of a repository committed
+	// before before, a zero date matching every commit
+	LatestFileRename(ctx context.Context, repo domain.RepoMetadata, path string, before time.Time) (*domain.FileRevision, error)
+	// FileRevisionsBySpans fetches a page of the changes of the paths a file had over the spans of its history by the
+	// commits of a repository, newest first
+	FileRevisionsBySpans(ctx context.Context, repo domain.RepoMetadata, spans []domain.FilePathSpan, query domain.APIPagingData) ([]domain.FileRevision, *domain.PagingInfo, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type IntegrityRepository interface {
+	SaveIntegrityReport(ctx context.Context, report domain.IntegrityReport) (*domain.IntegrityReport, error)
+	LatestIntegrityReport(ctx context.Context, repo domain.RepoMetadata) (*domain.IntegrityReport, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type IssueRepository interface {
+	SaveIssues(ctx context.Context, issues []domain.Issue) error
+	LatestIssueUpdate(ctx context.Context, repo domain.RepoMetadata) (*time.Time, error)
+	IssueByNumber(ctx context.Context, repo domain.RepoMetadata, number int) (*domain.Issue, error)
+	// UnparsedCommits fetches at most limit commits of a repository whose message was not parsed for references yet
+	UnparsedCommits(ctx context.Context, repo domain.RepoMetadata, limit int) ([]domain.Commit, error)
+	// SaveCommitReferences stores the references found in the messages of commits of a repository and marks the commits parsed
+	SaveCommitReferences(ctx context.Context, repo domain.RepoMetadata, commitIDs []string, refs []domain.CommitReference) error
+	CommitsByIssue(ctx context.Context, repo domain.RepoMetadata, number int) ([]domain.ReferencingCommit, error)
+	ReferencesByCommitID(ctx context.Context, commitID string) ([]domain.CommitReference, error)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type MetadataSnapshotRepository interface {
+	SaveMetadataSnapshot(ctx context.Context, snapshot domain.RepoMetadataSnapshot) (*domain.RepoMetadataSnapshot, error)
+	MetadataSnapshots(ctx context.Context, repo domain.RepoMetadata, from time.Time, to time.Time) ([]domain.RepoMetadataSnapshot, error)
+}
@@ -12,6 +12,7 @@ package repo_mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/kenmobility/git-api-service/internal/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -40,110 +41,1245 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddContributorIdentities mocks base method.
+func (m *MockRepository) AddContributorIdentities(arg0 context.Context, arg1 domain.Contributor, arg2 []domain.ContributorIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddContributorIdentities", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddContributorIdentities indicates an expected call of AddContributorIdentities.
+func (mr *MockRepositoryMockRecorder) AddContributorIdentities(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContributorIdentities", reflect.TypeOf((*MockRepository)(nil).AddContributorIdentities), arg0, arg1, arg2)
+}
+
 // AllCommitsByRepository mocks base method.
-func (m *MockRepository) AllCommitsByRepository(arg0 context.Context, arg1 domain.RepoMetadata, arg2 domain.APIPagingData) ([]domain.Commit, *domain.PagingInfo, error) {
+func (m *MockRepository) AllCommitsByRepository(arg0 context.Context, arg1 domain.RepoMetadata, arg2 domain.CommitFilter, arg3 domain.APIPagingData) ([]domain.Commit, *domain.PagingInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllCommitsByRepository", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Commit)
+	ret1, _ := ret[1].(*domain.PagingInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AllCommitsByRepository indicates an expected call of AllCommitsByRepository.
+func (mr *MockRepositoryMockRecorder) AllCommitsByRepository(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllCommitsByRepository", reflect.TypeOf((*MockRepository)(nil).AllCommitsByRepository), arg0, arg1, arg2, arg3)
+}
+
+// AllOrgMemberships mocks base method.
+func (m *MockRepository) AllOrgMemberships(arg0 context.Context) ([]domain.OrgMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllOrgMemberships", arg0)
+	ret0, _ := ret[0].([]domain.OrgMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllOrgMemberships indicates an expected call of AllOrgMemberships.
+func (mr *MockRepositoryMockRecorder) AllOrgMemberships(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllOrgMemberships", reflect.TypeOf((*MockRepository)(nil).AllOrgMemberships), arg0)
+}
+
+// AllRepoMetadata mocks base method.
+func (m *MockRepository) AllRepoMetadata(arg0 context.Context) ([]domain.RepoMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllRepoMetadata", arg0)
+	ret0, _ := ret[0].([]domain.RepoMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllRepoMetadata indicates an expected call of AllRepoMetadata.
+func (mr *MockRepositoryMockRecorder) AllRepoMetadata(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllRepoMetadata", reflect.TypeOf((*MockRepository)(nil).AllRepoMetadata), arg0)
+}
+
+// ApplyCommitDiff mocks base method.
+func (m *MockRepository) ApplyCommitDiff(arg0 context.Context, arg1 domain.RepoMetadata, arg2 domain.CommitDiff) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyCommitDiff", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyCommitDiff indicates an expected call of ApplyCommitDiff.
+func (mr *MockRepositoryMockRecorder) ApplyCommitDiff(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyCommitDiff", reflect.TypeOf((*MockRepository)(nil).ApplyCommitDiff), arg0, arg1, arg2)
+}
+
+// AuthorChangesByRepository mocks base method.
+func (m *MockRepository) AuthorChangesByRepository(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string, arg3 time.Duration, arg4 time.Time) ([]domain.AuthorChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorChangesByRepository", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]domain.AuthorChanges)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorChangesByRepository indicates an expected call of AuthorChangesByRepository.
+func (mr *MockRepositoryMockRecorder) AuthorChangesByRepository(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorChangesByRepository", reflect.TypeOf((*MockRepository)(nil).AuthorChangesByRepository), arg0, arg1, arg2, arg3, arg4)
+}
+
+// AuthoredOutsideRepository mocks base method.
+func (m *MockRepository) AuthoredOutsideRepository(arg0 context.Context, arg1 domain.RepoMetadata, arg2 []string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthoredOutsideRepository", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthoredOutsideRepository indicates an expected call of AuthoredOutsideRepository.
+func (mr *MockRepositoryMockRecorder) AuthoredOutsideRepository(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthoredOutsideRepository", reflect.TypeOf((*MockRepository)(nil).AuthoredOutsideRepository), arg0, arg1, arg2)
+}
+
+// BackfillJobsByRepository mocks base method.
+func (m *MockRepository) BackfillJobsByRepository(arg0 context.Context, arg1 domain.RepoMetadata) ([]domain.BackfillJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackfillJobsByRepository", arg0, arg1)
+	ret0, _ := ret[0].([]domain.BackfillJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BackfillJobsByRepository indicates an expected call of BackfillJobsByRepository.
+func (mr *MockRepositoryMockRecorder) BackfillJobsByRepository(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackfillJobsByRepository", reflect.TypeOf((*MockRepository)(nil).BackfillJobsByRepository), arg0, arg1)
+}
+
+// BranchByName mocks base method.
+func (m *MockRepository) BranchByName(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string) (*domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BranchByName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BranchByName indicates an expected call of BranchByName.
+func (mr *MockRepositoryMockRecorder) BranchByName(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchByName", reflect.TypeOf((*MockRepository)(nil).BranchByName), arg0, arg1, arg2)
+}
+
+// BranchesByCommitIDs mocks base method.
+func (m *MockRepository) BranchesByCommitIDs(arg0 context.Context, arg1 domain.RepoMetadata, arg2 []string) (map[string][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BranchesByCommitIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BranchesByCommitIDs indicates an expected call of BranchesByCommitIDs.
+func (mr *MockRepositoryMockRecorder) BranchesByCommitIDs(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchesByCommitIDs", reflect.TypeOf((*MockRepository)(nil).BranchesByCommitIDs), arg0, arg1, arg2)
+}
+
+// BranchesByRepository mocks base method.
+func (m *MockRepository) BranchesByRepository(arg0 context.Context, arg1 domain.RepoMetadata) ([]domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BranchesByRepository", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BranchesByRepository indicates an expected call of BranchesByRepository.
+func (mr *MockRepositoryMockRecorder) BranchesByRepository(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchesByRepository", reflect.TypeOf((*MockRepository)(nil).BranchesByRepository), arg0, arg1)
+}
+
+// CheckRunsByCommitID mocks base method.
+func (m *MockRepository) CheckRunsByCommitID(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string) ([]domain.CheckRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRunsByCommitID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.CheckRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckRunsByCommitID indicates an expected call of CheckRunsByCommitID.
+func (mr *MockRepositoryMockRecorder) CheckRunsByCommitID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRunsByCommitID", reflect.TypeOf((*MockRepository)(nil).CheckRunsByCommitID), arg0, arg1, arg2)
+}
+
+// CheckRunsByRepository mocks base method.
+func (m *MockRepository) CheckRunsByRepository(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3 time.Time) ([]domain.CheckRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRunsByRepository", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.CheckRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckRunsByRepository indicates an expected call of CheckRunsByRepository.
+func (mr *MockRepositoryMockRecorder) CheckRunsByRepository(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRunsByRepository", reflect.TypeOf((*MockRepository)(nil).CheckRunsByRepository), arg0, arg1, arg2, arg3)
+}
+
+// ClaimFetching mocks base method.
+func (m *MockRepository) ClaimFetching(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimFetching", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimFetching indicates an expected call of ClaimFetching.
+func (mr *MockRepositoryMockRecorder) ClaimFetching(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimFetching", reflect.TypeOf((*MockRepository)(nil).ClaimFetching), arg0, arg1)
+}
+
+// CommitsByIssue mocks base method.
+func (m *MockRepository) CommitsByIssue(arg0 context.Context, arg1 domain.RepoMetadata, arg2 int) ([]domain.ReferencingCommit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitsByIssue", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.ReferencingCommit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitsByIssue indicates an expected call of CommitsByIssue.
+func (mr *MockRepositoryMockRecorder) CommitsByIssue(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitsByIssue", reflect.TypeOf((*MockRepository)(nil).CommitsByIssue), arg0, arg1, arg2)
+}
+
+// CommitsByRepository mocks base method.
+func (m *MockRepository) CommitsByRepository(arg0 context.Context, arg1 domain.RepoMetadata) ([]domain.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitsByRepository", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitsByRepository indicates an expected call of CommitsByRepository.
+func (mr *MockRepositoryMockRecorder) CommitsByRepository(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitsByRepository", reflect.TypeOf((*MockRepository)(nil).CommitsByRepository), arg0, arg1)
+}
+
+// CommitsPendingChecks mocks base method.
+func (m *MockRepository) CommitsPendingChecks(arg0 context.Context, arg1 domain.RepoMetadata, arg2 time.Time, arg3 int) ([]domain.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitsPendingChecks", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitsPendingChecks indicates an expected call of CommitsPendingChecks.
+func (mr *MockRepositoryMockRecorder) CommitsPendingChecks(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitsPendingChecks", reflect.TypeOf((*MockRepository)(nil).CommitsPendingChecks), arg0, arg1, arg2, arg3)
+}
+
+// ContributorByIdentityKey mocks base method.
+func (m *MockRepository) ContributorByIdentityKey(arg0 context.Context, arg1 string) (*domain.Contributor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContributorByIdentityKey", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contributor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContributorByIdentityKey indicates an expected call of ContributorByIdentityKey.
+func (mr *MockRepositoryMockRecorder) ContributorByIdentityKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContributorByIdentityKey", reflect.TypeOf((*MockRepository)(nil).ContributorByIdentityKey), arg0, arg1)
+}
+
+// ContributorByPublicId mocks base method.
+func (m *MockRepository) ContributorByPublicId(arg0 context.Context, arg1 string) (*domain.Contributor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContributorByPublicId", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Contributor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ContributorByPublicId indicates an expected call of ContributorByPublicId.
+func (mr *MockRepositoryMockRecorder) ContributorByPublicId(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContributorByPublicId", reflect.TypeOf((*MockRepository)(nil).ContributorByPublicId), arg0, arg1)
+}
+
+// CreateContributor mocks base method.
+func (m *MockRepository) CreateContributor(arg0 context.Context, arg1 domain.Contributor, arg2 []domain.ContributorIdentity) (*domain.Contributor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContributor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Contributor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContributor indicates an expected call of CreateContributor.
+func (mr *MockRepositoryMockRecorder) CreateContributor(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContributor", reflect.TypeOf((*MockRepository)(nil).CreateContributor), arg0, arg1, arg2)
+}
+
+// DeleteBranch mocks base method.
+func (m *MockRepository) DeleteBranch(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBranch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBranch indicates an expected call of DeleteBranch.
+func (mr *MockRepositoryMockRecorder) DeleteBranch(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBranch", reflect.TypeOf((*MockRepository)(nil).DeleteBranch), arg0, arg1, arg2)
+}
+
+// DeleteRepoMetadata mocks base method.
+func (m *MockRepository) DeleteRepoMetadata(arg0 context.Context, arg1 domain.RepoMetadata, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRepoMetadata", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRepoMetadata indicates an expected call of DeleteRepoMetadata.
+func (mr *MockRepositoryMockRecorder) DeleteRepoMetadata(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRepoMetadata", reflect.TypeOf((*MockRepository)(nil).DeleteRepoMetadata), arg0, arg1, arg2)
+}
+
+// DeleteStargazersExcept mocks base method.
+func (m *MockRepository) DeleteStargazersExcept(arg0 context.Context, arg1 domain.RepoMetadata, arg2 []string, arg3 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStargazersExcept", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStargazersExcept indicates an expected call of DeleteStargazersExcept.
+func (mr *MockRepositoryMockRecorder) DeleteStargazersExcept(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStargazersExcept", reflect.TypeOf((*MockRepository)(nil).DeleteStargazersExcept), arg0, arg1, arg2, arg3)
+}
+
+// EnrichmentStateByRepository mocks base method.
+func (m *MockRepository) EnrichmentStateByRepository(arg0 context.Context, arg1 domain.RepoMetadata) (*domain.EnrichmentState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrichmentStateByRepository", arg0, arg1)
+	ret0, _ := ret[0].(*domain.EnrichmentState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrichmentStateByRepository indicates an expected call of EnrichmentStateByRepository.
+func (mr *MockRepositoryMockRecorder) EnrichmentStateByRepository(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrichmentStateByRepository", reflect.TypeOf((*MockRepository)(nil).EnrichmentStateByRepository), arg0, arg1)
+}
+
+// FailRunningOrgImports mocks base method.
+func (m *MockRepository) FailRunningOrgImports(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailRunningOrgImports", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailRunningOrgImports indicates an expected call of FailRunningOrgImports.
+func (mr *MockRepositoryMockRecorder) FailRunningOrgImports(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailRunningOrgImports", reflect.TypeOf((*MockRepository)(nil).FailRunningOrgImports), arg0, arg1)
+}
+
+// FailRunningReindexJobs mocks base method.
+func (m *MockRepository) FailRunningReindexJobs(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailRunningReindexJobs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailRunningReindexJobs indicates an expected call of FailRunningReindexJobs.
+func (mr *MockRepositoryMockRecorder) FailRunningReindexJobs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailRunningReindexJobs", reflect.TypeOf((*MockRepository)(nil).FailRunningReindexJobs), arg0, arg1)
+}
+
+// FastestGrowingRepos mocks base method.
+func (m *MockRepository) FastestGrowingRepos(arg0 context.Context, arg1 time.Time, arg2 int) ([]domain.StarGrowth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FastestGrowingRepos", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.StarGrowth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FastestGrowingRepos indicates an expected call of FastestGrowingRepos.
+func (mr *MockRepositoryMockRecorder) FastestGrowingRepos(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FastestGrowingRepos", reflect.TypeOf((*MockRepository)(nil).FastestGrowingRepos), arg0, arg1, arg2)
+}
+
+// FileRevisionsBySpans mocks base method.
+func (m *MockRepository) FileRevisionsBySpans(arg0 context.Context, arg1 domain.RepoMetadata, arg2 []domain.FilePathSpan, arg3 domain.APIPagingData) ([]domain.FileRevision, *domain.PagingInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileRevisionsBySpans", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.FileRevision)
+	ret1, _ := ret[1].(*domain.PagingInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FileRevisionsBySpans indicates an expected call of FileRevisionsBySpans.
+func (mr *MockRepositoryMockRecorder) FileRevisionsBySpans(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileRevisionsBySpans", reflect.TypeOf((*MockRepository)(nil).FileRevisionsBySpans), arg0, arg1, arg2, arg3)
+}
+
+// GetByCommitID mocks base method.
+func (m *MockRepository) GetByCommitID(arg0 context.Context, arg1 string) (*domain.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCommitID", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCommitID indicates an expected call of GetByCommitID.
+func (mr *MockRepositoryMockRecorder) GetByCommitID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCommitID", reflect.TypeOf((*MockRepository)(nil).GetByCommitID), arg0, arg1)
+}
+
+// IssueByNumber mocks base method.
+func (m *MockRepository) IssueByNumber(arg0 context.Context, arg1 domain.RepoMetadata, arg2 int) (*domain.Issue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueByNumber", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Issue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueByNumber indicates an expected call of IssueByNumber.
+func (mr *MockRepositoryMockRecorder) IssueByNumber(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueByNumber", reflect.TypeOf((*MockRepository)(nil).IssueByNumber), arg0, arg1, arg2)
+}
+
+// LatestCommit mocks base method.
+func (m *MockRepository) LatestCommit(arg0 context.Context, arg1 domain.RepoMetadata) (*domain.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestCommit", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestCommit indicates an expected call of LatestCommit.
+func (mr *MockRepositoryMockRecorder) LatestCommit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestCommit", reflect.TypeOf((*MockRepository)(nil).LatestCommit), arg0, arg1)
+}
+
+// LatestFileRename mocks base method.
+func (m *MockRepository) LatestFileRename(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string, arg3 time.Time) (*domain.FileRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestFileRename", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.FileRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestFileRename indicates an expected call of LatestFileRename.
+func (mr *MockRepositoryMockRecorder) LatestFileRename(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestFileRename", reflect.TypeOf((*MockRepository)(nil).LatestFileRename), arg0, arg1, arg2, arg3)
+}
+
+// LatestIntegrityReport mocks base method.
+func (m *MockRepository) LatestIntegrityReport(arg0 context.Context, arg1 domain.RepoMetadata) (*domain.IntegrityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestIntegrityReport", arg0, arg1)
+	ret0, _ := ret[0].(*domain.IntegrityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestIntegrityReport indicates an expected call of LatestIntegrityReport.
+func (mr *MockRepositoryMockRecorder) LatestIntegrityReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestIntegrityReport", reflect.TypeOf((*MockRepository)(nil).LatestIntegrityReport), arg0, arg1)
+}
+
+// LatestIssueUpdate mocks base method.
+func (m *MockRepository) LatestIssueUpdate(arg0 context.Context, arg1 domain.RepoMetadata) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestIssueUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestIssueUpdate indicates an expected call of LatestIssueUpdate.
+func (mr *MockRepositoryMockRecorder) LatestIssueUpdate(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestIssueUpdate", reflect.TypeOf((*MockRepository)(nil).LatestIssueUpdate), arg0, arg1)
+}
+
+// LatestOrgImport mocks base method.
+func (m *MockRepository) LatestOrgImport(arg0 context.Context, arg1 string) (*domain.OrgImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestOrgImport", arg0, arg1)
+	ret0, _ := ret[0].(*domain.OrgImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestOrgImport indicates an expected call of LatestOrgImport.
+func (mr *MockRepositoryMockRecorder) LatestOrgImport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestOrgImport", reflect.TypeOf((*MockRepository)(nil).LatestOrgImport), arg0, arg1)
+}
+
+// LatestReindexJob mocks base method.
+func (m *MockRepository) LatestReindexJob(arg0 context.Context, arg1 domain.RepoMetadata) (*domain.ReindexJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestReindexJob", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ReindexJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestReindexJob indicates an expected call of LatestReindexJob.
+func (mr *MockRepositoryMockRecorder) LatestReindexJob(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestReindexJob", reflect.TypeOf((*MockRepository)(nil).LatestReindexJob), arg0, arg1)
+}
+
+// MergeContributors mocks base method.
+func (m *MockRepository) MergeContributors(arg0 context.Context, arg1 domain.Contributor, arg2 []domain.Contributor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeContributors", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeContributors indicates an expected call of MergeContributors.
+func (mr *MockRepositoryMockRecorder) MergeContributors(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeContributors", reflect.TypeOf((*MockRepository)(nil).MergeContributors), arg0, arg1, arg2)
+}
+
+// MetadataSnapshots mocks base method.
+func (m *MockRepository) MetadataSnapshots(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3 time.Time) ([]domain.RepoMetadataSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MetadataSnapshots", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.RepoMetadataSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MetadataSnapshots indicates an expected call of MetadataSnapshots.
+func (mr *MockRepositoryMockRecorder) MetadataSnapshots(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MetadataSnapshots", reflect.TypeOf((*MockRepository)(nil).MetadataSnapshots), arg0, arg1, arg2, arg3)
+}
+
+// PathStatsByRepository mocks base method.
+func (m *MockRepository) PathStatsByRepository(arg0 context.Context, arg1 domain.RepoMetadata, arg2, arg3 time.Time, arg4 string) ([]domain.Hotspot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PathStatsByRepository", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]domain.Hotspot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PathStatsByRepository indicates an expected call of PathStatsByRepository.
+func (mr *MockRepositoryMockRecorder) PathStatsByRepository(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PathStatsByRepository", reflect.TypeOf((*MockRepository)(nil).PathStatsByRepository), arg0, arg1, arg2, arg3, arg4)
+}
+
+// PruneBranchCommits mocks base method.
+func (m *MockRepository) PruneBranchCommits(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string, arg3 []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneBranchCommits", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneBranchCommits indicates an expected call of PruneBranchCommits.
+func (mr *MockRepositoryMockRecorder) PruneBranchCommits(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneBranchCommits", reflect.TypeOf((*MockRepository)(nil).PruneBranchCommits), arg0, arg1, arg2, arg3)
+}
+
+// PullRequestUpdates mocks base method.
+func (m *MockRepository) PullRequestUpdates(arg0 context.Context, arg1 domain.RepoMetadata, arg2 []int) (map[int]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullRequestUpdates", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PullRequestUpdates indicates an expected call of PullRequestUpdates.
+func (mr *MockRepositoryMockRecorder) PullRequestUpdates(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullRequestUpdates", reflect.TypeOf((*MockRepository)(nil).PullRequestUpdates), arg0, arg1, arg2)
+}
+
+// PullRequestsByCommitIDs mocks base method.
+func (m *MockRepository) PullRequestsByCommitIDs(arg0 context.Context, arg1 domain.RepoMetadata, arg2 []string) (map[string]domain.PullRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullRequestsByCommitIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]domain.PullRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PullRequestsByCommitIDs indicates an expected call of PullRequestsByCommitIDs.
+func (mr *MockRepositoryMockRecorder) PullRequestsByCommitIDs(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullRequestsByCommitIDs", reflect.TypeOf((*MockRepository)(nil).PullRequestsByCommitIDs), arg0, arg1, arg2)
+}
+
+// PullRequestsByRepository mocks base method.
+func (m *MockRepository) PullRequestsByRepository(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string, arg3 domain.APIPagingData) ([]domain.PullRequest, *domain.PagingInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullRequestsByRepository", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.PullRequest)
+	ret1, _ := ret[1].(*domain.PagingInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PullRequestsByRepository indicates an expected call of PullRequestsByRepository.
+func (mr *MockRepositoryMockRecorder) PullRequestsByRepository(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullRequestsByRepository", reflect.TypeOf((*MockRepository)(nil).PullRequestsByRepository), arg0, arg1, arg2, arg3)
+}
+
+// PullRequestsSyncedUntil mocks base method.
+func (m *MockRepository) PullRequestsSyncedUntil(arg0 context.Context, arg1 domain.RepoMetadata) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PullRequestsSyncedUntil", arg0, arg1)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PullRequestsSyncedUntil indicates an expected call of PullRequestsSyncedUntil.
+func (mr *MockRepositoryMockRecorder) PullRequestsSyncedUntil(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PullRequestsSyncedUntil", reflect.TypeOf((*MockRepository)(nil).PullRequestsSyncedUntil), arg0, arg1)
+}
+
+// ReferencesByCommitID mocks base method.
+func (m *MockRepository) ReferencesByCommitID(arg0 context.Context, arg1 string) ([]domain.CommitReference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReferencesByCommitID", arg0, arg1)
+	ret0, _ := ret[0].([]domain.CommitReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReferencesByCommitID indicates an expected call of ReferencesByCommitID.
+func (mr *MockRepositoryMockRecorder) ReferencesByCommitID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReferencesByCommitID", reflect.TypeOf((*MockRepository)(nil).ReferencesByCommitID), arg0, arg1)
+}
+
+// ReleaseByTag mocks base method.
+func (m *MockRepository) ReleaseByTag(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string) (*domain.Release, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseByTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseByTag indicates an expected call of ReleaseByTag.
+func (mr *MockRepositoryMockRecorder) ReleaseByTag(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseByTag", reflect.TypeOf((*MockRepository)(nil).ReleaseByTag), arg0, arg1, arg2)
+}
+
+// ReleasedChildren mocks base method.
+func (m *MockRepository) ReleasedChildren(arg0 context.Context, arg1 domain.RepoMetadata) ([]domain.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleasedChildren", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleasedChildren indicates an expected call of ReleasedChildren.
+func (mr *MockRepositoryMockRecorder) ReleasedChildren(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleasedChildren", reflect.TypeOf((*MockRepository)(nil).ReleasedChildren), arg0, arg1)
+}
+
+// ReleasesByRepository mocks base method.
+func (m *MockRepository) ReleasesByRepository(arg0 context.Context, arg1 domain.RepoMetadata) ([]domain.Release, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleasesByRepository", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleasesByRepository indicates an expected call of ReleasesByRepository.
+func (mr *MockRepositoryMockRecorder) ReleasesByRepository(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleasesByRepository", reflect.TypeOf((*MockRepository)(nil).ReleasesByRepository), arg0, arg1)
+}
+
+// ReplaceSyncRanges mocks base method.
+func (m *MockRepository) ReplaceSyncRanges(arg0 context.Context, arg1 domain.RepoMetadata, arg2 []domain.SyncRange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceSyncRanges", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceSyncRanges indicates an expected call of ReplaceSyncRanges.
+func (mr *MockRepositoryMockRecorder) ReplaceSyncRanges(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceSyncRanges", reflect.TypeOf((*MockRepository)(nil).ReplaceSyncRanges), arg0, arg1, arg2)
+}
+
+// RepoMetadataByCommitID mocks base method.
+func (m *MockRepository) RepoMetadataByCommitID(arg0 context.Context, arg1 string) ([]domain.RepoMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepoMetadataByCommitID", arg0, arg1)
+	ret0, _ := ret[0].([]domain.RepoMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepoMetadataByCommitID indicates an expected call of RepoMetadataByCommitID.
+func (mr *MockRepositoryMockRecorder) RepoMetadataByCommitID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepoMetadataByCommitID", reflect.TypeOf((*MockRepository)(nil).RepoMetadataByCommitID), arg0, arg1)
+}
+
+// RepoMetadataByName mocks base method.
+func (m *MockRepository) RepoMetadataByName(arg0 context.Context, arg1 string) (*domain.RepoMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepoMetadataByName", arg0, arg1)
+	ret0, _ := ret[0].(*domain.RepoMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepoMetadataByName indicates an expected call of RepoMetadataByName.
+func (mr *MockRepositoryMockRecorder) RepoMetadataByName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepoMetadataByName", reflect.TypeOf((*MockRepository)(nil).RepoMetadataByName), arg0, arg1)
+}
+
+// RepoMetadataByProviderID mocks base method.
+func (m *MockRepository) RepoMetadataByProviderID(arg0 context.Context, arg1 int64) (*domain.RepoMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepoMetadataByProviderID", arg0, arg1)
+	ret0, _ := ret[0].(*domain.RepoMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepoMetadataByProviderID indicates an expected call of RepoMetadataByProviderID.
+func (mr *MockRepositoryMockRecorder) RepoMetadataByProviderID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepoMetadataByProviderID", reflect.TypeOf((*MockRepository)(nil).RepoMetadataByProviderID), arg0, arg1)
+}
+
+// RepoMetadataByPublicId mocks base method.
+func (m *MockRepository) RepoMetadataByPublicId(arg0 context.Context, arg1 string) (*domain.RepoMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepoMetadataByPublicId", arg0, arg1)
+	ret0, _ := ret[0].(*domain.RepoMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepoMetadataByPublicId indicates an expected call of RepoMetadataByPublicId.
+func (mr *MockRepositoryMockRecorder) RepoMetadataByPublicId(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepoMetadataByPublicId", reflect.TypeOf((*MockRepository)(nil).RepoMetadataByPublicId), arg0, arg1)
+}
+
+// SaveBackfillJob mocks base method.
+func (m *MockRepository) SaveBackfillJob(arg0 context.Context, arg1 domain.BackfillJob) (*domain.BackfillJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBackfillJob", arg0, arg1)
+	ret0, _ := ret[0].(*domain.BackfillJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveBackfillJob indicates an expected call of SaveBackfillJob.
+func (mr *MockRepositoryMockRecorder) SaveBackfillJob(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBackfillJob", reflect.TypeOf((*MockRepository)(nil).SaveBackfillJob), arg0, arg1)
+}
+
+// SaveBranch mocks base method.
+func (m *MockRepository) SaveBranch(arg0 context.Context, arg1 domain.Branch) (*domain.Branch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBranch", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Branch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveBranch indicates an expected call of SaveBranch.
+func (mr *MockRepositoryMockRecorder) SaveBranch(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBranch", reflect.TypeOf((*MockRepository)(nil).SaveBranch), arg0, arg1)
+}
+
+// SaveBranchCommits mocks base method.
+func (m *MockRepository) SaveBranchCommits(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string, arg3 []string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBranchCommits", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveBranchCommits indicates an expected call of SaveBranchCommits.
+func (mr *MockRepositoryMockRecorder) SaveBranchCommits(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBranchCommits", reflect.TypeOf((*MockRepository)(nil).SaveBranchCommits), arg0, arg1, arg2, arg3)
+}
+
+// SaveCheckRuns mocks base method.
+func (m *MockRepository) SaveCheckRuns(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string, arg3 []domain.CheckRun, arg4 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCheckRuns", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCheckRuns indicates an expected call of SaveCheckRuns.
+func (mr *MockRepositoryMockRecorder) SaveCheckRuns(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCheckRuns", reflect.TypeOf((*MockRepository)(nil).SaveCheckRuns), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SaveCommit mocks base method.
+func (m *MockRepository) SaveCommit(arg0 context.Context, arg1 domain.Commit) (*domain.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCommit", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCommit indicates an expected call of SaveCommit.
+func (mr *MockRepositoryMockRecorder) SaveCommit(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCommit", reflect.TypeOf((*MockRepository)(nil).SaveCommit), arg0, arg1)
+}
+
+// SaveCommitDetail mocks base method.
+func (m *MockRepository) SaveCommitDetail(arg0 context.Context, arg1 string, arg2 domain.CommitDetail) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCommitDetail", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCommitDetail indicates an expected call of SaveCommitDetail.
+func (mr *MockRepositoryMockRecorder) SaveCommitDetail(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCommitDetail", reflect.TypeOf((*MockRepository)(nil).SaveCommitDetail), arg0, arg1, arg2)
+}
+
+// SaveCommitReferences mocks base method.
+func (m *MockRepository) SaveCommitReferences(arg0 context.Context, arg1 domain.RepoMetadata, arg2 []string, arg3 []domain.CommitReference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCommitReferences", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCommitReferences indicates an expected call of SaveCommitReferences.
+func (mr *MockRepositoryMockRecorder) SaveCommitReferences(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCommitReferences", reflect.TypeOf((*MockRepository)(nil).SaveCommitReferences), arg0, arg1, arg2, arg3)
+}
+
+// SaveCommits mocks base method.
+func (m *MockRepository) SaveCommits(arg0 context.Context, arg1 []domain.Commit) ([]domain.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCommits", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveCommits indicates an expected call of SaveCommits.
+func (mr *MockRepositoryMockRecorder) SaveCommits(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCommits", reflect.TypeOf((*MockRepository)(nil).SaveCommits), arg0, arg1)
+}
+
+// SaveEnrichmentFailure mocks base method.
+func (m *MockRepository) SaveEnrichmentFailure(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEnrichmentFailure", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEnrichmentFailure indicates an expected call of SaveEnrichmentFailure.
+func (mr *MockRepositoryMockRecorder) SaveEnrichmentFailure(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEnrichmentFailure", reflect.TypeOf((*MockRepository)(nil).SaveEnrichmentFailure), arg0, arg1, arg2)
+}
+
+// SaveEnrichmentRetry mocks base method.
+func (m *MockRepository) SaveEnrichmentRetry(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEnrichmentRetry", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEnrichmentRetry indicates an expected call of SaveEnrichmentRetry.
+func (mr *MockRepositoryMockRecorder) SaveEnrichmentRetry(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEnrichmentRetry", reflect.TypeOf((*MockRepository)(nil).SaveEnrichmentRetry), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SaveEnrichmentRun mocks base method.
+func (m *MockRepository) SaveEnrichmentRun(arg0 context.Context, arg1 domain.RepoMetadata, arg2 time.Time, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEnrichmentRun", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEnrichmentRun indicates an expected call of SaveEnrichmentRun.
+func (mr *MockRepositoryMockRecorder) SaveEnrichmentRun(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEnrichmentRun", reflect.TypeOf((*MockRepository)(nil).SaveEnrichmentRun), arg0, arg1, arg2, arg3)
+}
+
+// SaveIntegrityReport mocks base method.
+func (m *MockRepository) SaveIntegrityReport(arg0 context.Context, arg1 domain.IntegrityReport) (*domain.IntegrityReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIntegrityReport", arg0, arg1)
+	ret0, _ := ret[0].(*domain.IntegrityReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveIntegrityReport indicates an expected call of SaveIntegrityReport.
+func (mr *MockRepositoryMockRecorder) SaveIntegrityReport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIntegrityReport", reflect.TypeOf((*MockRepository)(nil).SaveIntegrityReport), arg0, arg1)
+}
+
+// SaveIssues mocks base method.
+func (m *MockRepository) SaveIssues(arg0 context.Context, arg1 []domain.Issue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIssues", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIssues indicates an expected call of SaveIssues.
+func (mr *MockRepositoryMockRecorder) SaveIssues(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIssues", reflect.TypeOf((*MockRepository)(nil).SaveIssues), arg0, arg1)
+}
+
+// SaveMetadataSnapshot mocks base method.
+func (m *MockRepository) SaveMetadataSnapshot(arg0 context.Context, arg1 domain.RepoMetadataSnapshot) (*domain.RepoMetadataSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMetadataSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(*domain.RepoMetadataSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveMetadataSnapshot indicates an expected call of SaveMetadataSnapshot.
+func (mr *MockRepositoryMockRecorder) SaveMetadataSnapshot(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMetadataSnapshot", reflect.TypeOf((*MockRepository)(nil).SaveMetadataSnapshot), arg0, arg1)
+}
+
+// SaveOrgImport mocks base method.
+func (m *MockRepository) SaveOrgImport(arg0 context.Context, arg1 domain.OrgImport) (*domain.OrgImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrgImport", arg0, arg1)
+	ret0, _ := ret[0].(*domain.OrgImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveOrgImport indicates an expected call of SaveOrgImport.
+func (mr *MockRepositoryMockRecorder) SaveOrgImport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrgImport", reflect.TypeOf((*MockRepository)(nil).SaveOrgImport), arg0, arg1)
+}
+
+// SaveOrgImportResults mocks base method.
+func (m *MockRepository) SaveOrgImportResults(arg0 context.Context, arg1 domain.OrgImport, arg2 []domain.OrgImportResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrgImportResults", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOrgImportResults indicates an expected call of SaveOrgImportResults.
+func (mr *MockRepositoryMockRecorder) SaveOrgImportResults(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrgImportResults", reflect.TypeOf((*MockRepository)(nil).SaveOrgImportResults), arg0, arg1, arg2)
+}
+
+// SaveOrgMembership mocks base method.
+func (m *MockRepository) SaveOrgMembership(arg0 context.Context, arg1 domain.OrgMembership) (*domain.OrgMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrgMembership", arg0, arg1)
+	ret0, _ := ret[0].(*domain.OrgMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveOrgMembership indicates an expected call of SaveOrgMembership.
+func (mr *MockRepositoryMockRecorder) SaveOrgMembership(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrgMembership", reflect.TypeOf((*MockRepository)(nil).SaveOrgMembership), arg0, arg1)
+}
+
+// SavePullRequestCommits mocks base method.
+func (m *MockRepository) SavePullRequestCommits(arg0 context.Context, arg1 domain.RepoMetadata, arg2 int, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePullRequestCommits", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePullRequestCommits indicates an expected call of SavePullRequestCommits.
+func (mr *MockRepositoryMockRecorder) SavePullRequestCommits(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePullRequestCommits", reflect.TypeOf((*MockRepository)(nil).SavePullRequestCommits), arg0, arg1, arg2, arg3)
+}
+
+// SavePullRequests mocks base method.
+func (m *MockRepository) SavePullRequests(arg0 context.Context, arg1 []domain.PullRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePullRequests", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePullRequests indicates an expected call of SavePullRequests.
+func (mr *MockRepositoryMockRecorder) SavePullRequests(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePullRequests", reflect.TypeOf((*MockRepository)(nil).SavePullRequests), arg0, arg1)
+}
+
+// SavePullRequestsSyncedUntil mocks base method.
+func (m *MockRepository) SavePullRequestsSyncedUntil(arg0 context.Context, arg1 domain.RepoMetadata, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePullRequestsSyncedUntil", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePullRequestsSyncedUntil indicates an expected call of SavePullRequestsSyncedUntil.
+func (mr *MockRepositoryMockRecorder) SavePullRequestsSyncedUntil(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePullRequestsSyncedUntil", reflect.TypeOf((*MockRepository)(nil).SavePullRequestsSyncedUntil), arg0, arg1, arg2)
+}
+
+// SaveReindexJob mocks base method.
+func (m *MockRepository) SaveReindexJob(arg0 context.Context, arg1 domain.ReindexJob) (*domain.ReindexJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveReindexJob", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ReindexJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveReindexJob indicates an expected call of SaveReindexJob.
+func (mr *MockRepositoryMockRecorder) SaveReindexJob(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReindexJob", reflect.TypeOf((*MockRepository)(nil).SaveReindexJob), arg0, arg1)
+}
+
+// SaveReleases mocks base method.
+func (m *MockRepository) SaveReleases(arg0 context.Context, arg1 []domain.Release) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AllCommitsByRepository", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]domain.Commit)
-	ret1, _ := ret[1].(*domain.PagingInfo)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "SaveReleases", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// AllCommitsByRepository indicates an expected call of AllCommitsByRepository.
-func (mr *MockRepositoryMockRecorder) AllCommitsByRepository(arg0, arg1, arg2 any) *gomock.Call {
+// SaveReleases indicates an expected call of SaveReleases.
+func (mr *MockRepositoryMockRecorder) SaveReleases(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllCommitsByRepository", reflect.TypeOf((*MockRepository)(nil).AllCommitsByRepository), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveReleases", reflect.TypeOf((*MockRepository)(nil).SaveReleases), arg0, arg1)
 }
 
-// AllRepoMetadata mocks base method.
-func (m *MockRepository) AllRepoMetadata(arg0 context.Context) ([]domain.RepoMetadata, error) {
+// SaveRepoMetadata mocks base method.
+func (m *MockRepository) SaveRepoMetadata(arg0 context.Context, arg1 domain.RepoMetadata) (*domain.RepoMetadata, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AllRepoMetadata", arg0)
-	ret0, _ := ret[0].([]domain.RepoMetadata)
+	ret := m.ctrl.Call(m, "SaveRepoMetadata", arg0, arg1)
+	ret0, _ := ret[0].(*domain.RepoMetadata)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AllRepoMetadata indicates an expected call of AllRepoMetadata.
-func (mr *MockRepositoryMockRecorder) AllRepoMetadata(arg0 any) *gomock.Call {
+// SaveRepoMetadata indicates an expected call of SaveRepoMetadata.
+func (mr *MockRepositoryMockRecorder) SaveRepoMetadata(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllRepoMetadata", reflect.TypeOf((*MockRepository)(nil).AllRepoMetadata), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRepoMetadata", reflect.TypeOf((*MockRepository)(nil).SaveRepoMetadata), arg0, arg1)
 }
 
-// GetByCommitID mocks base method.
-func (m *MockRepository) GetByCommitID(arg0 context.Context, arg1 string) (*domain.Commit, error) {
+// SaveStargazers mocks base method.
+func (m *MockRepository) SaveStargazers(arg0 context.Context, arg1 []domain.Stargazer) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByCommitID", arg0, arg1)
-	ret0, _ := ret[0].(*domain.Commit)
+	ret := m.ctrl.Call(m, "SaveStargazers", arg0, arg1)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByCommitID indicates an expected call of GetByCommitID.
-func (mr *MockRepositoryMockRecorder) GetByCommitID(arg0, arg1 any) *gomock.Call {
+// SaveStargazers indicates an expected call of SaveStargazers.
+func (mr *MockRepositoryMockRecorder) SaveStargazers(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCommitID", reflect.TypeOf((*MockRepository)(nil).GetByCommitID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveStargazers", reflect.TypeOf((*MockRepository)(nil).SaveStargazers), arg0, arg1)
 }
 
-// RepoMetadataByName mocks base method.
-func (m *MockRepository) RepoMetadataByName(arg0 context.Context, arg1 string) (*domain.RepoMetadata, error) {
+// SaveSyncCursor mocks base method.
+func (m *MockRepository) SaveSyncCursor(arg0 context.Context, arg1 domain.SyncCursor) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RepoMetadataByName", arg0, arg1)
-	ret0, _ := ret[0].(*domain.RepoMetadata)
+	ret := m.ctrl.Call(m, "SaveSyncCursor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSyncCursor indicates an expected call of SaveSyncCursor.
+func (mr *MockRepositoryMockRecorder) SaveSyncCursor(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSyncCursor", reflect.TypeOf((*MockRepository)(nil).SaveSyncCursor), arg0, arg1)
+}
+
+// SaveTags mocks base method.
+func (m *MockRepository) SaveTags(arg0 context.Context, arg1 []domain.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTags", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTags indicates an expected call of SaveTags.
+func (mr *MockRepositoryMockRecorder) SaveTags(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTags", reflect.TypeOf((*MockRepository)(nil).SaveTags), arg0, arg1)
+}
+
+// StargazerCount mocks base method.
+func (m *MockRepository) StargazerCount(arg0 context.Context, arg1 domain.RepoMetadata) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StargazerCount", arg0, arg1)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RepoMetadataByName indicates an expected call of RepoMetadataByName.
-func (mr *MockRepositoryMockRecorder) RepoMetadataByName(arg0, arg1 any) *gomock.Call {
+// StargazerCount indicates an expected call of StargazerCount.
+func (mr *MockRepositoryMockRecorder) StargazerCount(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepoMetadataByName", reflect.TypeOf((*MockRepository)(nil).RepoMetadataByName), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StargazerCount", reflect.TypeOf((*MockRepository)(nil).StargazerCount), arg0, arg1)
 }
 
-// RepoMetadataByPublicId mocks base method.
-func (m *MockRepository) RepoMetadataByPublicId(arg0 context.Context, arg1 string) (*domain.RepoMetadata, error) {
+// StoredCommitIDs mocks base method.
+func (m *MockRepository) StoredCommitIDs(arg0 context.Context, arg1 domain.RepoMetadata, arg2 []string) ([]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RepoMetadataByPublicId", arg0, arg1)
-	ret0, _ := ret[0].(*domain.RepoMetadata)
+	ret := m.ctrl.Call(m, "StoredCommitIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RepoMetadataByPublicId indicates an expected call of RepoMetadataByPublicId.
-func (mr *MockRepositoryMockRecorder) RepoMetadataByPublicId(arg0, arg1 any) *gomock.Call {
+// StoredCommitIDs indicates an expected call of StoredCommitIDs.
+func (mr *MockRepositoryMockRecorder) StoredCommitIDs(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepoMetadataByPublicId", reflect.TypeOf((*MockRepository)(nil).RepoMetadataByPublicId), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoredCommitIDs", reflect.TypeOf((*MockRepository)(nil).StoredCommitIDs), arg0, arg1, arg2)
 }
 
-// SaveCommit mocks base method.
-func (m *MockRepository) SaveCommit(arg0 context.Context, arg1 domain.Commit) (*domain.Commit, error) {
+// SyncCursorByRepository mocks base method.
+func (m *MockRepository) SyncCursorByRepository(arg0 context.Context, arg1 domain.RepoMetadata) (*domain.SyncCursor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveCommit", arg0, arg1)
-	ret0, _ := ret[0].(*domain.Commit)
+	ret := m.ctrl.Call(m, "SyncCursorByRepository", arg0, arg1)
+	ret0, _ := ret[0].(*domain.SyncCursor)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SaveCommit indicates an expected call of SaveCommit.
-func (mr *MockRepositoryMockRecorder) SaveCommit(arg0, arg1 any) *gomock.Call {
+// SyncCursorByRepository indicates an expected call of SyncCursorByRepository.
+func (mr *MockRepositoryMockRecorder) SyncCursorByRepository(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCommit", reflect.TypeOf((*MockRepository)(nil).SaveCommit), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncCursorByRepository", reflect.TypeOf((*MockRepository)(nil).SyncCursorByRepository), arg0, arg1)
 }
 
-// SaveRepoMetadata mocks base method.
-func (m *MockRepository) SaveRepoMetadata(arg0 context.Context, arg1 domain.RepoMetadata) (*domain.RepoMetadata, error) {
+// SyncRangesByRepository mocks base method.
+func (m *MockRepository) SyncRangesByRepository(arg0 context.Context, arg1 domain.RepoMetadata) ([]domain.SyncRange, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveRepoMetadata", arg0, arg1)
-	ret0, _ := ret[0].(*domain.RepoMetadata)
+	ret := m.ctrl.Call(m, "SyncRangesByRepository", arg0, arg1)
+	ret0, _ := ret[0].([]domain.SyncRange)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// SaveRepoMetadata indicates an expected call of SaveRepoMetadata.
-func (mr *MockRepositoryMockRecorder) SaveRepoMetadata(arg0, arg1 any) *gomock.Call {
+// SyncRangesByRepository indicates an expected call of SyncRangesByRepository.
+func (mr *MockRepositoryMockRecorder) SyncRangesByRepository(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRepoMetadata", reflect.TypeOf((*MockRepository)(nil).SaveRepoMetadata), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncRangesByRepository", reflect.TypeOf((*MockRepository)(nil).SyncRangesByRepository), arg0, arg1)
+}
+
+// TagsByRepository mocks base method.
+func (m *MockRepository) TagsByRepository(arg0 context.Context, arg1 domain.RepoMetadata) ([]domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagsByRepository", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TagsByRepository indicates an expected call of TagsByRepository.
+func (mr *MockRepositoryMockRecorder) TagsByRepository(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagsByRepository", reflect.TypeOf((*MockRepository)(nil).TagsByRepository), arg0, arg1)
 }
 
 // TopCommitAuthorsByRepository mocks base method.
@@ -161,6 +1297,138 @@ func (mr *MockRepositoryMockRecorder) TopCommitAuthorsByRepository(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopCommitAuthorsByRepository", reflect.TypeOf((*MockRepository)(nil).TopCommitAuthorsByRepository), arg0, arg1, arg2)
 }
 
+// TrackedRepoNames mocks base method.
+func (m *MockRepository) TrackedRepoNames(arg0 context.Context) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackedRepoNames", arg0)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrackedRepoNames indicates an expected call of TrackedRepoNames.
+func (mr *MockRepositoryMockRecorder) TrackedRepoNames(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackedRepoNames", reflect.TypeOf((*MockRepository)(nil).TrackedRepoNames), arg0)
+}
+
+// UnenrichedCommits mocks base method.
+func (m *MockRepository) UnenrichedCommits(arg0 context.Context, arg1 domain.RepoMetadata, arg2 int) ([]domain.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnenrichedCommits", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnenrichedCommits indicates an expected call of UnenrichedCommits.
+func (mr *MockRepositoryMockRecorder) UnenrichedCommits(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnenrichedCommits", reflect.TypeOf((*MockRepository)(nil).UnenrichedCommits), arg0, arg1, arg2)
+}
+
+// UnparsedCommits mocks base method.
+func (m *MockRepository) UnparsedCommits(arg0 context.Context, arg1 domain.RepoMetadata, arg2 int) ([]domain.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnparsedCommits", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnparsedCommits indicates an expected call of UnparsedCommits.
+func (mr *MockRepositoryMockRecorder) UnparsedCommits(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnparsedCommits", reflect.TypeOf((*MockRepository)(nil).UnparsedCommits), arg0, arg1, arg2)
+}
+
+// UnreleasedCommits mocks base method.
+func (m *MockRepository) UnreleasedCommits(arg0 context.Context, arg1 domain.RepoMetadata) ([]domain.Commit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnreleasedCommits", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Commit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnreleasedCommits indicates an expected call of UnreleasedCommits.
+func (mr *MockRepositoryMockRecorder) UnreleasedCommits(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnreleasedCommits", reflect.TypeOf((*MockRepository)(nil).UnreleasedCommits), arg0, arg1)
+}
+
+// UnresolvedAuthors mocks base method.
+func (m *MockRepository) UnresolvedAuthors(arg0 context.Context, arg1 domain.RepoMetadata) ([]domain.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnresolvedAuthors", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnresolvedAuthors indicates an expected call of UnresolvedAuthors.
+func (mr *MockRepositoryMockRecorder) UnresolvedAuthors(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnresolvedAuthors", reflect.TypeOf((*MockRepository)(nil).UnresolvedAuthors), arg0, arg1)
+}
+
+// UntrackedProviderIDs mocks base method.
+func (m *MockRepository) UntrackedProviderIDs(arg0 context.Context) (map[int64]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UntrackedProviderIDs", arg0)
+	ret0, _ := ret[0].(map[int64]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UntrackedProviderIDs indicates an expected call of UntrackedProviderIDs.
+func (mr *MockRepositoryMockRecorder) UntrackedProviderIDs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UntrackedProviderIDs", reflect.TypeOf((*MockRepository)(nil).UntrackedProviderIDs), arg0)
+}
+
+// UpdateBackfillJob mocks base method.
+func (m *MockRepository) UpdateBackfillJob(arg0 context.Context, arg1 domain.BackfillJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBackfillJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBackfillJob indicates an expected call of UpdateBackfillJob.
+func (mr *MockRepositoryMockRecorder) UpdateBackfillJob(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBackfillJob", reflect.TypeOf((*MockRepository)(nil).UpdateBackfillJob), arg0, arg1)
+}
+
+// UpdateContributor mocks base method.
+func (m *MockRepository) UpdateContributor(arg0 context.Context, arg1 domain.Contributor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContributor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContributor indicates an expected call of UpdateContributor.
+func (mr *MockRepositoryMockRecorder) UpdateContributor(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContributor", reflect.TypeOf((*MockRepository)(nil).UpdateContributor), arg0, arg1)
+}
+
+// UpdateFetchingState mocks base method.
+func (m *MockRepository) UpdateFetchingState(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFetchingState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFetchingState indicates an expected call of UpdateFetchingState.
+func (mr *MockRepositoryMockRecorder) UpdateFetchingState(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFetchingState", reflect.TypeOf((*MockRepository)(nil).UpdateFetchingState), arg0, arg1, arg2)
+}
+
 // UpdateFetchingStateForAllRepos mocks base method.
 func (m *MockRepository) UpdateFetchingStateForAllRepos(arg0 context.Context, arg1 bool) error {
 	m.ctrl.T.Helper()
@@ -175,6 +1443,119 @@ func (mr *MockRepositoryMockRecorder) UpdateFetchingStateForAllRepos(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFetchingStateForAllRepos", reflect.TypeOf((*MockRepository)(nil).UpdateFetchingStateForAllRepos), arg0, arg1)
 }
 
+// UpdateFirstReleases mocks base method.
+func (m *MockRepository) UpdateFirstReleases(arg0 context.Context, arg1 domain.RepoMetadata, arg2 map[string]string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFirstReleases", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFirstReleases indicates an expected call of UpdateFirstReleases.
+func (mr *MockRepositoryMockRecorder) UpdateFirstReleases(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFirstReleases", reflect.TypeOf((*MockRepository)(nil).UpdateFirstReleases), arg0, arg1, arg2, arg3)
+}
+
+// UpdateIdentitiesPending mocks base method.
+func (m *MockRepository) UpdateIdentitiesPending(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIdentitiesPending", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateIdentitiesPending indicates an expected call of UpdateIdentitiesPending.
+func (mr *MockRepositoryMockRecorder) UpdateIdentitiesPending(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIdentitiesPending", reflect.TypeOf((*MockRepository)(nil).UpdateIdentitiesPending), arg0, arg1, arg2)
+}
+
+// UpdateMailmapETag mocks base method.
+func (m *MockRepository) UpdateMailmapETag(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMailmapETag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMailmapETag indicates an expected call of UpdateMailmapETag.
+func (mr *MockRepositoryMockRecorder) UpdateMailmapETag(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMailmapETag", reflect.TypeOf((*MockRepository)(nil).UpdateMailmapETag), arg0, arg1, arg2)
+}
+
+// UpdateOrgImport mocks base method.
+func (m *MockRepository) UpdateOrgImport(arg0 context.Context, arg1 domain.OrgImport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrgImport", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrgImport indicates an expected call of UpdateOrgImport.
+func (mr *MockRepositoryMockRecorder) UpdateOrgImport(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrgImport", reflect.TypeOf((*MockRepository)(nil).UpdateOrgImport), arg0, arg1)
+}
+
+// UpdateOrgMembershipSyncedAt mocks base method.
+func (m *MockRepository) UpdateOrgMembershipSyncedAt(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrgMembershipSyncedAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOrgMembershipSyncedAt indicates an expected call of UpdateOrgMembershipSyncedAt.
+func (mr *MockRepositoryMockRecorder) UpdateOrgMembershipSyncedAt(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrgMembershipSyncedAt", reflect.TypeOf((*MockRepository)(nil).UpdateOrgMembershipSyncedAt), arg0, arg1, arg2)
+}
+
+// UpdatePausedState mocks base method.
+func (m *MockRepository) UpdatePausedState(arg0 context.Context, arg1 string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePausedState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePausedState indicates an expected call of UpdatePausedState.
+func (mr *MockRepositoryMockRecorder) UpdatePausedState(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePausedState", reflect.TypeOf((*MockRepository)(nil).UpdatePausedState), arg0, arg1, arg2)
+}
+
+// UpdateReindexJob mocks base method.
+func (m *MockRepository) UpdateReindexJob(arg0 context.Context, arg1 domain.ReindexJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReindexJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReindexJob indicates an expected call of UpdateReindexJob.
+func (mr *MockRepositoryMockRecorder) UpdateReindexJob(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReindexJob", reflect.TypeOf((*MockRepository)(nil).UpdateReindexJob), arg0, arg1)
+}
+
+// UpdateRepoIdentity mocks base method.
+func (m *MockRepository) UpdateRepoIdentity(arg0 context.Context, arg1 domain.RepoMetadata, arg2 string) (*domain.RepoMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRepoIdentity", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.RepoMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRepoIdentity indicates an expected call of UpdateRepoIdentity.
+func (mr *MockRepositoryMockRecorder) UpdateRepoIdentity(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRepoIdentity", reflect.TypeOf((*MockRepository)(nil).UpdateRepoIdentity), arg0, arg1, arg2)
+}
+
 // UpdateRepoMetadata mocks base method.
 func (m *MockRepository) UpdateRepoMetadata(arg0 context.Context, arg1 domain.RepoMetadata) (*domain.RepoMetadata, error) {
 	m.ctrl.T.Helper()
@@ -189,3 +1570,62 @@ func (mr *MockRepositoryMockRecorder) UpdateRepoMetadata(arg0, arg1 any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRepoMetadata", reflect.TypeOf((*MockRepository)(nil).UpdateRepoMetadata), arg0, arg1)
 }
+
+// UpdateRepoStats mocks base method.
+func (m *MockRepository) UpdateRepoStats(arg0 context.Context, arg1 domain.RepoMetadata) (*domain.RepoMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRepoStats", arg0, arg1)
+	ret0, _ := ret[0].(*domain.RepoMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRepoStats indicates an expected call of UpdateRepoStats.
+func (mr *MockRepositoryMockRecorder) UpdateRepoStats(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRepoStats", reflect.TypeOf((*MockRepository)(nil).UpdateRepoStats), arg0, arg1)
+}
+
+// UpdateTrackingSettings mocks base method.
+func (m *MockRepository) UpdateTrackingSettings(arg0 context.Context, arg1 domain.RepoMetadata) (*domain.RepoMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrackingSettings", arg0, arg1)
+	ret0, _ := ret[0].(*domain.RepoMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTrackingSettings indicates an expected call of UpdateTrackingSettings.
+func (mr *MockRepositoryMockRecorder) UpdateTrackingSettings(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrackingSettings", reflect.TypeOf((*MockRepository)(nil).UpdateTrackingSettings), arg0, arg1)
+}
+
+// UpdateUpstreamState mocks base method.
+func (m *MockRepository) UpdateUpstreamState(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUpstreamState", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUpstreamState indicates an expected call of UpdateUpstreamState.
+func (mr *MockRepositoryMockRecorder) UpdateUpstreamState(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUpstreamState", reflect.TypeOf((*MockRepository)(nil).UpdateUpstreamState), arg0, arg1, arg2)
+}
+
+// WeeklyStars mocks base method.
+func (m *MockRepository) WeeklyStars(arg0 context.Context, arg1 domain.RepoMetadata) ([]domain.StarCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WeeklyStars", arg0, arg1)
+	ret0, _ := ret[0].([]domain.StarCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WeeklyStars indicates an expected call of WeeklyStars.
+func (mr *MockRepositoryMockRecorder) WeeklyStars(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WeeklyStars", reflect.TypeOf((*MockRepository)(nil).WeeklyStars), arg0, arg1)
+}
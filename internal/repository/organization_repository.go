@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type OrganizationRepository interface {
+	SaveOrgMembership(ctx context.Context, membership domain.OrgMembership) (*domain.OrgMembership, error)
+	AllOrgMemberships(ctx context.Context) ([]domain.OrgMembership, error)
+	UpdateOrgMembershipSyncedAt(ctx context.Context, owner string, syncedAt time.Time) error
+	SaveOrgImport(ctx context.Context, orgImport domain.OrgImport) (*domain.OrgImport, error)
+	UpdateOrgImport(ctx context.Context, orgImport domain.OrgImport) error
+	SaveOrgImportResults(ctx context.Context, orgImport domain.OrgImport, results []domain.OrgImportResult) error
+	LatestOrgImport(ctx context.Context, owner string) (*domain.OrgImport, error)
+	FailRunningOrgImports(ctx context.Context, reason string) error
+	UntrackedProviderIDs(ctx context.Context) (map[int64]bool, error)
+	TrackedRepoNames(ctx context.Context) (map[string]bool, error)
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// SyncRange represents the Postgres model for the sync_ranges table, a nil Since
+// means the range starts at the first commit of the repository.
+type SyncRange struct {
+	ID           uint `gorm:"primaryKey"`
+	RepositoryID uint `gorm:"index"`
+	Since        *time.Time
+	Until        time.Time
+	CreatedAt    time.Time
+}
+
+// BackfillJob represents the Postgres model for the backfill_jobs table.
+type BackfillJob struct {
+	ID           uint `gorm:"primaryKey"`
+	RepositoryID uint `gorm:"index"`
+	Since        *time.Time
+	Until        time.Time
+	Status       string `gorm:"type:varchar(20);index"`
+	Page         int
+	CommitsSaved int
+	Error        string `gorm:"type:text"`
+	Attempts     int
+	RetryAt      *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	CompletedAt  *time.Time
+}
+
+// ReindexJob represents the Postgres model for the reindex_jobs table.
+type ReindexJob struct {
+	ID             uint   `gorm:"primaryKey"`
+	RepositoryID   uint   `gorm:"index"`
+	Status         string `gorm:"type:varchar(20);index"`
+	Page           int
+	CommitsFetched int
+	Added          int
+	Removed        int
+	Changed        int
+	Error          string `gorm:"type:text"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CompletedAt    *time.Time
+}
+
+// ToDomain converts a Postgres SyncRange object to domain entity SyncRange.
+func (ps *SyncRange) ToDomain() domain.SyncRange {
+	r := domain.SyncRange{Until: ps.Until}
+	if ps.Since != nil {
+		r.Since = *ps.Since
+	}
+	return r
+}
+
+// FromDomainSyncRange returns a Postgres SyncRange object from domain entity SyncRange.
+func FromDomainSyncRange(repositoryID uint, r domain.SyncRange) *SyncRange {
+	return &SyncRange{
+		RepositoryID: repositoryID,
+		Since:        timePtr(r.Since),
+		Until:        r.Until,
+	}
+}
+
+// ToDomain converts a Postgres BackfillJob object to domain entity BackfillJob.
+func (pb *BackfillJob) ToDomain() *domain.BackfillJob {
+	job := &domain.BackfillJob{
+		ID:           pb.ID,
+		RepositoryID: pb.RepositoryID,
+		Until:        pb.Until,
+		Status:       pb.Status,
+		Page:         pb.Page,
+		CommitsSaved: pb.CommitsSaved,
+		Error:        pb.Error,
+		Attempts:     pb.Attempts,
+		RetryAt:      pb.RetryAt,
+		CreatedAt:    pb.CreatedAt,
+		UpdatedAt:    pb.UpdatedAt,
+		CompletedAt:  pb.CompletedAt,
+	}
+	if pb.Since != nil {
+		job.Since = *pb.Since
+	}
+	return job
+}
+
+// FromDomainBackfillJob returns a Postgres BackfillJob object from domain entity BackfillJob.
+func FromDomainBackfillJob(j *domain.BackfillJob) *BackfillJob {
+	return &BackfillJob{
+		ID:           j.ID,
+		RepositoryID: j.RepositoryID,
+		Since:        timePtr(j.Since),
+		Until:        j.Until,
+		Status:       j.Status,
+		Page:         j.Page,
+		CommitsSaved: j.CommitsSaved,
+		Error:        j.Error,
+		Attempts:     j.Attempts,
+		RetryAt:      j.RetryAt,
+		CreatedAt:    j.CreatedAt,
+		UpdatedAt:    j.UpdatedAt,
+		CompletedAt:  j.CompletedAt,
+	}
+}
+
+// ToDomain converts a Postgres ReindexJob object to domain entity ReindexJob.
+func (pr *ReindexJob) ToDomain() *domain.ReindexJob {
+	return &domain.ReindexJob{
+		ID:             pr.ID,
+		RepositoryID:   pr.RepositoryID,
+		Status:         pr.Status,
+		Page:           pr.Page,
+		CommitsFetched: pr.CommitsFetched,
+		Added:          pr.Added,
+		Removed:        pr.Removed,
+		Changed:        pr.Changed,
+		Error:          pr.Error,
+		CreatedAt:      pr.CreatedAt,
+		UpdatedAt:      pr.UpdatedAt,
+		CompletedAt:    pr.CompletedAt,
+	}
+}
+
+// FromDomainReindexJob returns a Postgres ReindexJob object from domain entity ReindexJob.
+func FromDomainReindexJob(j *domain.ReindexJob) *ReindexJob {
+	return &ReindexJob{
+		ID:             j.ID,
+		RepositoryID:   j.RepositoryID,
+		Status:         j.Status,
+		Page:           j.Page,
+		CommitsFetched: j.CommitsFetched,
+		Added:          j.Added,
+		Removed:        j.Removed,
+		Changed:        j.Changed,
+		Error:          j.Error,
+		CreatedAt:      j.CreatedAt,
+		UpdatedAt:      j.UpdatedAt,
+		CompletedAt:    j.CompletedAt,
+	}
+}
+
+// timePtr maps a zero time to nil so that it is stored as NULL
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
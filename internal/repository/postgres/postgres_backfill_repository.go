@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+type PostgresBackfillRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresBackfillRepository(db *gorm.DB) repository.BackfillRepository {
+	return &PostgresBackfillRepository{DB: db}
+}
+
+// SyncRangesByRepository fetches the commit date ranges already fetched for a repository
+func (b *PostgresBackfillRepository) SyncRangesByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.SyncRange, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbRanges []SyncRange
+	err := b.DB.WithContext(ctx).Where("repository_id = ?", repo.ID).Order("since ASC NULLS FIRST").Find(&dbRanges).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make([]domain.SyncRange, 0, len(dbRanges))
+	for _, r := range dbRanges {
+		ranges = append(ranges, r.ToDomain())
+	}
+	return ranges, nil
+}
+
+// ReplaceSyncRanges replaces the fetched commit date ranges of a repository in a single transaction
+func (b *PostgresBackfillRepository) ReplaceSyncRanges(ctx context.Context, repo domain.RepoMetadata, ranges []domain.SyncRange) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return b.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&SyncRange{}).Error; err != nil {
+			return err
+		}
+
+		if len(ranges) == 0 {
+			return nil
+		}
+
+		dbRanges := make([]*SyncRange, 0, len(ranges))
+		for _, r := range ranges {
+			dbRanges = append(dbRanges, FromDomainSyncRange(repo.ID, r))
+		}
+		return tx.Create(dbRanges).Error
+	})
+}
+
+// SaveBackfillJob stores a new backfill job
+func (b *PostgresBackfillRepository) SaveBackfillJob(ctx context.Context, job domain.BackfillJob) (*domain.BackfillJob, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	dbJob := FromDomainBackfillJob(&job)
+	if err := b.DB.WithContext(ctx).Create(dbJob).Error; err != nil {
+		return nil, err
+	}
+	return dbJob.ToDomain(), nil
+}
+
+// UpdateBackfillJob persists the status and progress of a backfill job
+func (b *PostgresBackfillRepository) UpdateBackfillJob(ctx context.Context, job domain.BackfillJob) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	dbJob := FromDomainBackfillJob(&job)
+	err := b.DB.WithContext(ctx).Model(&BackfillJob{}).
+		Where("id = ?", job.ID).
+		Select("status", "page", "commits_saved", "error", "attempts", "retry_at", "completed_at").
+		Updates(dbJob).Error
+	if err != nil {
+		log.Error().Msgf("Persistence::UpdateBackfillJob error: %v, (%v)", err.Error(), err.Error())
+	}
+	return err
+}
+
+// BackfillJobsByRepository fetches the backfill jobs of a repository, newest first
+func (b *PostgresBackfillRepository) BackfillJobsByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.BackfillJob, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbJobs []BackfillJob
+	err := b.DB.WithContext(ctx).Where("repository_id = ?", repo.ID).Order("created_at DESC").Find(&dbJobs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.BackfillJob, 0, len(dbJobs))
+	for _, j := range dbJobs {
+		jobs = append(jobs, *j.ToDomain())
+	}
+	return jobs, nil
+}
+
+// SaveReindexJob stores a new reindex job
+func (b *PostgresBackfillRepository) SaveReindexJob(ctx context.Context, job domain.ReindexJob) (*domain.ReindexJob, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	dbJob := FromDomainReindexJob(&job)
+	if err := b.DB.WithContext(ctx).Create(dbJob).Error; err != nil {
+		return nil, err
+	}
+	return dbJob.ToDomain(), nil
+}
+
+// UpdateReindexJob persists the status, progress and result of a reindex job
+func (b *PostgresBackfillRepository) UpdateReindexJob(ctx context.Context, job domain.ReindexJob) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	dbJob := FromDomainReindexJob(&job)
+	err := b.DB.WithContext(ctx).Model(&ReindexJob{}).
+		Where("id = ?", job.ID).
+		Select("status", "page", "commits_fetched", "added", "removed", "changed", "error", "completed_at").
+		Updates(dbJob).Error
+	if err != nil {
+		log.Error().Msgf("Persistence::UpdateReindexJob error: %v, (%v)", err.Error(), err.Error())
+	}
+	return err
+}
+
+// LatestReindexJob fetches the latest reindex job of a repository
+func (b *PostgresBackfillRepository) LatestReindexJob(ctx context.Context, repo domain.RepoMetadata) (*domain.ReindexJob, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbJob ReindexJob
+	err := b.DB.WithContext(ctx).Where("repository_id = ?", repo.ID).Order("created_at DESC").Limit(1).Find(&dbJob).Error
+	if err != nil {
+		return nil, err
+	}
+	if dbJob.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	return dbJob.ToDomain(), nil
+}
+
+// FailRunningReindexJobs marks the reindex jobs interrupted by a restart as failed and releases the claim their
+// repositories still hold in the same transaction
+func (b *PostgresBackfillRepository) FailRunningReindexJobs(ctx context.Context, reason string) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return b.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		running := tx.Model(&ReindexJob{}).Select("repository_id").Where("status = ?", domain.ReindexStatusRunning)
+		err := tx.Model(&Repository{}).
+			Where("id IN (?)", running).
+			Update("is_fetching", false).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&ReindexJob{}).
+			Where("status = ?", domain.ReindexStatusRunning).
+			Updates(map[string]interface{}{"status": domain.ReindexStatusFailed, "error": reason}).Error
+	})
+}
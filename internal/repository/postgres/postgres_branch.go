@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// Branch represents the Postgres model for the branches table, it holds a tracked branch of a repository
+// and the head its commits were ingested up to.
+type Branch struct {
+	ID           uint   `gorm:"primaryKey"`
+	RepositoryID uint   `gorm:"uniqueIndex:idx_branches_repository_id_name"`
+	Name         string `gorm:"type:varchar;uniqueIndex:idx_branches_repository_id_name"`
+	HeadSHA      string `gorm:"type:varchar(40)"`
+	SyncedSHA    string `gorm:"type:varchar(40)"`
+	IsDefault    bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// BranchCommit represents the Postgres model for the branch_commits table, it records that a commit
+// is reachable from a tracked branch of a repository.
+type BranchCommit struct {
+	ID           uint   `gorm:"primaryKey"`
+	RepositoryID uint   `gorm:"uniqueIndex:idx_branch_commits_repository_id_branch_commit_id"`
+	Branch       string `gorm:"type:varchar;uniqueIndex:idx_branch_commits_repository_id_branch_commit_id"`
+	CommitID     string `gorm:"type:varchar(40);uniqueIndex:idx_branch_commits_repository_id_branch_commit_id;index"`
+	CreatedAt    time.Time
+}
+
+// ToDomain converts a Postgres Branch object to domain entity Branch.
+func (pb *Branch) ToDomain() *domain.Branch {
+	return &domain.Branch{
+		RepositoryID: pb.RepositoryID,
+		Name:         pb.Name,
+		HeadSHA:      pb.HeadSHA,
+		SyncedSHA:    pb.SyncedSHA,
+		IsDefault:    pb.IsDefault,
+		UpdatedAt:    pb.UpdatedAt,
+	}
+}
+
+// FromDomainBranch returns a Postgres Branch object from domain entity Branch.
+func FromDomainBranch(b *domain.Branch) *Branch {
+	return &Branch{
+		RepositoryID: b.RepositoryID,
+		Name:         b.Name,
+		HeadSHA:      b.HeadSHA,
+		SyncedSHA:    b.SyncedSHA,
+		IsDefault:    b.IsDefault,
+	}
+}
@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresBranchRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresBranchRepository(db *gorm.DB) repository.BranchRepository {
+	return &PostgresBranchRepository{DB: db}
+}
+
+// SaveBranch stores a tracked branch of a repository or updates its heads when it is already stored
+func (b *PostgresBranchRepository) SaveBranch(ctx context.Context, branch domain.Branch) (*domain.Branch, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	dbBranch := FromDomainBranch(&branch)
+	err := b.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "repository_id"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"head_sha", "synced_sha", "is_default", "updated_at"}),
+	}).Create(dbBranch).Error
+	if err != nil {
+		return nil, err
+	}
+	return dbBranch.ToDomain(), nil
+}
+
+// BranchByName fetches a tracked branch of a repository
+func (b *PostgresBranchRepository) BranchByName(ctx context.Context, repo domain.RepoMetadata, name string) (*domain.Branch, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var branch Branch
+	err := b.DB.WithContext(ctx).Where("repository_id = ? AND name = ?", repo.ID, name).Find(&branch).Error
+	if err != nil {
+		return nil, err
+	}
+	if branch.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	return branch.ToDomain(), nil
+}
+
+// BranchesByRepository fetches the tracked branches of a repository with the number of commits reachable from each
+func (b *PostgresBranchRepository) BranchesByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.Branch, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbBranches []Branch
+	err := b.DB.WithContext(ctx).Where("repository_id = ?", repo.ID).Order("is_default DESC, name ASC").Find(&dbBranches).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var counts []struct {
+		Branch string
+		Count  int
+	}
+	err = b.DB.WithContext(ctx).Model(&BranchCommit{}).
+		Select("branch, count(*) AS count").
+		Where("repository_id = ?", repo.ID).
+		Group("branch").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	countByBranch := make(map[string]int, len(counts))
+	for _, c := range counts {
+		countByBranch[c.Branch] = c.Count
+	}
+
+	branches := make([]domain.Branch, 0, len(dbBranches))
+	for _, br := range dbBranches {
+		branch := br.ToDomain()
+		branch.CommitCount = countByBranch[br.Name]
+		branches = append(branches, *branch)
+	}
+	return branches, nil
+}
+
+// DeleteBranch removes a tracked branch of a repository and its commit memberships, the commits are kept
+func (b *PostgresBranchRepository) DeleteBranch(ctx context.Context, repo domain.RepoMetadata, name string) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return b.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("repository_id = ? AND branch = ?", repo.ID, name).Delete(&BranchCommit{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("repository_id = ? AND name = ?", repo.ID, name).Delete(&Branch{}).Error
+	})
+}
+
+// SaveBranchCommits records that commits are reachable from a branch of a repository, inserting them in batches of
+// commitInsertBatchSize and skipping the memberships already recorded, and returns the number of recorded memberships
+func (b *PostgresBranchRepository) SaveBranchCommits(ctx context.Context, repo domain.RepoMetadata, branch string, commitIDs []string) (int, error) {
+	if ctx.Err() == context.Canceled {
+		return 0, message.ErrContextCancelled
+	}
+
+	now := time.Now()
+	saved := 0
+	for start := 0; start < len(commitIDs); start += commitInsertBatchSize {
+		batch := commitIDs[start:min(start+commitInsertBatchSize, len(commitIDs))]
+
+		var sb strings.Builder
+		sb.WriteString(`INSERT INTO branch_commits (repository_id, branch, commit_id, created_at) VALUES `)
+		args := make([]interface{}, 0, len(batch)*4)
+		for i, id := range batch {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString("(?, ?, ?, ?)")
+			args = append(args, repo.ID, branch, id, now)
+		}
+		sb.WriteString(" ON CONFLICT DO NOTHING")
+
+		result := b.DB.WithContext(ctx).Exec(sb.String(), args...)
+		if result.Error != nil {
+			return saved, result.Error
+		}
+		saved += int(result.RowsAffected)
+	}
+	return saved, nil
+}
+
+// PruneBranchCommits removes the memberships of a branch of a repository for the commits no longer reachable from it,
+// reachable lists every commit reachable from the branch, and returns the number of removed memberships
+func (b *PostgresBranchRepository) PruneBranchCommits(ctx context.Context, repo domain.RepoMetadata, branch string, reachable []string) (int, error) {
+	if ctx.Err() == context.Canceled {
+		return 0, message.ErrContextCancelled
+	}
+
+	var stored []string
+	err := b.DB.WithContext(ctx).Model(&BranchCommit{}).
+		Where("repository_id = ? AND branch = ?", repo.ID, branch).
+		Pluck("commit_id", &stored).Error
+	if err != nil {
+		return 0, err
+	}
+
+	isReachable := make(map[string]bool, len(reachable))
+	for _, id := range reachable {
+		isReachable[id] = true
+	}
+	unreachable := make([]string, 0)
+	for _, id := range stored {
+		if !isReachable[id] {
+			unreachable = append(unreachable, id)
+		}
+	}
+
+	removed := 0
+	for start := 0; start < len(unreachable); start += commitInsertBatchSize {
+		batch := unreachable[start:min(start+commitInsertBatchSize, len(unreachable))]
+		result := b.DB.WithContext(ctx).
+			Where("repository_id = ? AND branch = ? AND commit_id IN ?", repo.ID, branch, batch).
+			Delete(&BranchCommit{})
+		if result.Error != nil {
+			return removed, result.Error
+		}
+		removed += int(result.RowsAffected)
+	}
+	return removed, nil
+}
+
+// BranchesByCommitIDs fetches the tracked branches of a repository each commit is reachable from, keyed by commit id,
+// the commit ids are looked up in batches of commitInsertBatchSize
+func (b *PostgresBranchRepository) BranchesByCommitIDs(ctx context.Context, repo domain.RepoMetadata, commitIDs []string) (map[string][]string, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	branches := make(map[string][]string, len(commitIDs))
+	if len(commitIDs) == 0 {
+		return branches, nil
+	}
+
+	for start := 0; start < len(commitIDs); start += commitInsertBatchSize {
+		batch := commitIDs[start:min(start+commitInsertBatchSize, len(commitIDs))]
+
+		var memberships []BranchCommit
+		err := b.DB.WithContext(ctx).
+			Where("repository_id = ? AND commit_id IN ?", repo.ID, batch).
+			Order("branch ASC").
+			Find(&memberships).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range memberships {
+			branches[m.CommitID] = append(branches[m.CommitID], m.Branch)
+		}
+	}
+	return branches, nil
+}
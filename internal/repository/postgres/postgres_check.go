@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// CheckRun represents the Postgres model for the check_runs table, it holds a run of a status context
+// or of a check on a commit of a repository.
+type CheckRun struct {
+	ID           uint       `gorm:"primaryKey"`
+	RepositoryID uint       `gorm:"uniqueIndex:idx_check_runs_repository_id_commit_id_kind_external_id;index:idx_check_runs_repository_id_started_at"`
+	CommitID     string     `gorm:"type:varchar(40);uniqueIndex:idx_check_runs_repository_id_commit_id_kind_external_id"`
+	Kind         string     `gorm:"type:varchar(20);uniqueIndex:idx_check_runs_repository_id_commit_id_kind_external_id"`
+	ExternalID   int64      `gorm:"uniqueIndex:idx_check_runs_repository_id_commit_id_kind_external_id"`
+	Name         string     `gorm:"type:varchar"`
+	Conclusion   string     `gorm:"type:varchar(20)"`
+	URL          string     `gorm:"type:varchar"`
+	StartedAt    *time.Time `gorm:"index:idx_check_runs_repository_id_started_at"`
+	CompletedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ToDomain converts a Postgres CheckRun object to domain entity CheckRun.
+func (pc *CheckRun) ToDomain() *domain.CheckRun {
+	return &domain.CheckRun{
+		RepositoryID: pc.RepositoryID,
+		CommitID:     pc.CommitID,
+		Kind:         pc.Kind,
+		ExternalID:   pc.ExternalID,
+		Name:         pc.Name,
+		Conclusion:   pc.Conclusion,
+		URL:          pc.URL,
+		StartedAt:    pc.StartedAt,
+		CompletedAt:  pc.CompletedAt,
+	}
+}
+
+// FromDomainCheckRun returns a Postgres CheckRun object from domain entity CheckRun.
+func FromDomainCheckRun(c *domain.CheckRun) *CheckRun {
+	return &CheckRun{
+		RepositoryID: c.RepositoryID,
+		CommitID:     c.CommitID,
+		Kind:         c.Kind,
+		ExternalID:   c.ExternalID,
+		Name:         c.Name,
+		Conclusion:   c.Conclusion,
+		URL:          c.URL,
+		StartedAt:    c.StartedAt,
+		CompletedAt:  c.CompletedAt,
+	}
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresCheckRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresCheckRepository(db *gorm.DB) repository.CheckRepository {
+	return &PostgresCheckRepository{DB: db}
+}
+
+// CommitsPendingChecks fetches at most limit commits of the tracked branches of a repository committed since the
+// given date whose checks are not settled yet, those never synced first, newest first, then those synced the longest
+// ago, so every pending commit is polled in turn
+func (p *PostgresCheckRepository) CommitsPendingChecks(ctx context.Context, repo domain.RepoMetadata, since time.Time, limit int) ([]domain.Commit, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbCommits []Commit
+	err := p.DB.WithContext(ctx).Model(&Commit{}).
+		Select(repositoryCommitColumns).
+		Joins("JOIN repository_commits ON repository_commits.commit_id = commits.commit_id").
+		Joins("JOIN repositories ON repositories.id = repository_commits.repository_id").
+		Where("repository_commits.repository_id = ? AND NOT repository_commits.checks_settled", repo.ID).
+		Where("EXISTS (SELECT 1 FROM branch_commits WHERE branch_commits.repository_id = repository_commits.repository_id AND branch_commits.commit_id = commits.commit_id)").
+		Where("commits.committed_at >= ?", since).
+		Order("repository_commits.checks_polled_at ASC NULLS FIRST, commits.committed_at DESC").
+		Limit(limit).
+		Find(&dbCommits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return domainCommits(dbCommits), nil
+}
+
+// SaveCheckRuns stores the check runs of a commit of a repository, updating the runs already stored, and records
+// whether its checks are settled and when they were synced in a single transaction
+func (p *PostgresCheckRepository) SaveCheckRuns(ctx context.Context, repo domain.RepoMetadata, commitID string, runs []domain.CheckRun, settled bool) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(runs) > 0 {
+			dbRuns := make([]*CheckRun, 0, len(runs))
+			for i := range runs {
+				dbRuns = append(dbRuns, FromDomainCheckRun(&runs[i]))
+			}
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "repository_id"}, {Name: "commit_id"}, {Name: "kind"}, {Name: "external_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"name", "conclusion", "url", "started_at", "completed_at", "updated_at"}),
+			}).CreateInBatches(dbRuns, commitInsertBatchSize).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&RepositoryCommit{}).
+			Where("repository_id = ? AND commit_id = ?", repo.ID, commitID).
+			Updates(map[string]interface{}{"checks_settled": settled, "checks_polled_at": time.Now()}).Error
+	})
+}
+
+// CheckRunsByCommitID fetches the check runs of a commit of a repository, oldest first
+func (p *PostgresCheckRepository) CheckRunsByCommitID(ctx context.Context, repo domain.RepoMetadata, commitID string) ([]domain.CheckRun, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbRuns []CheckRun
+	err := p.DB.WithContext(ctx).
+		Where("repository_id = ? AND commit_id = ?", repo.ID, commitID).
+		Order("started_at ASC NULLS LAST, external_id ASC").
+		Find(&dbRuns).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return domainCheckRuns(dbRuns), nil
+}
+
+// CheckRunsByRepository fetches the check runs of a repository started within the given window, a zero date leaves it open
+func (p *PostgresCheckRepository) CheckRunsByRepository(ctx context.Context, repo domain.RepoMetadata, from, to time.Time) ([]domain.CheckRun, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	db := p.DB.WithContext(ctx).Where("repository_id = ?", repo.ID)
+	if !from.IsZero() {
+		db = db.Where("started_at >= ?", from)
+	}
+	if !to.IsZero() {
+		db = db.Where("started_at <= ?", to)
+	}
+
+	var dbRuns []CheckRun
+	if err := db.Order("commit_id, started_at ASC").Find(&dbRuns).Error; err != nil {
+		return nil, err
+	}
+
+	return domainCheckRuns(dbRuns), nil
+}
+
+func domainCheckRuns(dbRuns []CheckRun) []domain.CheckRun {
+	runs := make([]domain.CheckRun, 0, len(dbRuns))
+	for _, r := range dbRuns {
+		runs = append(runs, *r.ToDomain())
+	}
+	return runs
+}
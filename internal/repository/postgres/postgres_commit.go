@@ -1,44 +1,144 @@
 package postgres
 
 import (
+	"strings"
 	"time"
 
 	"github.com/kenmobility/git-api-service/internal/domain"
 )
 
-// Commit represents the GORM model for the commits table.
+// Commit represents the GORM model for the commits table, a commit is stored once by sha and linked to
+// every repository containing it through the repository_commits table. RepositoryID, RepositoryName and
+// FirstReleasedIn are not columns, they are read from the repository a commit is queried through. AuthorKey
+// is the identity key of the author, the author queries group on it.
 type Commit struct {
+	ID              uint   `gorm:"primaryKey"`
+	CommitID        string `gorm:"type:varchar(100);uniqueIndex"`
+	Message         string `gorm:"type:varchar"`
+	Author          string `gorm:"type:varchar"`
+	AuthorEmail     string `gorm:"type:varchar"`
+	AuthorLogin     string `gorm:"type:varchar"`
+	AuthorKey       string `gorm:"type:varchar;index"`
+	Date            time.Time
+	Committer       string    `gorm:"type:varchar"`
+	CommitterEmail  string    `gorm:"type:varchar"`
+	CommitterLogin  string    `gorm:"type:varchar"`
+	CommittedAt     time.Time `gorm:"index"`
+	URL             string    `gorm:"type:varchar"`
+	RepositoryID    uint      `gorm:"->;-:migration"`
+	RepositoryName  string    `gorm:"->;-:migration"`
+	FirstReleasedIn string    `gorm:"->;-:migration"`
+	ParentSHAs      string    `gorm:"type:text"`
+	Additions       int
+	Deletions       int
+	FilesChanged    int
+	// EnrichedAt is the date the stats and file changes of the commit were stored, nil until then
+	EnrichedAt *time.Time
+	// EnrichmentError is the error the detail of the commit failed to be fetched with for good, the commit is not retried
+	EnrichmentError string `gorm:"type:varchar"`
+	// EnrichmentAttempts is the number of times the detail of the commit failed to be fetched with a transient error
+	EnrichmentAttempts int `gorm:"not null;default:0"`
+	// EnrichmentRetryAt is the date the detail of the commit is fetched again after a transient error
+	EnrichmentRetryAt *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// RepositoryCommit represents the GORM model for the repository_commits table, it links
+// a stored commit to a tracked repository containing it.
+type RepositoryCommit struct {
+	ID           uint        `gorm:"primaryKey"`
+	RepositoryID uint        `gorm:"uniqueIndex:idx_repository_commits_repository_id_commit"`
+	Repository   *Repository `gorm:"constraint:OnDelete:CASCADE"`
+	CommitID     string      `gorm:"type:varchar(100);uniqueIndex:idx_repository_commits_repository_id_commit;index"`
+	// FirstReleasedIn is the tag of the earliest release of the repository containing the commit
+	FirstReleasedIn string `gorm:"type:varchar;index"`
+	// ReferencesParsed reports whether the message of the commit was parsed for issue and ticket references
+	ReferencesParsed bool `gorm:"not null;default:false;index"`
+	// ChecksSettled reports whether the statuses and check runs of the commit are final
+	ChecksSettled bool `gorm:"not null;default:false"`
+	// ChecksPolledAt is when the statuses and check runs of the commit were last synced
+	ChecksPolledAt *time.Time
+	CreatedAt      time.Time
+}
+
+// ArchivedCommit represents the GORM model for the archived_commits table, it holds
+// the commits of untracked repositories whose history was retained.
+type ArchivedCommit struct {
 	ID             uint   `gorm:"primaryKey"`
-	CommitID       string `gorm:"type:varchar(100);uniqueIndex"`
+	CommitID       string `gorm:"type:varchar(100);index"`
 	Message        string `gorm:"type:varchar"`
 	Author         string `gorm:"type:varchar"`
+	AuthorEmail    string `gorm:"type:varchar"`
+	AuthorLogin    string `gorm:"type:varchar"`
 	Date           time.Time
+	Committer      string `gorm:"type:varchar"`
+	CommitterEmail string `gorm:"type:varchar"`
+	CommitterLogin string `gorm:"type:varchar"`
+	CommittedAt    time.Time
 	URL            string `gorm:"type:varchar"`
 	RepositoryName string `gorm:"type:varchar(100);index"`
+	ParentSHAs     string `gorm:"type:text"`
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	ArchivedAt     time.Time
 }
 
 // ToDomain converts a PostgresCommit to a generic domain entity Commit.
 func (pc *Commit) ToDomain() *domain.Commit {
 	return &domain.Commit{
-		CommitID:       pc.CommitID,
-		Message:        pc.Message,
-		Author:         pc.Author,
-		Date:           pc.Date,
-		URL:            pc.URL,
-		RepositoryName: pc.RepositoryName,
+		CommitID:        pc.CommitID,
+		Message:         pc.Message,
+		Author:          pc.Author,
+		AuthorEmail:     pc.AuthorEmail,
+		AuthorLogin:     pc.AuthorLogin,
+		Date:            pc.Date,
+		Committer:       pc.Committer,
+		CommitterEmail:  pc.CommitterEmail,
+		CommitterLogin:  pc.CommitterLogin,
+		CommittedAt:     pc.CommittedAt,
+		URL:             pc.URL,
+		RepositoryID:    pc.RepositoryID,
+		RepositoryName:  pc.RepositoryName,
+		FirstReleasedIn: pc.FirstReleasedIn,
+		ParentSHAs:      splitSHAs(pc.ParentSHAs),
+		Stats:           pc.stats(),
 	}
 }
 
+// stats returns the line stats of an enriched commit, nil when it is not enriched
+func (pc *Commit) stats() *domain.CommitStats {
+	if pc.EnrichedAt == nil {
+		return nil
+	}
+	return &domain.CommitStats{Additions: pc.Additions, Deletions: pc.Deletions, FilesChanged: pc.FilesChanged}
+}
+
 // FromDomain creates a PostgresCommit from a generic domain entity Commit.
 func FromDomainCommit(c *domain.Commit) *Commit {
 	return &Commit{
 		CommitID:       c.CommitID,
 		Message:        c.Message,
 		Author:         c.Author,
+		AuthorEmail:    c.AuthorEmail,
+		AuthorLogin:    c.AuthorLogin,
+		AuthorKey:      c.AuthorIdentity().Key(),
 		Date:           c.Date,
+		Committer:      c.Committer,
+		CommitterEmail: c.CommitterEmail,
+		CommitterLogin: c.CommitterLogin,
+		CommittedAt:    c.CommittedAt,
 		URL:            c.URL,
+		RepositoryID:   c.RepositoryID,
 		RepositoryName: c.RepositoryName,
+		ParentSHAs:     strings.Join(c.ParentSHAs, ","),
+	}
+}
+
+// splitSHAs splits comma separated commit shas
+func splitSHAs(shas string) []string {
+	if shas == "" {
+		return nil
 	}
+	return strings.Split(shas, ",")
 }
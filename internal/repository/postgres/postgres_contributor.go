@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// Contributor represents the Postgres model for the contributors table, it holds a person authoring commits
+// under the identities linked to it in the contributor_identities table.
+type Contributor struct {
+	ID         uint   `gorm:"primaryKey"`
+	PublicID   string `gorm:"type:varchar;uniqueIndex"`
+	Name       string `gorm:"type:varchar"`
+	Email      string `gorm:"type:varchar"`
+	Login      string `gorm:"type:varchar"`
+	Identities []ContributorIdentity
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ContributorIdentity represents the Postgres model for the contributor_identities table, it resolves the
+// commits whose author key is Key to a contributor.
+type ContributorIdentity struct {
+	ID            uint         `gorm:"primaryKey"`
+	Key           string       `gorm:"type:varchar;uniqueIndex"`
+	ContributorID uint         `gorm:"index"`
+	Contributor   *Contributor `gorm:"constraint:OnDelete:CASCADE"`
+	Name          string       `gorm:"type:varchar"`
+	Email         string       `gorm:"type:varchar"`
+	Login         string       `gorm:"type:varchar"`
+	Source        string       `gorm:"type:varchar(20)"`
+	CreatedAt     time.Time
+}
+
+// ToDomain converts a Postgres Contributor object to domain entity Contributor.
+func (pc *Contributor) ToDomain() *domain.Contributor {
+	identities := make([]domain.ContributorIdentity, 0, len(pc.Identities))
+	for _, i := range pc.Identities {
+		identities = append(identities, domain.ContributorIdentity{
+			Key:    i.Key,
+			Name:   i.Name,
+			Email:  i.Email,
+			Login:  i.Login,
+			Source: i.Source,
+		})
+	}
+	return &domain.Contributor{
+		ID:         pc.ID,
+		PublicID:   pc.PublicID,
+		Name:       pc.Name,
+		Email:      pc.Email,
+		Login:      pc.Login,
+		Identities: identities,
+		CreatedAt:  pc.CreatedAt,
+		UpdatedAt:  pc.UpdatedAt,
+	}
+}
+
+// FromDomainContributorIdentity returns a Postgres ContributorIdentity object of a contributor from domain entity ContributorIdentity.
+func FromDomainContributorIdentity(contributorID uint, i domain.ContributorIdentity) ContributorIdentity {
+	return ContributorIdentity{
+		Key:           i.Key,
+		ContributorID: contributorID,
+		Name:          i.Name,
+		Email:         i.Email,
+		Login:         i.Login,
+		Source:        i.Source,
+	}
+}
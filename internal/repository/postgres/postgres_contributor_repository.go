@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// contributorJoins joins commits to the contributors their authors are resolved to
+const contributorJoins = `LEFT JOIN contributor_identities ON contributor_identities.key = commits.author_key
+	LEFT JOIN contributors ON contributors.id = contributor_identities.contributor_id`
+
+// contributorKeyColumn is the author key of a commit aggregated by contributor, its identity key while unresolved
+const contributorKeyColumn = "COALESCE('" + domain.ContributorKeyPrefix + "' || contributors.public_id, commits.author_key)"
+
+type PostgresContributorRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresContributorRepository(db *gorm.DB) repository.ContributorRepository {
+	return &PostgresContributorRepository{DB: db}
+}
+
+// UnresolvedAuthors fetches the distinct author identities of the commits of a repository whose key, or whose email
+// for an author with a login, is not resolved to a contributor yet
+func (p *PostgresContributorRepository) UnresolvedAuthors(ctx context.Context, repo domain.RepoMetadata) ([]domain.Identity, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var identities []domain.Identity
+	err := p.DB.WithContext(ctx).Model(&Commit{}).
+		Select("DISTINCT commits.author AS name, commits.author_email AS email, commits.author_login AS login").
+		Joins("JOIN repository_commits ON repository_commits.commit_id = commits.commit_id").
+		Where("repository_commits.repository_id = ?", repo.ID).
+		Where(`(NOT EXISTS (SELECT 1 FROM contributor_identities ci WHERE ci.key = commits.author_key)
+			OR (commits.author_login <> '' AND commits.author_email <> ''
+				AND NOT EXISTS (SELECT 1 FROM contributor_identities ci WHERE ci.key = 'email:' || LOWER(BTRIM(commits.author_email)))))`).
+		Scan(&identities).Error
+	if err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+// AuthoredOutsideRepository reports whether commits of a repository other than repo are authored under one of keys
+func (p *PostgresContributorRepository) AuthoredOutsideRepository(ctx context.Context, repo domain.RepoMetadata, keys []string) (bool, error) {
+	if ctx.Err() == context.Canceled {
+		return false, message.ErrContextCancelled
+	}
+	if len(keys) == 0 {
+		return false, nil
+	}
+
+	var found []string
+	err := p.DB.WithContext(ctx).Model(&Commit{}).
+		Select("commits.commit_id").
+		Joins("JOIN repository_commits ON repository_commits.commit_id = commits.commit_id").
+		Where("commits.author_key IN ?", keys).
+		Where("repository_commits.repository_id <> ?", repo.ID).
+		Limit(1).
+		Scan(&found).Error
+	if err != nil {
+		return false, err
+	}
+	return len(found) > 0, nil
+}
+
+func (p *PostgresContributorRepository) ContributorByIdentityKey(ctx context.Context, key string) (*domain.Contributor, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var identity ContributorIdentity
+	if err := p.DB.WithContext(ctx).Where("key = ?", key).Find(&identity).Error; err != nil {
+		return nil, err
+	}
+	if identity.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+
+	return p.contributor(p.DB.WithContext(ctx).Where("id = ?", identity.ContributorID))
+}
+
+func (p *PostgresContributorRepository) ContributorByPublicId(ctx context.Context, publicID string) (*domain.Contributor, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	return p.contributor(p.DB.WithContext(ctx).Where("public_id = ?", publicID))
+}
+
+func (p *PostgresContributorRepository) CreateContributor(ctx context.Context, contributor domain.Contributor, identities []domain.ContributorIdentity) (*domain.Contributor, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	dbContributor := Contributor{
+		PublicID: contributor.PublicID,
+		Name:     contributor.Name,
+		Email:    contributor.Email,
+		Login:    contributor.Login,
+	}
+	err := p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&dbContributor).Error; err != nil {
+			return err
+		}
+		return addContributorIdentities(tx, dbContributor.ID, identities)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.contributor(p.DB.WithContext(ctx).Where("id = ?", dbContributor.ID))
+}
+
+func (p *PostgresContributorRepository) UpdateContributor(ctx context.Context, contributor domain.Contributor) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return p.DB.WithContext(ctx).Model(&Contributor{}).
+		Where("id = ?", contributor.ID).
+		Updates(map[string]interface{}{
+			"name":  contributor.Name,
+			"email": contributor.Email,
+			"login": contributor.Login,
+		}).Error
+}
+
+// AddContributorIdentities resolves identities to a contributor, identities already resolved are left as they are
+func (p *PostgresContributorRepository) AddContributorIdentities(ctx context.Context, contributor domain.Contributor, identities []domain.ContributorIdentity) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return addContributorIdentities(p.DB.WithContext(ctx), contributor.ID, identities)
+}
+
+// MergeContributors moves the identities of the sources to the target contributor and deletes the sources
+func (p *PostgresContributorRepository) MergeContributors(ctx context.Context, target domain.Contributor, sources []domain.Contributor) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	sourceIDs := make([]uint, 0, len(sources))
+	for _, s := range sources {
+		if s.ID != target.ID {
+			sourceIDs = append(sourceIDs, s.ID)
+		}
+	}
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+
+	return p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&ContributorIdentity{}).
+			Where("contributor_id IN ?", sourceIDs).
+			Update("contributor_id", target.ID).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("id IN ?", sourceIDs).Delete(&Contributor{}).Error
+	})
+}
+
+// contributor fetches the contributor matching a query with its identities
+func (p *PostgresContributorRepository) contributor(query *gorm.DB) (*domain.Contributor, error) {
+	var contributor Contributor
+	err := query.Preload("Identities", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).Find(&contributor).Error
+	if err != nil {
+		return nil, err
+	}
+	if contributor.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	return contributor.ToDomain(), nil
+}
+
+func addContributorIdentities(tx *gorm.DB, contributorID uint, identities []domain.ContributorIdentity) error {
+	if len(identities) == 0 {
+		return nil
+	}
+
+	dbIdentities := make([]ContributorIdentity, 0, len(identities))
+	for _, i := range identities {
+		dbIdentities = append(dbIdentities, FromDomainContributorIdentity(contributorID, i))
+	}
+	return tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoNothing: true}).
+		Create(&dbIdentities).Error
+}
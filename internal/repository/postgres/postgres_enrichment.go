@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// CommitFile represents the Postgres model for the commit_files table, it holds a change of a file in a
+// commit, the changes are stored once per commit like the commits themselves.
+type CommitFile struct {
+	ID           uint   `gorm:"primaryKey"`
+	CommitID     string `gorm:"type:varchar(40);uniqueIndex:idx_commit_files_commit_id_path"`
+	Path         string `gorm:"type:varchar;uniqueIndex:idx_commit_files_commit_id_path;index"`
+	PreviousPath string `gorm:"type:varchar;index"`
+	Status       string `gorm:"type:varchar(20)"`
+	Additions    int
+	Deletions    int
+	CreatedAt    time.Time
+}
+
+// EnrichmentState represents the Postgres model for the enrichment_states table, it holds the last
+// enrichment run of a repository.
+type EnrichmentState struct {
+	ID           uint `gorm:"primaryKey"`
+	RepositoryID uint `gorm:"uniqueIndex"`
+	LastRunAt    *time.Time
+	LastError    string `gorm:"type:varchar"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ToDomain converts a Postgres CommitFile object to domain entity FileChange.
+func (pf *CommitFile) ToDomain() *domain.FileChange {
+	return &domain.FileChange{
+		CommitID:     pf.CommitID,
+		Path:         pf.Path,
+		PreviousPath: pf.PreviousPath,
+		Status:       pf.Status,
+		Additions:    pf.Additions,
+		Deletions:    pf.Deletions,
+	}
+}
+
+// FromDomainFileChange returns a Postgres CommitFile object from domain entity FileChange.
+func FromDomainFileChange(f *domain.FileChange) *CommitFile {
+	return &CommitFile{
+		CommitID:     f.CommitID,
+		Path:         f.Path,
+		PreviousPath: f.PreviousPath,
+		Status:       f.Status,
+		Additions:    f.Additions,
+		Deletions:    f.Deletions,
+	}
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresEnrichmentRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresEnrichmentRepository(db *gorm.DB) repository.EnrichmentRepository {
+	return &PostgresEnrichmentRepository{DB: db}
+}
+
+// UnenrichedCommits fetches at most limit commits of a repository not enriched with their file changes yet, newest first,
+// the commits whose detail failed to be fetched for good or is to be fetched again later are left out
+func (p *PostgresEnrichmentRepository) UnenrichedCommits(ctx context.Context, repo domain.RepoMetadata, limit int) ([]domain.Commit, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbCommits []Commit
+	err := p.DB.WithContext(ctx).Model(&Commit{}).
+		Select(repositoryCommitColumns).
+		Joins("JOIN repository_commits ON repository_commits.commit_id = commits.commit_id").
+		Joins("JOIN repositories ON repositories.id = repository_commits.repository_id").
+		Where("repository_commits.repository_id = ? AND commits.enriched_at IS NULL AND commits.enrichment_error = ''", repo.ID).
+		Where("(commits.enrichment_retry_at IS NULL OR commits.enrichment_retry_at <= ?)", time.Now()).
+		Order("commits.committed_at DESC").
+		Limit(limit).
+		Find(&dbCommits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return domainCommits(dbCommits), nil
+}
+
+// SaveCommitDetail stores the file changes of a commit, replacing those already stored, and its stats in a single transaction
+func (p *PostgresEnrichmentRepository) SaveCommitDetail(ctx context.Context, commitID string, detail domain.CommitDetail) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("commit_id = ?", commitID).Delete(&CommitFile{}).Error; err != nil {
+			return err
+		}
+
+		if len(detail.Files) > 0 {
+			dbFiles := make([]*CommitFile, 0, len(detail.Files))
+			for i := range detail.Files {
+				dbFiles = append(dbFiles, FromDomainFileChange(&detail.Files[i]))
+			}
+			err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(dbFiles, commitInsertBatchSize).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&Commit{}).
+			Where("commit_id = ?", commitID).
+			Updates(map[string]interface{}{
+				"additions":     detail.Stats.Additions,
+				"deletions":     detail.Stats.Deletions,
+				"files_changed": detail.Stats.FilesChanged,
+				"enriched_at":   time.Now(),
+			}).Error
+	})
+}
+
+// SaveEnrichmentFailure records the error the detail of a commit failed to be fetched with for good
+func (p *PostgresEnrichmentRepository) SaveEnrichmentFailure(ctx context.Context, commitID string, reason string) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return p.DB.WithContext(ctx).Model(&Commit{}).
+		Where("commit_id = ?", commitID).
+		Update("enrichment_error", reason).Error
+}
+
+// SaveEnrichmentRetry records a transient failure to fetch the detail of a commit, which is fetched again after retryAt,
+// and records reason as its failure once it failed maxAttempts times
+func (p *PostgresEnrichmentRepository) SaveEnrichmentRetry(ctx context.Context, commitID string, reason string, retryAt time.Time, maxAttempts int) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return p.DB.WithContext(ctx).Model(&Commit{}).
+		Where("commit_id = ?", commitID).
+		Updates(map[string]interface{}{
+			"enrichment_attempts": gorm.Expr("enrichment_attempts + 1"),
+			"enrichment_retry_at": retryAt,
+			"enrichment_error":    gorm.Expr("CASE WHEN enrichment_attempts + 1 >= ? THEN ? ELSE '' END", maxAttempts, reason),
+		}).Error
+}
+
+// EnrichmentStateByRepository returns the enrichment progress of the commits of a repository and its last run
+func (p *PostgresEnrichmentRepository) EnrichmentStateByRepository(ctx context.Context, repo domain.RepoMetadata) (*domain.EnrichmentState, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var counts struct {
+		Total    int
+		Enriched int
+		Failed   int
+	}
+	err := p.DB.WithContext(ctx).Model(&RepositoryCommit{}).
+		Select(`count(*) AS total, count(commits.enriched_at) AS enriched,
+			count(*) FILTER (WHERE commits.enriched_at IS NULL AND commits.enrichment_error <> '') AS failed`).
+		Joins("JOIN commits ON commits.commit_id = repository_commits.commit_id").
+		Where("repository_commits.repository_id = ?", repo.ID).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var run EnrichmentState
+	if err := p.DB.WithContext(ctx).Where("repository_id = ?", repo.ID).Find(&run).Error; err != nil {
+		return nil, err
+	}
+
+	return &domain.EnrichmentState{
+		RepositoryID:    repo.ID,
+		TotalCommits:    counts.Total,
+		EnrichedCommits: counts.Enriched,
+		FailedCommits:   counts.Failed,
+		LastRunAt:       run.LastRunAt,
+		LastError:       run.LastError,
+	}, nil
+}
+
+// SaveEnrichmentRun records the date of an enrichment run of a repository and the error it stopped on, if any
+func (p *PostgresEnrichmentRepository) SaveEnrichmentRun(ctx context.Context, repo domain.RepoMetadata, runAt time.Time, runErr string) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return p.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "repository_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_run_at", "last_error", "updated_at"}),
+	}).Create(&EnrichmentState{RepositoryID: repo.ID, LastRunAt: &runAt, LastError: runErr}).Error
+}
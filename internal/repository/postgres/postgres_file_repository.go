@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+)
+
+// likeEscaper escapes the wildcards of a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type PostgresFileRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresFileRepository(db *gorm.DB) repository.FileRepository {
+	return &PostgresFileRepository{DB: db}
+}
+
+// PathStatsByRepository fetches the stats of the files and the directories changed by the enriched commits of a
+// repository committed within the given window under a path prefix, aggregated per path, a zero date leaves the window
+// open and an empty prefix matches every path. Directories end with a slash and the root is left out.
+func (p *PostgresFileRepository) PathStatsByRepository(ctx context.Context, repo domain.RepoMetadata, from, to time.Time, pathPrefix string) ([]domain.Hotspot, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	// the authors are counted by contributor
+	stats := `COUNT(DISTINCT commit_files.commit_id) AS changes, SUM(commit_files.additions + commit_files.deletions) AS churn,
+		COUNT(DISTINCT ` + contributorKeyColumn + `) AS authors, MAX(commits.committed_at) AS last_changed_at`
+
+	var files []domain.Hotspot
+	err := p.changedFiles(ctx, repo, from, to, pathPrefix).
+		Joins(contributorJoins).
+		Select("commit_files.path, " + stats).
+		Group("commit_files.path").
+		Scan(&files).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []domain.Hotspot
+	err = p.changedFiles(ctx, repo, from, to, pathPrefix).
+		Joins(contributorJoins).
+		Joins(directoriesJoin(1)).
+		Select("directories.dir || '/' AS path, " + stats).
+		Group("directories.dir").
+		Scan(&dirs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range dirs {
+		dirs[i].IsDir = true
+	}
+	return append(files, dirs...), nil
+}
+
+// AuthorChangesByRepository fetches the changes of the directories, the root included, changed by the enriched commits
+// of a repository under a path prefix, aggregated per directory and author, each change weighted by its lines and
+// halved every halfLife before now, zero disabling the decay
+func (p *PostgresFileRepository) AuthorChangesByRepository(ctx context.Context, repo domain.RepoMetadata, pathPrefix string, halfLife time.Duration, now time.Time) ([]domain.AuthorChanges, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	weight := "GREATEST(commit_files.additions + commit_files.deletions, 1)"
+	var args []interface{}
+	if halfLife > 0 {
+		weight += " * POWER(0.5, GREATEST(EXTRACT(EPOCH FROM CAST(? AS timestamptz) - commits.committed_at), 0) / CAST(? AS double precision))"
+		args = append(args, now, halfLife.Seconds())
+	}
+
+	var changes []domain.AuthorChanges
+	// the authors are resolved to their contributors, named after their latest change
+	err := p.changedFiles(ctx, repo, time.Time{}, time.Time{}, pathPrefix).
+		Joins(contributorJoins).
+		Joins(directoriesJoin(0)).
+		Select(`directories.dir, `+contributorKeyColumn+` AS author_key,
+			(ARRAY_AGG(COALESCE(NULLIF(contributors.name, ''), commits.author) ORDER BY commits.committed_at DESC))[1] AS author,
+			(ARRAY_AGG(COALESCE(NULLIF(contributors.email, ''), commits.author_email) ORDER BY commits.committed_at DESC))[1] AS author_email,
+			(ARRAY_AGG(COALESCE(NULLIF(contributors.login, ''), commits.author_login) ORDER BY commits.committed_at DESC))[1] AS author_login,
+			COUNT(DISTINCT commit_files.commit_id) AS commits, SUM(commit_files.additions + commit_files.deletions) AS lines,
+			SUM(`+weight+`) AS weight, MAX(commits.committed_at) AS last_changed_at`, args...).
+		Group("directories.dir, " + contributorKeyColumn).
+		Scan(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// fileRevisionColumns selects the commits of the changes of a file along with the change
+const fileRevisionColumns = repositoryCommitColumns + `, commit_files.path AS file_path,
+	commit_files.previous_path AS file_previous_path, commit_files.status AS file_status,
+	commit_files.additions AS file_additions, commit_files.deletions AS file_deletions`
+
+// fileRevisionRow is a change of a file scanned along with its commit
+type fileRevisionRow struct {
+	Commit
+	FilePath         string
+	FilePreviousPath string
+	FileStatus       string
+	FileAdditions    int
+	FileDeletions    int
+}
+
+func (r fileRevisionRow) toDomain() domain.FileRevision {
+	return domain.FileRevision{
+		Commit: *r.Commit.ToDomain(),
+		Change: domain.FileChange{
+			CommitID:     r.CommitID,
+			Path:         r.FilePath,
+			PreviousPath: r.FilePreviousPath,
+			Status:       r.FileStatus,
+			Additions:    r.FileAdditions,
+			Deletions:    r.FileDeletions,
+		},
+	}
+}
+
+// LatestFileRename fetches the newest change renaming a file to path by the commits of a repository committed
+// before before, a zero date matching every commit
+func (p *PostgresFileRepository) LatestFileRename(ctx context.Context, repo domain.RepoMetadata, path string, before time.Time) (*domain.FileRevision, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	db := p.fileRevisions(ctx, repo).
+		Where("commit_files.path = ? AND commit_files.status = ? AND commit_files.previous_path <> ''", path, domain.FileRenamed)
+	if !before.IsZero() {
+		db = db.Where("commits.committed_at < ?", before)
+	}
+
+	var rows []fileRevisionRow
+	if err := db.Limit(1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	revision := rows[0].toDomain()
+	return &revision, nil
+}
+
+// FileRevisionsBySpans fetches a page of the changes of the paths a file had over the spans of its history by the
+// commits of a repository, newest first
+func (p *PostgresFileRepository) FileRevisionsBySpans(ctx context.Context, repo domain.RepoMetadata, spans []domain.FilePathSpan, query domain.APIPagingData) ([]domain.FileRevision, *domain.PagingInfo, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, nil, message.ErrContextCancelled
+	}
+
+	queryInfo, offset := repository.GetQueryPaginationData(query)
+	if len(spans) == 0 {
+		pagingInfo := repository.PagingInfo(queryInfo, 0)
+		return nil, &pagingInfo, nil
+	}
+
+	conditions := make([]string, 0, len(spans))
+	var args []interface{}
+	for _, span := range spans {
+		condition := "commit_files.path = ?"
+		args = append(args, span.Path)
+		if !span.From.IsZero() {
+			condition += " AND commits.committed_at >= ?"
+			args = append(args, span.From)
+		}
+		if !span.Until.IsZero() {
+			condition += " AND commits.committed_at < ?"
+			args = append(args, span.Until)
+		}
+		conditions = append(conditions, "("+condition+")")
+	}
+	db := p.repositoryFiles(ctx, repo).
+		Where("("+strings.Join(conditions, " OR ")+")", args...).
+		Session(&gorm.Session{})
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var rows []fileRevisionRow
+	err := db.Joins("JOIN repositories ON repositories.id = repository_commits.repository_id").
+		Select(fileRevisionColumns).
+		Order("commits.committed_at DESC, commits.date DESC").
+		Offset(offset).Limit(queryInfo.Limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revisions := make([]domain.FileRevision, 0, len(rows))
+	for _, r := range rows {
+		revisions = append(revisions, r.toDomain())
+	}
+	pagingInfo := repository.PagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(revisions)
+	return revisions, &pagingInfo, nil
+}
+
+// fileRevisions selects the changes of the files of a repository along with their commit, newest first
+func (p *PostgresFileRepository) fileRevisions(ctx context.Context, repo domain.RepoMetadata) *gorm.DB {
+	return p.repositoryFiles(ctx, repo).
+		Joins("JOIN repositories ON repositories.id = repository_commits.repository_id").
+		Select(fileRevisionColumns).
+		Order("commits.committed_at DESC, commits.date DESC")
+}
+
+// repositoryFiles scopes a commit files query to the commits of a repository
+func (p *PostgresFileRepository) repositoryFiles(ctx context.Context, repo domain.RepoMetadata) *gorm.DB {
+	return p.DB.WithContext(ctx).Model(&CommitFile{}).
+		Joins("JOIN commits ON commits.commit_id = commit_files.commit_id").
+		Joins("JOIN repository_commits ON repository_commits.commit_id = commit_files.commit_id").
+		Where("repository_commits.repository_id = ?", repo.ID)
+}
+
+// changedFiles scopes a commit files query to the commits of a repository committed within the given window under a
+// path prefix, a zero date leaves the window open and an empty prefix matches every path
+func (p *PostgresFileRepository) changedFiles(ctx context.Context, repo domain.RepoMetadata, from, to time.Time, pathPrefix string) *gorm.DB {
+	db := p.repositoryFiles(ctx, repo)
+	if !from.IsZero() {
+		db = db.Where("commits.committed_at >= ?", from)
+	}
+	if !to.IsZero() {
+		db = db.Where("commits.committed_at <= ?", to)
+	}
+	if pathPrefix != "" {
+		db = db.Where("commit_files.path LIKE ?", likeEscaper.Replace(pathPrefix)+"%")
+	}
+	return db
+}
+
+// directoriesJoin expands each file change to the directories containing its path from depth minDepth on, the root
+// being the empty directory at depth 0
+func directoriesJoin(minDepth int) string {
+	return fmt.Sprintf(`CROSS JOIN LATERAL (
+		SELECT ARRAY_TO_STRING((STRING_TO_ARRAY(commit_files.path, '/'))[1:depth], '/') AS dir
+		FROM GENERATE_SERIES(%d, ARRAY_LENGTH(STRING_TO_ARRAY(commit_files.path, '/'), 1) - 1) AS depth
+	) AS directories`, minDepth)
+}
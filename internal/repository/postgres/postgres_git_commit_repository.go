@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/kenmobility/git-api-service/internal/domain"
 	"github.com/kenmobility/git-api-service/internal/repository"
@@ -12,6 +13,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// repositoryCommitColumns selects the commits of a repository scoped query along with their repository
+const repositoryCommitColumns = "commits.*, repository_commits.repository_id, repository_commits.first_released_in, repositories.name AS repository_name"
+
+// commitInsertBatchSize is the number of commits inserted per statement, keeping the bind
+// parameters of a statement well below the Postgres limit
+const commitInsertBatchSize = 1000
+
 type PostgresGitCommitRepository struct {
 	DB *gorm.DB
 }
@@ -36,41 +44,65 @@ func (gc *PostgresGitCommitRepository) GetByCommitID(ctx context.Context, commit
 	return commit.ToDomain(), err
 }
 
-// SaveCommit stores a repository commit into the database
+// SaveCommit stores a repository commit into the database, linking an already stored commit to the repository
 func (gc *PostgresGitCommitRepository) SaveCommit(ctx context.Context, commit domain.Commit) (*domain.Commit, error) {
 	if ctx.Err() == context.Canceled {
 		return nil, message.ErrContextCancelled
 	}
 
-	dbCommit := FromDomainCommit(&commit)
+	saved, err := gc.SaveCommits(ctx, []domain.Commit{commit})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(saved) == 0 {
+		log.Warn().Msgf("already saved commit-id:%s", commit.CommitID)
+		return nil, message.ErrCommitAlreadySaved
+	}
+	return &saved[0], nil
+}
 
-	tx := gc.DB.WithContext(ctx).Create(&dbCommit)
+// StoredCommitIDs returns which of commitIDs are stored for a repository
+func (gc *PostgresGitCommitRepository) StoredCommitIDs(ctx context.Context, repo domain.RepoMetadata, commitIDs []string) ([]string, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
 
-	if tx.Error != nil {
-		if strings.Contains(tx.Error.Error(), `duplicate key value violates unique constraint "idx_commits_commit_id"`) {
-			log.Warn().Msgf("already saved commit-id:%s", commit.CommitID)
-			return nil, tx.Error
-		} else {
-			log.Info().Msgf("getting the save commit error and returning it")
+	stored := make([]string, 0, len(commitIDs))
+	for start := 0; start < len(commitIDs); start += commitInsertBatchSize {
+		end := min(start+commitInsertBatchSize, len(commitIDs))
+		var batch []string
+		err := gc.DB.WithContext(ctx).Model(&RepositoryCommit{}).
+			Where("repository_id = ? AND commit_id IN ?", repo.ID, commitIDs[start:end]).
+			Pluck("commit_id", &batch).Error
+		if err != nil {
+			return nil, err
 		}
-		return nil, tx.Error
+		stored = append(stored, batch...)
 	}
-	return dbCommit.ToDomain(), nil
+	return stored, nil
 }
 
-// AllCommitsByRepository fetches all stores commits by repository name
-func (gc *PostgresGitCommitRepository) AllCommitsByRepository(ctx context.Context, r domain.RepoMetadata, query domain.APIPagingData) ([]domain.Commit, *domain.PagingInfo, error) {
+// AllCommitsByRepository fetches all stores commits by repository name matching the filter
+func (gc *PostgresGitCommitRepository) AllCommitsByRepository(ctx context.Context, r domain.RepoMetadata, filter domain.CommitFilter, query domain.APIPagingData) ([]domain.Commit, *domain.PagingInfo, error) {
 	var dbCommits []Commit
 
 	var count, queryCount int64
 
 	queryInfo, offset := repository.GetQueryPaginationData(query)
 
-	db := gc.DB.WithContext(ctx).Model(&Commit{}).Where(&Commit{RepositoryName: r.Name})
+	db := gc.repositoryCommits(ctx, r)
+	if filter.Branch != "" {
+		db = db.Joins("JOIN branch_commits ON branch_commits.commit_id = commits.commit_id AND branch_commits.repository_id = repository_commits.repository_id AND branch_commits.branch = ?", filter.Branch)
+	}
+	if filter.Release != "" {
+		db = db.Where("repository_commits.first_released_in = ?", filter.Release)
+	}
+	db = db.Session(&gorm.Session{})
 
 	db.Count(&count)
 
-	db = db.Offset(offset).Limit(queryInfo.Limit).
+	db = db.Select(repositoryCommitColumns).Offset(offset).Limit(queryInfo.Limit).
 		Order(fmt.Sprintf("commits.%s %s", queryInfo.Sort, queryInfo.Direction)).
 		Find(&dbCommits)
 	db.Count(&queryCount)
@@ -89,17 +121,273 @@ func (gc *PostgresGitCommitRepository) AllCommitsByRepository(ctx context.Contex
 
 func (gc *PostgresGitCommitRepository) TopCommitAuthorsByRepository(ctx context.Context, repo domain.RepoMetadata, limit int) ([]domain.AuthorCommitCount, error) {
 	var results []domain.AuthorCommitCount
-	err := gc.DB.WithContext(ctx).Model(&domain.Commit{}).
-		Select("author, COUNT(author) as commit_count").
-		Where("repository_name = ?", repo.Name).
-		Group("author").
-		Order("commit_count DESC").
+	// authors are aggregated by contributor and shown with its canonical identity, an author not resolved to a
+	// contributor yet is shown with the identity of their latest commit
+	err := gc.repositoryCommits(ctx, repo).
+		Joins(contributorJoins).
+		Select(contributorKeyColumn + ` AS author_key, COUNT(*) AS commit_count,
+			COALESCE(NULLIF(MAX(contributors.name), ''), (ARRAY_AGG(commits.author ORDER BY commits.date DESC))[1]) AS author,
+			COALESCE(NULLIF(MAX(contributors.email), ''), (ARRAY_AGG(commits.author_email ORDER BY commits.date DESC))[1]) AS author_email,
+			COALESCE(NULLIF(MAX(contributors.login), ''), (ARRAY_AGG(commits.author_login ORDER BY commits.date DESC))[1]) AS author_login`).
+		Group(contributorKeyColumn).
+		Order("commit_count DESC, author_key").
 		Limit(limit).
 		Scan(&results).Error
 
 	return results, err
 }
 
+// SaveCommits stores a batch of commits with multi-row inserts that skip already stored commits, commits
+// stored for another repository are only linked to theirs, and returns the commits that were new to their repository
+func (gc *PostgresGitCommitRepository) SaveCommits(ctx context.Context, commits []domain.Commit) ([]domain.Commit, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	if len(commits) == 0 {
+		return nil, nil
+	}
+
+	var saved []domain.Commit
+	err := gc.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		saved, err = insertCommits(tx, commits)
+		return err
+	})
+	if err != nil {
+		log.Error().Msgf("Persistence::SaveCommits error: %v", err)
+		return nil, err
+	}
+	return saved, nil
+}
+
+// insertCommits inserts the commits missing from the commits table and links every commit to its repository,
+// it returns the commits that were not yet linked to their repository
+func insertCommits(tx *gorm.DB, commits []domain.Commit) ([]domain.Commit, error) {
+	type link struct {
+		repositoryID uint
+		commitID     string
+	}
+
+	linked := make(map[link]bool, len(commits))
+	now := time.Now()
+	for start := 0; start < len(commits); start += commitInsertBatchSize {
+		batch := commits[start:min(start+commitInsertBatchSize, len(commits))]
+
+		var sb strings.Builder
+		sb.WriteString(`INSERT INTO commits (commit_id, message, author, author_email, author_login, author_key, date, committer,
+			committer_email, committer_login, committed_at, url, parent_shas, created_at, updated_at) VALUES `)
+		args := make([]interface{}, 0, len(batch)*15)
+		for i, c := range batch {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString("(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+			args = append(args, c.CommitID, c.Message, c.Author, c.AuthorEmail, c.AuthorLogin, c.AuthorIdentity().Key(), c.Date,
+				c.Committer, c.CommitterEmail, c.CommitterLogin, c.CommittedAt, c.URL, strings.Join(c.ParentSHAs, ","), now, now)
+		}
+		sb.WriteString(" ON CONFLICT DO NOTHING")
+		if err := tx.Exec(sb.String(), args...).Error; err != nil {
+			return nil, err
+		}
+
+		sb.Reset()
+		sb.WriteString(`INSERT INTO repository_commits (repository_id, commit_id, created_at) VALUES `)
+		args = make([]interface{}, 0, len(batch)*3)
+		for i, c := range batch {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString("(?, ?, ?)")
+			args = append(args, c.RepositoryID, c.CommitID, now)
+		}
+		sb.WriteString(" ON CONFLICT DO NOTHING RETURNING repository_id, commit_id")
+
+		var links []RepositoryCommit
+		if err := tx.Raw(sb.String(), args...).Scan(&links).Error; err != nil {
+			return nil, err
+		}
+		for _, l := range links {
+			linked[link{l.RepositoryID, l.CommitID}] = true
+		}
+	}
+
+	saved := make([]domain.Commit, 0, len(linked))
+	for _, c := range commits {
+		key := link{c.RepositoryID, c.CommitID}
+		if linked[key] {
+			c.CreatedAt, c.UpdatedAt = now, now
+			saved = append(saved, c)
+			// a commit listed twice in the batch is linked once
+			delete(linked, key)
+		}
+	}
+	return saved, nil
+}
+
+// LatestCommit fetches the stored commit of a repository with the newest committer date
+func (gc *PostgresGitCommitRepository) LatestCommit(ctx context.Context, repo domain.RepoMetadata) (*domain.Commit, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var commit Commit
+	err := gc.repositoryCommits(ctx, repo).
+		Select(repositoryCommitColumns).
+		Order("commits.committed_at DESC, commits.date DESC").
+		Limit(1).
+		Find(&commit).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if commit.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	return commit.ToDomain(), nil
+}
+
+// CommitsByRepository fetches every stored commit of a repository
+func (gc *PostgresGitCommitRepository) CommitsByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.Commit, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbCommits []Commit
+	err := gc.repositoryCommits(ctx, repo).
+		Select(repositoryCommitColumns).
+		Find(&dbCommits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return domainCommits(dbCommits), nil
+}
+
+// UnreleasedCommits fetches the commits of a repository not recorded in any release yet
+func (gc *PostgresGitCommitRepository) UnreleasedCommits(ctx context.Context, repo domain.RepoMetadata) ([]domain.Commit, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbCommits []Commit
+	err := gc.repositoryCommits(ctx, repo).
+		Select(repositoryCommitColumns).
+		Where("(repository_commits.first_released_in IS NULL OR repository_commits.first_released_in = '')").
+		Find(&dbCommits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return domainCommits(dbCommits), nil
+}
+
+// ReleasedChildren fetches the released commits of a repository with an unreleased parent, the parent was stored
+// after the release of the commit was synced
+func (gc *PostgresGitCommitRepository) ReleasedChildren(ctx context.Context, repo domain.RepoMetadata) ([]domain.Commit, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbCommits []Commit
+	err := gc.repositoryCommits(ctx, repo).
+		Select(repositoryCommitColumns).
+		Where("repository_commits.first_released_in <> ''").
+		Where(`EXISTS (SELECT 1 FROM repository_commits parents WHERE parents.repository_id = repository_commits.repository_id
+			AND (parents.first_released_in IS NULL OR parents.first_released_in = '')
+			AND parents.commit_id = ANY(STRING_TO_ARRAY(commits.parent_shas, ',')))`).
+		Find(&dbCommits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return domainCommits(dbCommits), nil
+}
+
+// ApplyCommitDiff swaps the stored commits of a repository with a reindexed copy in a single transaction,
+// so readers keep seeing the previous commits until the swap is committed. A changed commit is updated in the
+// commits table shared by every repository containing it: its sha pins its content, so the changes are provider
+// metadata such as logins, which hold for every repository, except its URL which names the repository and is
+// only rewritten when no other repository contains the commit.
+func (gc *PostgresGitCommitRepository) ApplyCommitDiff(ctx context.Context, repo domain.RepoMetadata, diff domain.CommitDiff) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return gc.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(diff.Removed) > 0 {
+			removedIDs := make([]string, 0, len(diff.Removed))
+			for _, c := range diff.Removed {
+				removedIDs = append(removedIDs, c.CommitID)
+			}
+			err := tx.Where("repository_id = ? AND commit_id IN ?", repo.ID, removedIDs).Delete(&RepositoryCommit{}).Error
+			if err != nil {
+				return err
+			}
+
+			// commits still contained in another repository are kept
+			err = tx.Where("commit_id IN ?", removedIDs).
+				Where("NOT EXISTS (SELECT 1 FROM repository_commits WHERE repository_commits.commit_id = commits.commit_id)").
+				Delete(&Commit{}).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Where("commit_id IN ?", removedIDs).
+				Where("NOT EXISTS (SELECT 1 FROM commits WHERE commits.commit_id = commit_files.commit_id)").
+				Delete(&CommitFile{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, c := range diff.Changed {
+			err := tx.Model(&Commit{}).
+				Where("commit_id = ?", c.CommitID).
+				Updates(map[string]interface{}{
+					"message":         c.Message,
+					"author":          c.Author,
+					"author_email":    c.AuthorEmail,
+					"author_login":    c.AuthorLogin,
+					"author_key":      c.AuthorIdentity().Key(),
+					"date":            c.Date,
+					"committer":       c.Committer,
+					"committer_email": c.CommitterEmail,
+					"committer_login": c.CommitterLogin,
+					"committed_at":    c.CommittedAt,
+					"parent_shas":     strings.Join(c.ParentSHAs, ","),
+				}).Error
+			if err != nil {
+				return err
+			}
+
+			err = tx.Model(&Commit{}).
+				Where("commit_id = ?", c.CommitID).
+				Where("NOT EXISTS (SELECT 1 FROM repository_commits WHERE repository_commits.commit_id = commits.commit_id AND repository_commits.repository_id <> ?)", repo.ID).
+				Update("url", c.URL).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		added := make([]domain.Commit, 0, len(diff.Added))
+		for _, c := range diff.Added {
+			c.RepositoryID = repo.ID
+			added = append(added, c)
+		}
+		_, err := insertCommits(tx, added)
+		return err
+	})
+}
+
+// repositoryCommits scopes a commits query to the commits of a repository
+func (gc *PostgresGitCommitRepository) repositoryCommits(ctx context.Context, repo domain.RepoMetadata) *gorm.DB {
+	return gc.DB.WithContext(ctx).Model(&Commit{}).
+		Joins("JOIN repository_commits ON repository_commits.commit_id = commits.commit_id").
+		Joins("JOIN repositories ON repositories.id = repository_commits.repository_id").
+		Where("repository_commits.repository_id = ?", repo.ID)
+}
+
 func domainCommits(dbCommits []Commit) []domain.Commit {
 	if len(dbCommits) == 0 {
 		return nil
@@ -109,14 +397,24 @@ func domainCommits(dbCommits []Commit) []domain.Commit {
 
 	for _, c := range dbCommits {
 		cr := domain.Commit{
-			CommitID:       c.CommitID,
-			Message:        c.Message,
-			Author:         c.Author,
-			Date:           c.Date,
-			URL:            c.URL,
-			RepositoryName: c.RepositoryName,
-			CreatedAt:      c.CreatedAt,
-			UpdatedAt:      c.UpdatedAt,
+			CommitID:        c.CommitID,
+			Message:         c.Message,
+			Author:          c.Author,
+			AuthorEmail:     c.AuthorEmail,
+			AuthorLogin:     c.AuthorLogin,
+			Date:            c.Date,
+			Committer:       c.Committer,
+			CommitterEmail:  c.CommitterEmail,
+			CommitterLogin:  c.CommitterLogin,
+			CommittedAt:     c.CommittedAt,
+			URL:             c.URL,
+			RepositoryID:    c.RepositoryID,
+			RepositoryName:  c.RepositoryName,
+			FirstReleasedIn: c.FirstReleasedIn,
+			ParentSHAs:      splitSHAs(c.ParentSHAs),
+			Stats:           c.stats(),
+			CreatedAt:       c.CreatedAt,
+			UpdatedAt:       c.UpdatedAt,
 		}
 
 		domainCommits = append(domainCommits, cr)
@@ -0,0 +1,414 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/internal/repository/postgres"
+	"github.com/kenmobility/git-api-service/pkg/helpers"
+	"github.com/stretchr/testify/require"
+	pgdriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// benchmarkRepoSize is the number of commits ingested per benchmark iteration
+const benchmarkRepoSize = 100_000
+
+// testDB connects to the database of TEST_DATABASE_DSN, skipping when it is not set
+func testDB(tb testing.TB) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		tb.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	db, err := gorm.Open(pgdriver.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(tb, err)
+	require.NoError(tb, db.AutoMigrate(&postgres.Repository{}, &postgres.RepositoryAlias{}, &postgres.Commit{}, &postgres.RepositoryCommit{},
+		&postgres.ArchivedCommit{}, &postgres.SyncRange{}, &postgres.BackfillJob{}, &postgres.ReindexJob{}, &postgres.SyncCursor{}, &postgres.IntegrityReport{}, &postgres.MetadataSnapshot{}, &postgres.Stargazer{}, &postgres.OrgMembership{}, &postgres.OrgImport{}, &postgres.OrgImportResult{}, &postgres.UntrackedRepository{},
+		&postgres.Branch{}, &postgres.BranchCommit{}, &postgres.Tag{}, &postgres.Release{}, &postgres.PullRequest{}, &postgres.PullRequestCommit{}, &postgres.PullRequestSync{}, &postgres.Issue{}, &postgres.CommitReference{}, &postgres.TicketPatternSet{}, &postgres.CheckRun{}, &postgres.CommitFile{}, &postgres.EnrichmentState{},
+		&postgres.Contributor{}, &postgres.ContributorIdentity{}))
+	return db
+}
+
+// createRepo stores a repository and deletes it along with its commits once the test is done
+func createRepo(tb testing.TB, db *gorm.DB) domain.RepoMetadata {
+	store := postgres.NewPostgresGitRepoMetadataRepository(db)
+	repo, err := store.SaveRepoMetadata(context.Background(), domain.RepoMetadata{
+		PublicID: uuid.New().String(),
+		Name:     fmt.Sprintf("%s-%s", helpers.RandomRepositoryName(), helpers.RandomString(6)),
+	})
+	require.NoError(tb, err)
+
+	tb.Cleanup(func() {
+		require.NoError(tb, store.DeleteRepoMetadata(context.Background(), *repo, false))
+	})
+	return *repo
+}
+
+func randomCommits(repo domain.RepoMetadata, n int) []domain.Commit {
+	date := time.Now().Add(-time.Duration(n) * time.Minute)
+	commits := make([]domain.Commit, 0, n)
+	for i := 0; i < n; i++ {
+		commits = append(commits, domain.Commit{
+			CommitID:       helpers.RandomString(40),
+			Message:        helpers.RandomWords(10),
+			Author:         helpers.RandomWords(2),
+			Date:           date.Add(time.Duration(i) * time.Minute),
+			CommittedAt:    date.Add(time.Duration(i) * time.Minute),
+			URL:            helpers.RandomRepositoryUrl(),
+			RepositoryID:   repo.ID,
+			RepositoryName: repo.Name,
+		})
+	}
+	return commits
+}
+
+func TestSaveCommitsSkipsStoredCommits(t *testing.T) {
+	db := testDB(t)
+	store := postgres.NewPostgresGitCommitRepository(db)
+	repo := createRepo(t, db)
+
+	commits := randomCommits(repo, 3)
+
+	saved, err := store.SaveCommits(context.Background(), commits[:2])
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+
+	// stored and repeated commits are skipped
+	saved, err = store.SaveCommits(context.Background(), append(commits, commits[2]))
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	require.Equal(t, commits[2].CommitID, saved[0].CommitID)
+}
+
+func TestSaveCommitsSharesCommitsBetweenRepositories(t *testing.T) {
+	db := testDB(t)
+	store := postgres.NewPostgresGitCommitRepository(db)
+	upstream := createRepo(t, db)
+	fork := createRepo(t, db)
+
+	commits := randomCommits(upstream, 2)
+	_, err := store.SaveCommits(context.Background(), commits)
+	require.NoError(t, err)
+
+	forkCommits := append(randomCommits(fork, 1), commits...)
+	for i := range forkCommits {
+		forkCommits[i].RepositoryID = fork.ID
+		forkCommits[i].RepositoryName = fork.Name
+	}
+	saved, err := store.SaveCommits(context.Background(), forkCommits)
+	require.NoError(t, err)
+	require.Len(t, saved, 3)
+
+	stored, err := store.CommitsByRepository(context.Background(), fork)
+	require.NoError(t, err)
+	require.Len(t, stored, 3)
+	require.Equal(t, fork.Name, stored[0].RepositoryName)
+
+	repos, err := postgres.NewPostgresGitRepoMetadataRepository(db).RepoMetadataByCommitID(context.Background(), commits[0].CommitID)
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+}
+
+func TestApplyCommitDiffKeepsURLOfSharedCommit(t *testing.T) {
+	db := testDB(t)
+	store := postgres.NewPostgresGitCommitRepository(db)
+	upstream := createRepo(t, db)
+	fork := createRepo(t, db)
+
+	commits := randomCommits(upstream, 1)
+	_, err := store.SaveCommits(context.Background(), commits)
+	require.NoError(t, err)
+	shared := commits[0]
+	shared.RepositoryID = fork.ID
+	_, err = store.SaveCommits(context.Background(), []domain.Commit{shared})
+	require.NoError(t, err)
+
+	// the fork's reindex updates the shared commit but not the URL pointing at the upstream repository
+	changed := shared
+	changed.AuthorLogin = "jane"
+	changed.URL = helpers.RandomRepositoryUrl()
+	require.NoError(t, store.ApplyCommitDiff(context.Background(), fork, domain.CommitDiff{Changed: []domain.Commit{changed}}))
+
+	stored, err := store.GetByCommitID(context.Background(), shared.CommitID)
+	require.NoError(t, err)
+	require.Equal(t, "jane", stored.AuthorLogin)
+	require.Equal(t, commits[0].URL, stored.URL)
+}
+
+func TestRenamedRepositoryResolvesByPreviousName(t *testing.T) {
+	db := testDB(t)
+	store := postgres.NewPostgresGitRepoMetadataRepository(db)
+	repo := createRepo(t, db)
+
+	previousName := repo.Name
+	repo.Name = fmt.Sprintf("%s-renamed", previousName)
+	repo.ProviderID = 42
+	renamed, err := store.UpdateRepoIdentity(context.Background(), repo, previousName)
+	require.NoError(t, err)
+	require.Len(t, renamed.Aliases, 1)
+	require.Equal(t, domain.RepoAliasRenamed, renamed.Aliases[0].Kind)
+
+	resolved, err := store.RepoMetadataByName(context.Background(), previousName)
+	require.NoError(t, err)
+	require.Equal(t, repo.Name, resolved.Name)
+}
+
+func TestUntrackedRepositoryIsRememberedUntilAddedAgain(t *testing.T) {
+	db := testDB(t)
+	store := postgres.NewPostgresGitRepoMetadataRepository(db)
+	orgStore := postgres.NewPostgresOrganizationRepository(db)
+
+	repo, err := store.SaveRepoMetadata(context.Background(), domain.RepoMetadata{
+		PublicID:   uuid.New().String(),
+		Name:       fmt.Sprintf("%s-%s", helpers.RandomRepositoryName(), helpers.RandomString(6)),
+		ProviderID: time.Now().UnixNano(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteRepoMetadata(context.Background(), *repo, false))
+
+	untracked, err := orgStore.UntrackedProviderIDs(context.Background())
+	require.NoError(t, err)
+	require.True(t, untracked[repo.ProviderID])
+
+	added, err := store.SaveRepoMetadata(context.Background(), domain.RepoMetadata{PublicID: uuid.New().String(), Name: repo.Name, ProviderID: repo.ProviderID})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.DeleteRepoMetadata(context.Background(), *added, false))
+	})
+
+	untracked, err = orgStore.UntrackedProviderIDs(context.Background())
+	require.NoError(t, err)
+	require.False(t, untracked[repo.ProviderID])
+}
+
+func TestCommitsPendingChecksPollsEveryCommitInTurn(t *testing.T) {
+	db := testDB(t)
+	repo := createRepo(t, db)
+	checks := postgres.NewPostgresCheckRepository(db)
+
+	commits := randomCommits(repo, 3)
+	_, err := postgres.NewPostgresGitCommitRepository(db).SaveCommits(context.Background(), commits)
+	require.NoError(t, err)
+	ids := []string{commits[0].CommitID, commits[1].CommitID, commits[2].CommitID}
+	_, err = postgres.NewPostgresBranchRepository(db).SaveBranchCommits(context.Background(), repo, "main", ids)
+	require.NoError(t, err)
+
+	since := time.Now().Add(-time.Hour)
+	pending, err := checks.CommitsPendingChecks(context.Background(), repo, since, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{ids[2], ids[1]}, []string{pending[0].CommitID, pending[1].CommitID})
+
+	// the commits just polled wait for the oldest one
+	for _, c := range pending {
+		require.NoError(t, checks.SaveCheckRuns(context.Background(), repo, c.CommitID, nil, false))
+	}
+	pending, err = checks.CommitsPendingChecks(context.Background(), repo, since, 2)
+	require.NoError(t, err)
+	require.Equal(t, ids[0], pending[0].CommitID)
+}
+
+func TestPathStatsCountCommitsOncePerDirectory(t *testing.T) {
+	db := testDB(t)
+	repo := createRepo(t, db)
+	files := postgres.NewPostgresFileRepository(db)
+	enrichment := postgres.NewPostgresEnrichmentRepository(db)
+
+	commits := randomCommits(repo, 2)
+	_, err := postgres.NewPostgresGitCommitRepository(db).SaveCommits(context.Background(), commits)
+	require.NoError(t, err)
+	require.NoError(t, enrichment.SaveCommitDetail(context.Background(), commits[0].CommitID, domain.CommitDetail{Files: []domain.FileChange{
+		{CommitID: commits[0].CommitID, Path: "pkg/api/server.go", Additions: 10},
+		{CommitID: commits[0].CommitID, Path: "pkg/api/routes.go", Additions: 5},
+	}}))
+	require.NoError(t, enrichment.SaveCommitDetail(context.Background(), commits[1].CommitID, domain.CommitDetail{Files: []domain.FileChange{
+		{CommitID: commits[1].CommitID, Path: "README.md", Deletions: 2},
+	}}))
+
+	stats, err := files.PathStatsByRepository(context.Background(), repo, time.Time{}, time.Time{}, "")
+	require.NoError(t, err)
+	byPath := make(map[string]domain.Hotspot)
+	for _, h := range stats {
+		byPath[h.Path] = h
+	}
+	require.Len(t, byPath, 5)
+	require.Equal(t, 1, byPath["pkg/api/"].Changes)
+	require.Equal(t, 15, byPath["pkg/api/"].Churn)
+	require.True(t, byPath["pkg/"].IsDir)
+
+	// the root is counted for ownership
+	changes, err := files.AuthorChangesByRepository(context.Background(), repo, "", 0, time.Now())
+	require.NoError(t, err)
+	commitsByDir := make(map[string]int)
+	for _, c := range changes {
+		commitsByDir[c.Dir] += c.Commits
+	}
+	require.Equal(t, map[string]int{"": 2, "pkg": 1, "pkg/api": 1}, commitsByDir)
+}
+
+func TestFileRevisionsBySpansPagesAcrossRenames(t *testing.T) {
+	db := testDB(t)
+	repo := createRepo(t, db)
+	files := postgres.NewPostgresFileRepository(db)
+	enrichment := postgres.NewPostgresEnrichmentRepository(db)
+
+	// client.go is created, renamed to pkg/client.go, edited and then created again at its previous path
+	commits := randomCommits(repo, 4)
+	_, err := postgres.NewPostgresGitCommitRepository(db).SaveCommits(context.Background(), commits)
+	require.NoError(t, err)
+	changes := []domain.FileChange{
+		{Path: "client.go", Status: domain.FileAdded},
+		{Path: "pkg/client.go", PreviousPath: "client.go", Status: domain.FileRenamed},
+		{Path: "pkg/client.go", Status: domain.FileModified},
+		{Path: "client.go", Status: domain.FileAdded},
+	}
+	for i, change := range changes {
+		change.CommitID = commits[i].CommitID
+		require.NoError(t, enrichment.SaveCommitDetail(context.Background(), change.CommitID, domain.CommitDetail{Files: []domain.FileChange{change}}))
+	}
+
+	rename, err := files.LatestFileRename(context.Background(), repo, "pkg/client.go", time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, commits[1].CommitID, rename.Commit.CommitID)
+
+	spans := []domain.FilePathSpan{
+		{Path: "pkg/client.go", From: rename.Commit.CommittedAt},
+		{Path: "client.go", Until: rename.Commit.CommittedAt},
+	}
+	revisions, pagingInfo, err := files.FileRevisionsBySpans(context.Background(), repo, spans, domain.APIPagingData{Page: 2, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	require.Equal(t, commits[0].CommitID, revisions[0].Commit.CommitID)
+	require.Equal(t, int64(3), pagingInfo.TotalCount)
+	require.False(t, pagingInfo.HasNextPage)
+}
+
+func TestCommitsOfReleaseReadTheirFirstRelease(t *testing.T) {
+	db := testDB(t)
+	store := postgres.NewPostgresGitCommitRepository(db)
+	repo := createRepo(t, db)
+
+	commits := randomCommits(repo, 2)
+	_, err := store.SaveCommits(context.Background(), commits)
+	require.NoError(t, err)
+
+	releases := postgres.NewPostgresReleaseRepository(db)
+	err = releases.UpdateFirstReleases(context.Background(), repo, map[string]string{commits[0].CommitID: "v1.0.0"}, nil)
+	require.NoError(t, err)
+
+	released, _, err := store.AllCommitsByRepository(context.Background(), repo, domain.CommitFilter{Release: "v1.0.0"}, domain.APIPagingData{})
+	require.NoError(t, err)
+	require.Len(t, released, 1)
+	require.Equal(t, commits[0].CommitID, released[0].CommitID)
+	require.Equal(t, "v1.0.0", released[0].FirstReleasedIn)
+
+	all, _, err := store.AllCommitsByRepository(context.Background(), repo, domain.CommitFilter{}, domain.APIPagingData{})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+}
+
+func TestFailRunningReindexJobsReleasesTheirRepositories(t *testing.T) {
+	db := testDB(t)
+	repos := postgres.NewPostgresGitRepoMetadataRepository(db)
+	jobs := postgres.NewPostgresBackfillRepository(db)
+	repo := createRepo(t, db)
+
+	claimed, err := repos.ClaimFetching(context.Background(), repo.PublicID)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	_, err = jobs.SaveReindexJob(context.Background(), domain.ReindexJob{RepositoryID: repo.ID, Status: domain.ReindexStatusRunning})
+	require.NoError(t, err)
+
+	// the reindex was interrupted by a crash, its repository is fetched again once it is failed
+	require.NoError(t, jobs.FailRunningReindexJobs(context.Background(), "reindex interrupted by a restart"))
+
+	stored, err := repos.RepoMetadataByPublicId(context.Background(), repo.PublicID)
+	require.NoError(t, err)
+	require.False(t, stored.IsFetching)
+
+	job, err := jobs.LatestReindexJob(context.Background(), repo)
+	require.NoError(t, err)
+	require.Equal(t, domain.ReindexStatusFailed, job.Status)
+}
+
+func TestMigrateTrackingSettingsOfLegacyRepositories(t *testing.T) {
+	db := testDB(t)
+	store := postgres.NewPostgresGitRepoMetadataRepository(db)
+	legacy := createRepo(t, db)
+
+	// a repository added with its full history is stored with a fetch interval and a page size
+	fullHistory := createRepo(t, db)
+	fullHistory.FetchInterval, fullHistory.CommitsPerPage = time.Hour, 50
+	_, err := store.UpdateTrackingSettings(context.Background(), fullHistory)
+	require.NoError(t, err)
+
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, postgres.MigrateTrackingSettings(db, since, time.Time{}, time.Hour, 50))
+
+	migrated, err := store.RepoMetadataByPublicId(context.Background(), legacy.PublicID)
+	require.NoError(t, err)
+	require.NotNil(t, migrated.TrackSince)
+	require.True(t, since.Equal(*migrated.TrackSince))
+	require.Nil(t, migrated.TrackUntil)
+
+	kept, err := store.RepoMetadataByPublicId(context.Background(), fullHistory.PublicID)
+	require.NoError(t, err)
+	require.Nil(t, kept.TrackSince)
+}
+
+func BenchmarkSaveCommit(b *testing.B) {
+	benchmarkIngestion(b, func(ctx context.Context, store repository.CommitRepository, commits []domain.Commit) {
+		for _, c := range commits {
+			if _, err := store.GetByCommitID(ctx, c.CommitID); err == nil {
+				continue
+			}
+			if _, err := store.SaveCommit(ctx, c); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkSaveCommits(b *testing.B) {
+	benchmarkIngestion(b, func(ctx context.Context, store repository.CommitRepository, commits []domain.Commit) {
+		// ingest in pages as the indexing loops do
+		for start := 0; start < len(commits); start += 100 {
+			if _, err := store.SaveCommits(ctx, commits[start:min(start+100, len(commits))]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// benchmarkIngestion measures the ingestion of a repository of benchmarkRepoSize commits
+func benchmarkIngestion(b *testing.B, ingest func(context.Context, repository.CommitRepository, []domain.Commit)) {
+	db := testDB(b)
+	store := postgres.NewPostgresGitCommitRepository(db)
+	repoStore := postgres.NewPostgresGitRepoMetadataRepository(db)
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		repo, err := repoStore.SaveRepoMetadata(context.Background(), domain.RepoMetadata{
+			PublicID: uuid.New().String(),
+			Name:     fmt.Sprintf("bench/%s", helpers.RandomString(10)),
+		})
+		require.NoError(b, err)
+		commits := randomCommits(*repo, benchmarkRepoSize)
+		b.StartTimer()
+
+		ingest(context.Background(), store, commits)
+
+		b.StopTimer()
+		require.NoError(b, repoStore.DeleteRepoMetadata(context.Background(), *repo, false))
+		b.StartTimer()
+	}
+
+	b.ReportMetric(float64(benchmarkRepoSize*b.N)/b.Elapsed().Seconds(), "commits/s")
+}
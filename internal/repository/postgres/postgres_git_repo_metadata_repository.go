@@ -2,12 +2,14 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/kenmobility/git-api-service/internal/domain"
 	"github.com/kenmobility/git-api-service/internal/repository"
 	"github.com/kenmobility/git-api-service/pkg/message"
 	"github.com/rs/zerolog/log"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresGitRepoMetadataRepository struct {
@@ -21,7 +23,16 @@ func NewPostgresGitRepoMetadataRepository(db *gorm.DB) repository.RepoMetadataRe
 func (r *PostgresGitRepoMetadataRepository) SaveRepoMetadata(ctx context.Context, repo domain.RepoMetadata) (*domain.RepoMetadata, error) {
 	dbRepository := FromDomainRepo(&repo)
 
-	err := r.DB.WithContext(ctx).Create(dbRepository).Error
+	// a repository added again is no longer remembered as untracked
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dbRepository).Error; err != nil {
+			return err
+		}
+		if repo.ProviderID == 0 {
+			return nil
+		}
+		return tx.Where("provider_id = ?", repo.ProviderID).Delete(&UntrackedRepository{}).Error
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +46,7 @@ func (r *PostgresGitRepoMetadataRepository) RepoMetadataByPublicId(ctx context.C
 	}
 
 	var repo Repository
-	err := r.DB.WithContext(ctx).Where("public_id = ?", publicId).Find(&repo).Error
+	err := r.DB.WithContext(ctx).Preload("Aliases").Where("public_id = ?", publicId).Find(&repo).Error
 
 	if repo.ID == 0 {
 		return nil, message.ErrNoRecordFound
@@ -48,17 +59,110 @@ func (r *PostgresGitRepoMetadataRepository) RepoMetadataByName(ctx context.Conte
 		return nil, message.ErrContextCancelled
 	}
 	var repo Repository
-	err := r.DB.WithContext(ctx).Where("name = ?", name).Find(&repo).Error
+	err := r.DB.WithContext(ctx).Preload("Aliases").Where("name = ?", name).Find(&repo).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// a previous name resolves to the repository it was most recently an alias of
+	if repo.ID == 0 {
+		err = r.DB.WithContext(ctx).Preload("Aliases").
+			Joins("JOIN repository_aliases ON repository_aliases.repository_id = repositories.id").
+			Where("repository_aliases.name = ?", name).
+			Order("repository_aliases.created_at DESC").
+			Limit(1).
+			Find(&repo).Error
+	}
+
+	if repo.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	return repo.ToDomain(), err
+}
+
+// RepoMetadataByProviderID fetches a repository using its identifier at the git provider
+func (r *PostgresGitRepoMetadataRepository) RepoMetadataByProviderID(ctx context.Context, providerID int64) (*domain.RepoMetadata, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var repo Repository
+	err := r.DB.WithContext(ctx).Preload("Aliases").Where("provider_id = ?", providerID).Find(&repo).Error
 	if repo.ID == 0 {
 		return nil, message.ErrNoRecordFound
 	}
 	return repo.ToDomain(), err
 }
 
+// RepoMetadataByCommitID fetches the tracked repositories containing a commit
+func (r *PostgresGitRepoMetadataRepository) RepoMetadataByCommitID(ctx context.Context, commitID string) ([]domain.RepoMetadata, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbRepositories []Repository
+	err := r.DB.WithContext(ctx).Preload("Aliases").
+		Joins("JOIN repository_commits ON repository_commits.repository_id = repositories.id").
+		Where("repository_commits.commit_id = ?", commitID).
+		Order("repositories.name").
+		Find(&dbRepositories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	repos := make([]domain.RepoMetadata, 0, len(dbRepositories))
+	for _, dbRepository := range dbRepositories {
+		repos = append(repos, *dbRepository.ToDomain())
+	}
+	return repos, nil
+}
+
+// UpdateRepoIdentity persists the provider id, name and url of a repository, keeping previousName
+// as an alias when the repository was renamed or transferred upstream
+func (r *PostgresGitRepoMetadataRepository) UpdateRepoIdentity(ctx context.Context, repo domain.RepoMetadata, previousName string) (*domain.RepoMetadata, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	err := r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if previousName != repo.Name {
+			alias := domain.NewRepoAlias(previousName, repo.Name)
+			err := tx.Create(&RepositoryAlias{
+				RepositoryID: repo.ID,
+				Name:         alias.Name,
+				Kind:         alias.Kind,
+				CreatedAt:    alias.CreatedAt,
+			}).Error
+			if err != nil {
+				return err
+			}
+
+			// a repository moving back to a previous name no longer has it as an alias
+			if err := tx.Where("repository_id = ? AND name = ?", repo.ID, repo.Name).Delete(&RepositoryAlias{}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&Repository{}).
+			Where("id = ?", repo.ID).
+			Updates(map[string]interface{}{
+				"provider_id": repo.ProviderID,
+				"name":        repo.Name,
+				"url":         repo.URL,
+			}).Error
+	})
+	if err != nil {
+		log.Error().Msgf("Persistence::UpdateRepoIdentity error: %v", err)
+		return nil, err
+	}
+
+	return r.RepoMetadataByPublicId(ctx, repo.PublicID)
+}
+
 func (r *PostgresGitRepoMetadataRepository) AllRepoMetadata(ctx context.Context) ([]domain.RepoMetadata, error) {
 	var dbRepositories []Repository
 
-	err := r.DB.WithContext(ctx).Find(&dbRepositories).Error
+	err := r.DB.WithContext(ctx).Preload("Aliases").Find(&dbRepositories).Error
 
 	if err != nil {
 		return nil, err
@@ -93,3 +197,252 @@ func (r *PostgresGitRepoMetadataRepository) UpdateFetchingStateForAllRepos(ctx c
 		Update("is_fetching", isFetching).
 		Error
 }
+
+// UpdateTrackingSettings persists the tracking window, branch, fetch interval and page size of a repository,
+// including cleared values which a struct update would skip
+func (r *PostgresGitRepoMetadataRepository) UpdateTrackingSettings(ctx context.Context, repo domain.RepoMetadata) (*domain.RepoMetadata, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+	dbRepo := FromDomainRepo(&repo)
+
+	err := r.DB.WithContext(ctx).Model(&Repository{}).
+		Where("public_id = ?", repo.PublicID).
+		Select("track_since", "track_until", "branch", "tracked_branches", "fetch_interval", "commits_per_page").
+		Updates(dbRepo).Error
+	if err != nil {
+		log.Error().Msgf("Persistence::UpdateTrackingSettings error: %v, (%v)", err.Error(), err.Error())
+		return nil, err
+	}
+
+	return dbRepo.ToDomain(), nil
+}
+
+// UpdateRepoStats persists the description, language, counts and lifecycle fields of a repository refreshed from the git provider,
+// including counts dropping to zero which a struct update would skip
+func (r *PostgresGitRepoMetadataRepository) UpdateRepoStats(ctx context.Context, repo domain.RepoMetadata) (*domain.RepoMetadata, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+	dbRepo := FromDomainRepo(&repo)
+
+	err := r.DB.WithContext(ctx).Model(&Repository{}).
+		Where("id = ?", repo.ID).
+		Select("description", "language", "forks_count", "stars_count", "open_issues_count", "watchers_count",
+			"default_branch", "archived", "disabled", "visibility", "pushed_at", "updated_at").
+		Updates(dbRepo).Error
+	if err != nil {
+		log.Error().Msgf("Persistence::UpdateRepoStats error: %v, (%v)", err.Error(), err.Error())
+		return nil, err
+	}
+
+	return r.RepoMetadataByPublicId(ctx, repo.PublicID)
+}
+
+// UpdateFetchingState persists whether the commits of a repository are being fetched
+func (r *PostgresGitRepoMetadataRepository) UpdateFetchingState(ctx context.Context, publicId string, isFetching bool) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return r.DB.WithContext(ctx).Model(&Repository{}).
+		Where("public_id = ?", publicId).
+		Update("is_fetching", isFetching).
+		Error
+}
+
+// ClaimFetching sets the fetching flag of a repository unless it is already set, in a single statement so that
+// concurrent callers cannot both claim it, it reports whether the repository was claimed
+func (r *PostgresGitRepoMetadataRepository) ClaimFetching(ctx context.Context, publicId string) (bool, error) {
+	if ctx.Err() == context.Canceled {
+		return false, message.ErrContextCancelled
+	}
+
+	result := r.DB.WithContext(ctx).Model(&Repository{}).
+		Where("public_id = ? AND is_fetching = ?", publicId, false).
+		Update("is_fetching", true)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// UpdatePausedState persists whether the monitoring of a repository is paused
+func (r *PostgresGitRepoMetadataRepository) UpdatePausedState(ctx context.Context, publicId string, paused bool) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return r.DB.WithContext(ctx).Model(&Repository{}).
+		Where("public_id = ?", publicId).
+		Update("paused", paused).
+		Error
+}
+
+// UpdateIdentitiesPending sets whether the commits of a repository await a reindex to record their author identities
+func (r *PostgresGitRepoMetadataRepository) UpdateIdentitiesPending(ctx context.Context, publicId string, pending bool) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return r.DB.WithContext(ctx).Model(&Repository{}).
+		Where("public_id = ?", publicId).
+		Update("identities_pending", pending).
+		Error
+}
+
+// UpdateMailmapETag persists the ETag of the .mailmap file last applied to the contributors of a repository
+func (r *PostgresGitRepoMetadataRepository) UpdateMailmapETag(ctx context.Context, publicId string, etag string) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return r.DB.WithContext(ctx).Model(&Repository{}).
+		Where("public_id = ?", publicId).
+		Update("mailmap_etag", etag).
+		Error
+}
+
+// UpdateUpstreamState persists the upstream lifecycle state of a repository and when it changed
+func (r *PostgresGitRepoMetadataRepository) UpdateUpstreamState(ctx context.Context, publicId string, state string) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return r.DB.WithContext(ctx).Model(&Repository{}).
+		Where("public_id = ?", publicId).
+		Updates(map[string]interface{}{"upstream_state": state, "upstream_state_changed_at": time.Now()}).
+		Error
+}
+
+// DeleteRepoMetadata removes a repository and its commits in a single transaction, when retainCommits
+// is true the commits are moved to the archived_commits table instead of being discarded
+func (r *PostgresGitRepoMetadataRepository) DeleteRepoMetadata(ctx context.Context, repo domain.RepoMetadata, retainCommits bool) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if retainCommits {
+			err := tx.Exec(`INSERT INTO archived_commits (commit_id, message, author, author_email, author_login, date, committer, committer_email,
+					committer_login, committed_at, url, repository_name, parent_shas, created_at, updated_at, archived_at)
+				SELECT c.commit_id, c.message, c.author, c.author_email, c.author_login, c.date, c.committer, c.committer_email,
+					c.committer_login, c.committed_at, c.url, ?, c.parent_shas, c.created_at, c.updated_at, ?
+				FROM commits c JOIN repository_commits rc ON rc.commit_id = c.commit_id WHERE rc.repository_id = ?`,
+				repo.Name, time.Now(), repo.ID).Error
+			if err != nil {
+				log.Error().Msgf("Persistence::DeleteRepoMetadata archive commits error: %v", err)
+				return err
+			}
+		}
+
+		// commits shared with another tracked repository are kept
+		err := tx.Where("commit_id IN (?)", tx.Model(&RepositoryCommit{}).Select("commit_id").Where("repository_id = ?", repo.ID)).
+			Where("NOT EXISTS (SELECT 1 FROM repository_commits WHERE repository_commits.commit_id = commits.commit_id AND repository_commits.repository_id <> ?)", repo.ID).
+			Delete(&Commit{}).Error
+		if err != nil {
+			log.Error().Msgf("Persistence::DeleteRepoMetadata delete commits error: %v", err)
+			return err
+		}
+
+		err = tx.Where("commit_id IN (?)", tx.Model(&RepositoryCommit{}).Select("commit_id").Where("repository_id = ?", repo.ID)).
+			Where("NOT EXISTS (SELECT 1 FROM commits WHERE commits.commit_id = commit_files.commit_id)").
+			Delete(&CommitFile{}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&RepositoryCommit{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&RepositoryAlias{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&SyncRange{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&BackfillJob{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&ReindexJob{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&SyncCursor{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&MetadataSnapshot{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&Stargazer{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&BranchCommit{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&Branch{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&Release{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&Tag{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&PullRequestCommit{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&PullRequestSync{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&PullRequest{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&CommitReference{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&Issue{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&CheckRun{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&EnrichmentState{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("repository_id = ?", repo.ID).Delete(&IntegrityReport{}).Error; err != nil {
+			return err
+		}
+
+		// watched organizations and users do not add an untracked repository back
+		if repo.ProviderID != 0 {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "provider_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"name", "untracked_at"}),
+			}).Create(&UntrackedRepository{ProviderID: repo.ProviderID, Name: repo.Name, UntrackedAt: time.Now()}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Where("public_id = ?", repo.PublicID).Delete(&Repository{}).Error
+	})
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// IntegrityReport represents the Postgres model for the integrity_reports table, Buckets and
+// MissingRanges hold JSON encoded lists and OrphanedCommits comma separated commit shas.
+type IntegrityReport struct {
+	ID              uint `gorm:"primaryKey"`
+	RepositoryID    uint `gorm:"index"`
+	Completeness    float64
+	StoredCount     int
+	ProviderCount   int
+	Buckets         string `gorm:"type:text"`
+	MissingRanges   string `gorm:"type:text"`
+	OrphanedCommits string `gorm:"type:text"`
+	RefetchJobs     int
+	VerifiedAt      time.Time `gorm:"index"`
+	CreatedAt       time.Time
+}
+
+// ToDomain converts a Postgres IntegrityReport object to domain entity IntegrityReport.
+func (pr *IntegrityReport) ToDomain() (*domain.IntegrityReport, error) {
+	report := &domain.IntegrityReport{
+		RepositoryID:    pr.RepositoryID,
+		Completeness:    pr.Completeness,
+		StoredCount:     pr.StoredCount,
+		ProviderCount:   pr.ProviderCount,
+		OrphanedCommits: splitSHAs(pr.OrphanedCommits),
+		RefetchJobs:     pr.RefetchJobs,
+		VerifiedAt:      pr.VerifiedAt,
+	}
+
+	if pr.Buckets != "" {
+		if err := json.Unmarshal([]byte(pr.Buckets), &report.Buckets); err != nil {
+			return nil, err
+		}
+	}
+	if pr.MissingRanges != "" {
+		if err := json.Unmarshal([]byte(pr.MissingRanges), &report.MissingRanges); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// FromDomainIntegrityReport returns a Postgres IntegrityReport object from domain entity IntegrityReport.
+func FromDomainIntegrityReport(r *domain.IntegrityReport) (*IntegrityReport, error) {
+	buckets, err := json.Marshal(r.Buckets)
+	if err != nil {
+		return nil, err
+	}
+	missingRanges, err := json.Marshal(r.MissingRanges)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IntegrityReport{
+		RepositoryID:    r.RepositoryID,
+		Completeness:    r.Completeness,
+		StoredCount:     r.StoredCount,
+		ProviderCount:   r.ProviderCount,
+		Buckets:         string(buckets),
+		MissingRanges:   string(missingRanges),
+		OrphanedCommits: strings.Join(r.OrphanedCommits, ","),
+		RefetchJobs:     r.RefetchJobs,
+		VerifiedAt:      r.VerifiedAt,
+	}, nil
+}
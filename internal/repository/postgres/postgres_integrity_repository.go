@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+)
+
+type PostgresIntegrityRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresIntegrityRepository(db *gorm.DB) repository.IntegrityRepository {
+	return &PostgresIntegrityRepository{DB: db}
+}
+
+// SaveIntegrityReport stores the result of a history verification of a repository
+func (i *PostgresIntegrityRepository) SaveIntegrityReport(ctx context.Context, report domain.IntegrityReport) (*domain.IntegrityReport, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	dbReport, err := FromDomainIntegrityReport(&report)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := i.DB.WithContext(ctx).Create(dbReport).Error; err != nil {
+		return nil, err
+	}
+	return dbReport.ToDomain()
+}
+
+// LatestIntegrityReport fetches the most recent history verification of a repository
+func (i *PostgresIntegrityRepository) LatestIntegrityReport(ctx context.Context, repo domain.RepoMetadata) (*domain.IntegrityReport, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var report IntegrityReport
+	err := i.DB.WithContext(ctx).Where("repository_id = ?", repo.ID).Order("verified_at DESC").Limit(1).Find(&report).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if report.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	return report.ToDomain()
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// Issue represents the Postgres model for the issues table, it holds an issue of a repository.
+// CreatedAt and UpdatedAt are the dates the issue was opened and last updated at the git provider.
+type Issue struct {
+	ID           uint   `gorm:"primaryKey"`
+	RepositoryID uint   `gorm:"uniqueIndex:idx_issues_repository_id_number"`
+	Number       int    `gorm:"uniqueIndex:idx_issues_repository_id_number"`
+	Title        string `gorm:"type:varchar"`
+	Author       string `gorm:"type:varchar(100)"`
+	State        string `gorm:"type:varchar(20);index"`
+	Labels       string `gorm:"type:text"`
+	Assignees    string `gorm:"type:text"`
+	URL          string `gorm:"type:varchar"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time `gorm:"index"`
+	ClosedAt     *time.Time
+}
+
+// CommitReference represents the Postgres model for the commit_references table, it holds a reference
+// to an issue or a ticket found in the message of a commit of a repository.
+type CommitReference struct {
+	ID           uint   `gorm:"primaryKey"`
+	RepositoryID uint   `gorm:"uniqueIndex:idx_commit_references_repository_id_commit_id_kind_key"`
+	CommitID     string `gorm:"type:varchar(40);uniqueIndex:idx_commit_references_repository_id_commit_id_kind_key;index"`
+	Kind         string `gorm:"type:varchar(20);uniqueIndex:idx_commit_references_repository_id_commit_id_kind_key"`
+	Key          string `gorm:"type:varchar;uniqueIndex:idx_commit_references_repository_id_commit_id_kind_key"`
+	IssueNumber  int    `gorm:"index"`
+	Closing      bool
+	// RepositoryName is not a column, it is read from the repository a reference is queried through
+	RepositoryName string `gorm:"->;-:migration"`
+	CreatedAt      time.Time
+}
+
+// TicketPatternSet represents the Postgres model for the ticket_pattern_sets table, its single row holds the
+// hash of the ticket patterns the commit messages were parsed with.
+type TicketPatternSet struct {
+	ID        uint   `gorm:"primaryKey"`
+	Hash      string `gorm:"type:varchar(64)"`
+	Patterns  string `gorm:"type:text"`
+	UpdatedAt time.Time
+}
+
+// ToDomain converts a Postgres Issue object to domain entity Issue.
+func (pi *Issue) ToDomain() *domain.Issue {
+	return &domain.Issue{
+		RepositoryID: pi.RepositoryID,
+		Number:       pi.Number,
+		Title:        pi.Title,
+		Author:       pi.Author,
+		State:        pi.State,
+		Labels:       splitLines(pi.Labels),
+		Assignees:    splitLines(pi.Assignees),
+		URL:          pi.URL,
+		CreatedAt:    pi.CreatedAt,
+		UpdatedAt:    pi.UpdatedAt,
+		ClosedAt:     pi.ClosedAt,
+	}
+}
+
+// FromDomainIssue returns a Postgres Issue object from domain entity Issue.
+func FromDomainIssue(i *domain.Issue) *Issue {
+	return &Issue{
+		RepositoryID: i.RepositoryID,
+		Number:       i.Number,
+		Title:        i.Title,
+		Author:       i.Author,
+		State:        i.State,
+		Labels:       strings.Join(i.Labels, "\n"),
+		Assignees:    strings.Join(i.Assignees, "\n"),
+		URL:          i.URL,
+		CreatedAt:    i.CreatedAt,
+		UpdatedAt:    i.UpdatedAt,
+		ClosedAt:     i.ClosedAt,
+	}
+}
+
+// ToDomain converts a Postgres CommitReference object to domain entity CommitReference.
+func (pr *CommitReference) ToDomain() *domain.CommitReference {
+	return &domain.CommitReference{
+		RepositoryID:   pr.RepositoryID,
+		RepositoryName: pr.RepositoryName,
+		CommitID:       pr.CommitID,
+		Kind:           pr.Kind,
+		Key:            pr.Key,
+		IssueNumber:    pr.IssueNumber,
+		Closing:        pr.Closing,
+	}
+}
@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresIssueRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresIssueRepository(db *gorm.DB) repository.IssueRepository {
+	return &PostgresIssueRepository{DB: db}
+}
+
+// SaveIssues stores the issues of a repository, updating the issues already stored
+func (p *PostgresIssueRepository) SaveIssues(ctx context.Context, issues []domain.Issue) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+
+	dbIssues := make([]*Issue, 0, len(issues))
+	for i := range issues {
+		dbIssues = append(dbIssues, FromDomainIssue(&issues[i]))
+	}
+	return p.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "repository_id"}, {Name: "number"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "author", "state", "labels", "assignees", "url", "updated_at", "closed_at"}),
+	}).CreateInBatches(dbIssues, commitInsertBatchSize).Error
+}
+
+// LatestIssueUpdate returns the latest update date of the stored issues of a repository, nil when none is stored
+func (p *PostgresIssueRepository) LatestIssueUpdate(ctx context.Context, repo domain.RepoMetadata) (*time.Time, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var latest *time.Time
+	err := p.DB.WithContext(ctx).Model(&Issue{}).
+		Select("max(updated_at)").
+		Where("repository_id = ?", repo.ID).
+		Scan(&latest).Error
+	if err != nil {
+		return nil, err
+	}
+	return latest, nil
+}
+
+// IssueByNumber fetches an issue of a repository by its number
+func (p *PostgresIssueRepository) IssueByNumber(ctx context.Context, repo domain.RepoMetadata, number int) (*domain.Issue, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var issue Issue
+	err := p.DB.WithContext(ctx).
+		Where("repository_id = ? AND number = ?", repo.ID, number).
+		Find(&issue).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if issue.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	return issue.ToDomain(), nil
+}
+
+// UnparsedCommits fetches at most limit commits of a repository whose message was not parsed for references yet, oldest first
+func (p *PostgresIssueRepository) UnparsedCommits(ctx context.Context, repo domain.RepoMetadata, limit int) ([]domain.Commit, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbCommits []Commit
+	err := p.DB.WithContext(ctx).Model(&Commit{}).
+		Select(repositoryCommitColumns).
+		Joins("JOIN repository_commits ON repository_commits.commit_id = commits.commit_id").
+		Joins("JOIN repositories ON repositories.id = repository_commits.repository_id").
+		Where("repository_commits.repository_id = ? AND NOT repository_commits.references_parsed", repo.ID).
+		Order("repository_commits.id ASC").
+		Limit(limit).
+		Find(&dbCommits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return domainCommits(dbCommits), nil
+}
+
+// SaveCommitReferences stores the references found in the messages of commits of a repository, skipping the
+// references already stored, and marks the commits parsed in a single transaction
+func (p *PostgresIssueRepository) SaveCommitReferences(ctx context.Context, repo domain.RepoMetadata, commitIDs []string, refs []domain.CommitReference) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+	if len(commitIDs) == 0 {
+		return nil
+	}
+
+	return p.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for start := 0; start < len(refs); start += commitInsertBatchSize {
+			end := min(start+commitInsertBatchSize, len(refs))
+
+			var sb strings.Builder
+			sb.WriteString(`INSERT INTO commit_references (repository_id, commit_id, kind, key, issue_number, closing, created_at) VALUES `)
+			args := make([]interface{}, 0, (end-start)*7)
+			for i, ref := range refs[start:end] {
+				if i > 0 {
+					sb.WriteString(",")
+				}
+				sb.WriteString("(?, ?, ?, ?, ?, ?, ?)")
+				args = append(args, repo.ID, ref.CommitID, ref.Kind, ref.Key, ref.IssueNumber, ref.Closing, now)
+			}
+			sb.WriteString(" ON CONFLICT DO NOTHING")
+
+			if err := tx.Exec(sb.String(), args...).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&RepositoryCommit{}).
+			Where("repository_id = ? AND commit_id IN ?", repo.ID, commitIDs).
+			Update("references_parsed", true).Error
+	})
+}
+
+// CommitsByIssue fetches the commits of a repository referencing one of its issues, oldest first
+func (p *PostgresIssueRepository) CommitsByIssue(ctx context.Context, repo domain.RepoMetadata, number int) ([]domain.ReferencingCommit, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var rows []struct {
+		Commit
+		Closing bool
+	}
+	err := p.DB.WithContext(ctx).Model(&Commit{}).
+		Select(repositoryCommitColumns+", commit_references.closing").
+		Joins("JOIN repository_commits ON repository_commits.commit_id = commits.commit_id").
+		Joins("JOIN repositories ON repositories.id = repository_commits.repository_id").
+		Joins("JOIN commit_references ON commit_references.repository_id = repository_commits.repository_id AND commit_references.commit_id = commits.commit_id").
+		Where("repository_commits.repository_id = ? AND commit_references.kind = ? AND commit_references.issue_number = ?", repo.ID, domain.ReferenceIssue, number).
+		Order("commits.committed_at ASC, commits.date ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]domain.ReferencingCommit, 0, len(rows))
+	for _, r := range rows {
+		commits = append(commits, domain.ReferencingCommit{Commit: *r.Commit.ToDomain(), Closing: r.Closing})
+	}
+	return commits, nil
+}
+
+// ReferencesByCommitID fetches the references found in the message of a commit in every repository containing it
+func (p *PostgresIssueRepository) ReferencesByCommitID(ctx context.Context, commitID string) ([]domain.CommitReference, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbRefs []CommitReference
+	err := p.DB.WithContext(ctx).Model(&CommitReference{}).
+		Select("commit_references.*, repositories.name AS repository_name").
+		Joins("JOIN repositories ON repositories.id = commit_references.repository_id").
+		Where("commit_references.commit_id = ?", commitID).
+		Order("repositories.name ASC, commit_references.id ASC").
+		Find(&dbRefs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]domain.CommitReference, 0, len(dbRefs))
+	for _, r := range dbRefs {
+		refs = append(refs, *r.ToDomain())
+	}
+	return refs, nil
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// MetadataSnapshot represents the Postgres model for the metadata_snapshots table, it holds
+// the provider metadata of a repository captured by a metadata refresh.
+type MetadataSnapshot struct {
+	ID              uint `gorm:"primaryKey"`
+	RepositoryID    uint `gorm:"index:idx_metadata_snapshots_repository_id_captured_at"`
+	StarsCount      int
+	ForksCount      int
+	WatchersCount   int
+	OpenIssuesCount int
+	Language        string    `gorm:"type:varchar"`
+	CapturedAt      time.Time `gorm:"index:idx_metadata_snapshots_repository_id_captured_at"`
+}
+
+// ToDomain converts a Postgres MetadataSnapshot object to domain entity RepoMetadataSnapshot.
+func (ps *MetadataSnapshot) ToDomain() *domain.RepoMetadataSnapshot {
+	return &domain.RepoMetadataSnapshot{
+		RepositoryID:    ps.RepositoryID,
+		StarsCount:      ps.StarsCount,
+		ForksCount:      ps.ForksCount,
+		WatchersCount:   ps.WatchersCount,
+		OpenIssuesCount: ps.OpenIssuesCount,
+		Language:        ps.Language,
+		CapturedAt:      ps.CapturedAt,
+	}
+}
+
+// FromDomainMetadataSnapshot returns a Postgres MetadataSnapshot object from domain entity RepoMetadataSnapshot.
+func FromDomainMetadataSnapshot(s *domain.RepoMetadataSnapshot) *MetadataSnapshot {
+	return &MetadataSnapshot{
+		RepositoryID:    s.RepositoryID,
+		StarsCount:      s.StarsCount,
+		ForksCount:      s.ForksCount,
+		WatchersCount:   s.WatchersCount,
+		OpenIssuesCount: s.OpenIssuesCount,
+		Language:        s.Language,
+		CapturedAt:      s.CapturedAt,
+	}
+}
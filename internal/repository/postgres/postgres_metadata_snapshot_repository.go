@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+)
+
+type PostgresMetadataSnapshotRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresMetadataSnapshotRepository(db *gorm.DB) repository.MetadataSnapshotRepository {
+	return &PostgresMetadataSnapshotRepository{DB: db}
+}
+
+// SaveMetadataSnapshot appends a snapshot to the metadata history of a repository
+func (s *PostgresMetadataSnapshotRepository) SaveMetadataSnapshot(ctx context.Context, snapshot domain.RepoMetadataSnapshot) (*domain.RepoMetadataSnapshot, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	dbSnapshot := FromDomainMetadataSnapshot(&snapshot)
+	if err := s.DB.WithContext(ctx).Create(dbSnapshot).Error; err != nil {
+		return nil, err
+	}
+	return dbSnapshot.ToDomain(), nil
+}
+
+// MetadataSnapshots fetches the metadata snapshots of a repository captured in [from, to), oldest first,
+// a zero from or to leaves the range unbounded on that side
+func (s *PostgresMetadataSnapshotRepository) MetadataSnapshots(ctx context.Context, repo domain.RepoMetadata, from time.Time, to time.Time) ([]domain.RepoMetadataSnapshot, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	query := s.DB.WithContext(ctx).Where("repository_id = ?", repo.ID)
+	if !from.IsZero() {
+		query = query.Where("captured_at >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("captured_at < ?", to)
+	}
+
+	var dbSnapshots []MetadataSnapshot
+	if err := query.Order("captured_at ASC").Find(&dbSnapshots).Error; err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]domain.RepoMetadataSnapshot, 0, len(dbSnapshots))
+	for _, ps := range dbSnapshots {
+		snapshots = append(snapshots, *ps.ToDomain())
+	}
+	return snapshots, nil
+}
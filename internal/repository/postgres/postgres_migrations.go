@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// MigrateRepositoryCommits links the commits stored before commits were shared between repositories, or before
+// they were linked by repository id, to their repository in the repository_commits table, then drops the
+// repository name columns the links were previously kept in
+func MigrateRepositoryCommits(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if tx.Migrator().HasColumn(&Commit{}, "repository_name") {
+			log.Info().Msg("linking stored commits to their repositories")
+			err := tx.Exec(`INSERT INTO repository_commits (repository_id, commit_id, created_at)
+				SELECT r.id, c.commit_id, c.created_at FROM commits c JOIN repositories r ON r.name = c.repository_name
+				ON CONFLICT DO NOTHING`).Error
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE commits DROP COLUMN repository_name`).Error; err != nil {
+				return err
+			}
+		}
+
+		if tx.Migrator().HasColumn(&RepositoryCommit{}, "repository_name") {
+			log.Info().Msg("linking repository commits by repository id")
+			err := tx.Exec(`UPDATE repository_commits SET repository_id = r.id FROM repositories r
+				WHERE r.name = repository_commits.repository_name AND repository_commits.repository_id IS NULL`).Error
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DELETE FROM repository_commits WHERE repository_id IS NULL`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE repository_commits DROP COLUMN repository_name`).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// MigrateTrackingSettings gives the repositories added before tracking settings were stored the window they were
+// indexed with, from the default start date up to the default end date, open ended without one. Such repositories
+// are told apart by their missing fetch interval and page size, which every repository added since is stored with,
+// so a repository switched to its full history keeps it.
+func MigrateTrackingSettings(db *gorm.DB, since, until time.Time, fetchInterval time.Duration, perPage int) error {
+	updates := map[string]interface{}{
+		"track_since":      since,
+		"fetch_interval":   fetchInterval,
+		"commits_per_page": perPage,
+	}
+	if !until.IsZero() {
+		updates["track_until"] = until
+	}
+
+	result := db.Model(&Repository{}).
+		Where("track_since IS NULL AND COALESCE(fetch_interval, 0) = 0 AND COALESCE(commits_per_page, 0) = 0").
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Info().Msgf("set the tracking window of %d repositories added before tracking settings were stored", result.RowsAffected)
+	}
+	return nil
+}
+
+// MigrateTicketPatterns has the messages of every stored commit parsed again for references when the ticket patterns
+// differ from those they were parsed with, dropping the ticket references the previous patterns found. Databases
+// parsed before the patterns were recorded are parsed again once.
+func MigrateTicketPatterns(db *gorm.DB, patterns []*regexp.Regexp) error {
+	sources := make([]string, 0, len(patterns))
+	for _, re := range patterns {
+		sources = append(sources, re.String())
+	}
+	joined := strings.Join(sources, "\n")
+	sum := sha256.Sum256([]byte(joined))
+	hash := hex.EncodeToString(sum[:])
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var set TicketPatternSet
+		if err := tx.Find(&set).Error; err != nil {
+			return err
+		}
+		if set.ID != 0 && set.Hash == hash {
+			return nil
+		}
+
+		log.Info().Msgf("ticket patterns changed to [%s], parsing the stored commits again", strings.Join(sources, " "))
+		if err := tx.Where("kind = ?", domain.ReferenceTicket).Delete(&CommitReference{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`UPDATE repository_commits SET references_parsed = false WHERE references_parsed`).Error; err != nil {
+			return err
+		}
+
+		set.Hash = hash
+		set.Patterns = joined
+		return tx.Save(&set).Error
+	})
+}
+
+// MigrateCommitIdentities keys the authors of the commits stored before author identities were recorded by their
+// name, the way domain.Identity.Key keys an author without login nor email, and flags their repositories so that
+// they are reindexed on boot to record the email and login of those authors.
+func MigrateCommitIdentities(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`UPDATE repositories SET identities_pending = true WHERE id IN (
+			SELECT repository_commits.repository_id FROM repository_commits
+			JOIN commits ON commits.commit_id = repository_commits.commit_id
+			WHERE commits.author_key IS NULL OR commits.author_key = '')`).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Exec(`UPDATE commits SET author_key = 'name:' || LOWER(BTRIM(REGEXP_REPLACE(author, '\s+', ' ', 'g')))
+			WHERE author_key IS NULL OR author_key = ''`)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			log.Info().Msgf("keyed the authors of %d stored commits by name, their repositories are reindexed on boot", result.RowsAffected)
+		}
+		return nil
+	})
+}
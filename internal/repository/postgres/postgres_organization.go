@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// OrgMembership represents the Postgres model for the org_memberships table, it holds an organization or user
+// whose repositories are watched and the filter selecting the repositories to track.
+type OrgMembership struct {
+	ID              uint   `gorm:"primaryKey"`
+	Owner           string `gorm:"type:varchar(100);uniqueIndex"`
+	IncludeForks    bool
+	IncludeArchived bool
+	Topic           string `gorm:"type:varchar(100)"`
+	Language        string `gorm:"type:varchar(100)"`
+	NamePattern     string `gorm:"type:varchar"`
+	LastSyncedAt    *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ToDomain converts a Postgres OrgMembership object to domain entity OrgMembership.
+func (pm *OrgMembership) ToDomain() *domain.OrgMembership {
+	return &domain.OrgMembership{
+		ID:    pm.ID,
+		Owner: pm.Owner,
+		Filter: domain.OrgImportFilter{
+			IncludeForks:    pm.IncludeForks,
+			IncludeArchived: pm.IncludeArchived,
+			Topic:           pm.Topic,
+			Language:        pm.Language,
+			NamePattern:     pm.NamePattern,
+		},
+		LastSyncedAt: pm.LastSyncedAt,
+		CreatedAt:    pm.CreatedAt,
+	}
+}
+
+// FromDomainOrgMembership returns a Postgres OrgMembership object from domain entity OrgMembership.
+func FromDomainOrgMembership(m *domain.OrgMembership) *OrgMembership {
+	return &OrgMembership{
+		Owner:           m.Owner,
+		IncludeForks:    m.Filter.IncludeForks,
+		IncludeArchived: m.Filter.IncludeArchived,
+		Topic:           m.Filter.Topic,
+		Language:        m.Filter.Language,
+		NamePattern:     m.Filter.NamePattern,
+		LastSyncedAt:    m.LastSyncedAt,
+		CreatedAt:       m.CreatedAt,
+	}
+}
+
+// OrgImport represents the Postgres model for the org_imports table, it holds a background import of the
+// repositories of an organization or user and the filter it was started with.
+type OrgImport struct {
+	ID              uint   `gorm:"primaryKey"`
+	Owner           string `gorm:"type:varchar(100);index"`
+	IncludeForks    bool
+	IncludeArchived bool
+	Topic           string `gorm:"type:varchar(100)"`
+	Language        string `gorm:"type:varchar(100)"`
+	NamePattern     string `gorm:"type:varchar"`
+	SyncMembership  bool
+	Status          string `gorm:"type:varchar(20);index"`
+	Error           string `gorm:"type:text"`
+	Results         []OrgImportResult
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	CompletedAt     *time.Time
+}
+
+// OrgImportResult represents the Postgres model for the org_import_results table, RepositoryID is the
+// public id of the repository when it was added.
+type OrgImportResult struct {
+	ID           uint   `gorm:"primaryKey"`
+	OrgImportID  uint   `gorm:"index"`
+	Name         string `gorm:"type:varchar(200)"`
+	Status       string `gorm:"type:varchar(20)"`
+	Reason       string `gorm:"type:text"`
+	RepositoryID string `gorm:"type:varchar(100)"`
+}
+
+// UntrackedRepository represents the Postgres model for the untracked_repositories table, it holds the
+// repositories untracked by a user so that watched organizations and users do not add them back.
+type UntrackedRepository struct {
+	ID          uint   `gorm:"primaryKey"`
+	ProviderID  int64  `gorm:"uniqueIndex"`
+	Name        string `gorm:"type:varchar(200)"`
+	UntrackedAt time.Time
+}
+
+// ToDomain converts a Postgres OrgImport object to domain entity OrgImport.
+func (pi *OrgImport) ToDomain() *domain.OrgImport {
+	orgImport := &domain.OrgImport{
+		ID:    pi.ID,
+		Owner: pi.Owner,
+		Filter: domain.OrgImportFilter{
+			IncludeForks:    pi.IncludeForks,
+			IncludeArchived: pi.IncludeArchived,
+			Topic:           pi.Topic,
+			Language:        pi.Language,
+			NamePattern:     pi.NamePattern,
+		},
+		SyncMembership: pi.SyncMembership,
+		Status:         pi.Status,
+		Error:          pi.Error,
+		Results:        make([]domain.OrgImportResult, 0, len(pi.Results)),
+		CreatedAt:      pi.CreatedAt,
+		UpdatedAt:      pi.UpdatedAt,
+		CompletedAt:    pi.CompletedAt,
+	}
+	for _, r := range pi.Results {
+		orgImport.Results = append(orgImport.Results, r.ToDomain())
+	}
+	return orgImport
+}
+
+// FromDomainOrgImport returns a Postgres OrgImport object from domain entity OrgImport, its results are
+// stored apart.
+func FromDomainOrgImport(i *domain.OrgImport) *OrgImport {
+	return &OrgImport{
+		ID:              i.ID,
+		Owner:           i.Owner,
+		IncludeForks:    i.Filter.IncludeForks,
+		IncludeArchived: i.Filter.IncludeArchived,
+		Topic:           i.Filter.Topic,
+		Language:        i.Filter.Language,
+		NamePattern:     i.Filter.NamePattern,
+		SyncMembership:  i.SyncMembership,
+		Status:          i.Status,
+		Error:           i.Error,
+		CreatedAt:       i.CreatedAt,
+		UpdatedAt:       i.UpdatedAt,
+		CompletedAt:     i.CompletedAt,
+	}
+}
+
+// ToDomain converts a Postgres OrgImportResult object to domain entity OrgImportResult.
+func (pr *OrgImportResult) ToDomain() domain.OrgImportResult {
+	result := domain.OrgImportResult{Name: pr.Name, Status: pr.Status, Reason: pr.Reason}
+	if pr.RepositoryID != "" {
+		result.Repository = &domain.RepoMetadata{PublicID: pr.RepositoryID, Name: pr.Name}
+	}
+	return result
+}
+
+// FromDomainOrgImportResult returns a Postgres OrgImportResult object from domain entity OrgImportResult.
+func FromDomainOrgImportResult(importID uint, r domain.OrgImportResult) OrgImportResult {
+	result := OrgImportResult{OrgImportID: importID, Name: r.Name, Status: r.Status, Reason: r.Reason}
+	if r.Repository != nil {
+		result.RepositoryID = r.Repository.PublicID
+	}
+	return result
+}
@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresOrganizationRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresOrganizationRepository(db *gorm.DB) repository.OrganizationRepository {
+	return &PostgresOrganizationRepository{DB: db}
+}
+
+// SaveOrgMembership creates the membership of an organization or user, or replaces the filter of an existing one
+func (o *PostgresOrganizationRepository) SaveOrgMembership(ctx context.Context, membership domain.OrgMembership) (*domain.OrgMembership, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	dbMembership := FromDomainOrgMembership(&membership)
+	err := o.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "owner"}},
+		DoUpdates: clause.AssignmentColumns([]string{"include_forks", "include_archived", "topic", "language", "name_pattern", "last_synced_at", "updated_at"}),
+	}).Create(dbMembership).Error
+	if err != nil {
+		return nil, err
+	}
+	return dbMembership.ToDomain(), nil
+}
+
+// AllOrgMemberships fetches the watched organizations and users
+func (o *PostgresOrganizationRepository) AllOrgMemberships(ctx context.Context) ([]domain.OrgMembership, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbMemberships []OrgMembership
+	if err := o.DB.WithContext(ctx).Order("id ASC").Find(&dbMemberships).Error; err != nil {
+		return nil, err
+	}
+
+	memberships := make([]domain.OrgMembership, 0, len(dbMemberships))
+	for _, m := range dbMemberships {
+		memberships = append(memberships, *m.ToDomain())
+	}
+	return memberships, nil
+}
+
+// UpdateOrgMembershipSyncedAt persists when the repositories of a watched organization or user were last synced
+func (o *PostgresOrganizationRepository) UpdateOrgMembershipSyncedAt(ctx context.Context, owner string, syncedAt time.Time) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return o.DB.WithContext(ctx).Model(&OrgMembership{}).
+		Where("owner = ?", owner).
+		Update("last_synced_at", syncedAt).
+		Error
+}
+
+// SaveOrgImport stores a new import of the repositories of an organization or user
+func (o *PostgresOrganizationRepository) SaveOrgImport(ctx context.Context, orgImport domain.OrgImport) (*domain.OrgImport, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	dbImport := FromDomainOrgImport(&orgImport)
+	if err := o.DB.WithContext(ctx).Create(dbImport).Error; err != nil {
+		log.Error().Msgf("Persistence::SaveOrgImport error: %v, (%v)", err.Error(), err.Error())
+		return nil, err
+	}
+	return dbImport.ToDomain(), nil
+}
+
+// UpdateOrgImport persists the status and error of an import
+func (o *PostgresOrganizationRepository) UpdateOrgImport(ctx context.Context, orgImport domain.OrgImport) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	dbImport := FromDomainOrgImport(&orgImport)
+	err := o.DB.WithContext(ctx).Model(&OrgImport{}).
+		Where("id = ?", orgImport.ID).
+		Select("status", "error", "completed_at").
+		Updates(dbImport).Error
+	if err != nil {
+		log.Error().Msgf("Persistence::UpdateOrgImport error: %v, (%v)", err.Error(), err.Error())
+	}
+	return err
+}
+
+// SaveOrgImportResults appends the results of a page of imported repositories to an import
+func (o *PostgresOrganizationRepository) SaveOrgImportResults(ctx context.Context, orgImport domain.OrgImport, results []domain.OrgImportResult) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	dbResults := make([]OrgImportResult, 0, len(results))
+	for _, r := range results {
+		dbResults = append(dbResults, FromDomainOrgImportResult(orgImport.ID, r))
+	}
+	return o.DB.WithContext(ctx).Create(&dbResults).Error
+}
+
+// LatestOrgImport fetches the latest import of an organization or user with its results
+func (o *PostgresOrganizationRepository) LatestOrgImport(ctx context.Context, owner string) (*domain.OrgImport, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbImport OrgImport
+	err := o.DB.WithContext(ctx).
+		Preload("Results", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Where("owner = ?", owner).
+		Order("created_at DESC").
+		Limit(1).
+		Find(&dbImport).Error
+	if err != nil {
+		return nil, err
+	}
+	if dbImport.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	return dbImport.ToDomain(), nil
+}
+
+// FailRunningOrgImports marks the imports interrupted by a restart as failed
+func (o *PostgresOrganizationRepository) FailRunningOrgImports(ctx context.Context, reason string) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return o.DB.WithContext(ctx).Model(&OrgImport{}).
+		Where("status = ?", domain.OrgImportStatusRunning).
+		Updates(map[string]interface{}{"status": domain.OrgImportStatusFailed, "error": reason}).Error
+}
+
+// UntrackedProviderIDs fetches the provider ids of the repositories untracked by a user
+func (o *PostgresOrganizationRepository) UntrackedProviderIDs(ctx context.Context) (map[int64]bool, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var ids []int64
+	if err := o.DB.WithContext(ctx).Model(&UntrackedRepository{}).Pluck("provider_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	untracked := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		untracked[id] = true
+	}
+	return untracked, nil
+}
+
+// TrackedRepoNames fetches the names of the tracked repositories
+func (o *PostgresOrganizationRepository) TrackedRepoNames(ctx context.Context) (map[string]bool, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var names []string
+	if err := o.DB.WithContext(ctx).Model(&Repository{}).Pluck("name", &names).Error; err != nil {
+		return nil, err
+	}
+
+	tracked := make(map[string]bool, len(names))
+	for _, name := range names {
+		tracked[name] = true
+	}
+	return tracked, nil
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// PullRequest represents the Postgres model for the pull_requests table, it holds a pull request of a repository.
+// CreatedAt and UpdatedAt are the dates the pull request was opened and last updated at the git provider.
+type PullRequest struct {
+	ID             uint   `gorm:"primaryKey"`
+	RepositoryID   uint   `gorm:"uniqueIndex:idx_pull_requests_repository_id_number"`
+	Number         int    `gorm:"uniqueIndex:idx_pull_requests_repository_id_number"`
+	Title          string `gorm:"type:varchar"`
+	Author         string `gorm:"type:varchar(100);index"`
+	State          string `gorm:"type:varchar(20);index"`
+	BaseRef        string `gorm:"type:varchar"`
+	HeadRef        string `gorm:"type:varchar"`
+	MergeCommitSHA string `gorm:"type:varchar(40)"`
+	ReviewCount    int
+	Labels         string `gorm:"type:text"`
+	URL            string `gorm:"type:varchar"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time `gorm:"index"`
+	MergedAt       *time.Time
+	ClosedAt       *time.Time
+}
+
+// PullRequestCommit represents the Postgres model for the pull_request_commits table, it links a merged
+// pull request of a repository to the commits it introduced.
+type PullRequestCommit struct {
+	ID           uint   `gorm:"primaryKey"`
+	RepositoryID uint   `gorm:"uniqueIndex:idx_pull_request_commits_repository_id_number_commit_id"`
+	Number       int    `gorm:"uniqueIndex:idx_pull_request_commits_repository_id_number_commit_id"`
+	CommitID     string `gorm:"type:varchar(40);uniqueIndex:idx_pull_request_commits_repository_id_number_commit_id;index"`
+	CreatedAt    time.Time
+}
+
+// PullRequestSync represents the Postgres model for the pull_request_syncs table, SyncedUntil is the latest update
+// date of the pull requests of a repository the last complete sync walked down from.
+type PullRequestSync struct {
+	ID           uint `gorm:"primaryKey"`
+	RepositoryID uint `gorm:"uniqueIndex"`
+	SyncedUntil  time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ToDomain converts a Postgres PullRequest object to domain entity PullRequest.
+func (pp *PullRequest) ToDomain() *domain.PullRequest {
+	return &domain.PullRequest{
+		RepositoryID:   pp.RepositoryID,
+		Number:         pp.Number,
+		Title:          pp.Title,
+		Author:         pp.Author,
+		State:          pp.State,
+		BaseRef:        pp.BaseRef,
+		HeadRef:        pp.HeadRef,
+		MergeCommitSHA: pp.MergeCommitSHA,
+		ReviewCount:    pp.ReviewCount,
+		Labels:         splitLines(pp.Labels),
+		URL:            pp.URL,
+		CreatedAt:      pp.CreatedAt,
+		UpdatedAt:      pp.UpdatedAt,
+		MergedAt:       pp.MergedAt,
+		ClosedAt:       pp.ClosedAt,
+	}
+}
+
+// FromDomainPullRequest returns a Postgres PullRequest object from domain entity PullRequest.
+func FromDomainPullRequest(p *domain.PullRequest) *PullRequest {
+	return &PullRequest{
+		RepositoryID:   p.RepositoryID,
+		Number:         p.Number,
+		Title:          p.Title,
+		Author:         p.Author,
+		State:          p.State,
+		BaseRef:        p.BaseRef,
+		HeadRef:        p.HeadRef,
+		MergeCommitSHA: p.MergeCommitSHA,
+		ReviewCount:    p.ReviewCount,
+		Labels:         strings.Join(p.Labels, "\n"),
+		URL:            p.URL,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+		MergedAt:       p.MergedAt,
+		ClosedAt:       p.ClosedAt,
+	}
+}
@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresPullRequestRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresPullRequestRepository(db *gorm.DB) repository.PullRequestRepository {
+	return &PostgresPullRequestRepository{DB: db}
+}
+
+// SavePullRequests stores the pull requests of a repository, updating the pull requests already stored
+func (p *PostgresPullRequestRepository) SavePullRequests(ctx context.Context, pullRequests []domain.PullRequest) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+	if len(pullRequests) == 0 {
+		return nil
+	}
+
+	dbPullRequests := make([]*PullRequest, 0, len(pullRequests))
+	for i := range pullRequests {
+		dbPullRequests = append(dbPullRequests, FromDomainPullRequest(&pullRequests[i]))
+	}
+	return p.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "repository_id"}, {Name: "number"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "author", "state", "base_ref", "head_ref", "merge_commit_sha",
+			"review_count", "labels", "url", "updated_at", "merged_at", "closed_at"}),
+	}).CreateInBatches(dbPullRequests, commitInsertBatchSize).Error
+}
+
+// PullRequestUpdates returns the update date of the stored pull requests of a repository among numbers, keyed by number
+func (p *PostgresPullRequestRepository) PullRequestUpdates(ctx context.Context, repo domain.RepoMetadata, numbers []int) (map[int]time.Time, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	updates := make(map[int]time.Time, len(numbers))
+	if len(numbers) == 0 {
+		return updates, nil
+	}
+
+	var dbPullRequests []PullRequest
+	err := p.DB.WithContext(ctx).Model(&PullRequest{}).
+		Select("number, updated_at").
+		Where("repository_id = ? AND number IN ?", repo.ID, numbers).
+		Find(&dbPullRequests).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pr := range dbPullRequests {
+		updates[pr.Number] = pr.UpdatedAt
+	}
+	return updates, nil
+}
+
+// PullRequestsSyncedUntil returns the latest update date the last complete pull requests sync of a repository walked
+// down from, nil when no sync completed yet
+func (p *PostgresPullRequestRepository) PullRequestsSyncedUntil(ctx context.Context, repo domain.RepoMetadata) (*time.Time, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var sync PullRequestSync
+	err := p.DB.WithContext(ctx).Where("repository_id = ?", repo.ID).Find(&sync).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if sync.ID == 0 {
+		return nil, nil
+	}
+	return &sync.SyncedUntil, nil
+}
+
+// SavePullRequestsSyncedUntil creates or replaces the checkpoint of the pull requests sync of a repository
+func (p *PostgresPullRequestRepository) SavePullRequestsSyncedUntil(ctx context.Context, repo domain.RepoMetadata, syncedUntil time.Time) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	return p.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "repository_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"synced_until", "updated_at"}),
+	}).Create(&PullRequestSync{RepositoryID: repo.ID, SyncedUntil: syncedUntil}).Error
+}
+
+// PullRequestsByRepository fetches a page of the pull requests of a repository, only those in state when it is not empty
+func (p *PostgresPullRequestRepository) PullRequestsByRepository(ctx context.Context, repo domain.RepoMetadata, state string, query domain.APIPagingData) ([]domain.PullRequest, *domain.PagingInfo, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, nil, message.ErrContextCancelled
+	}
+
+	var dbPullRequests []PullRequest
+	var count int64
+
+	queryInfo, offset := repository.GetQueryPaginationData(query)
+
+	db := p.DB.WithContext(ctx).Model(&PullRequest{}).Where("repository_id = ?", repo.ID)
+	if state != "" {
+		db = db.Where("state = ?", state)
+	}
+	db = db.Session(&gorm.Session{})
+
+	db.Count(&count)
+
+	db = db.Offset(offset).Limit(queryInfo.Limit).
+		Order(fmt.Sprintf("%s %s", queryInfo.Sort, queryInfo.Direction)).
+		Find(&dbPullRequests)
+	if db.Error != nil {
+		return nil, nil, db.Error
+	}
+
+	pagingInfo := repository.PagingInfo(queryInfo, int(count))
+	pagingInfo.Count = len(dbPullRequests)
+
+	pullRequests := make([]domain.PullRequest, 0, len(dbPullRequests))
+	for _, pr := range dbPullRequests {
+		pullRequests = append(pullRequests, *pr.ToDomain())
+	}
+	return pullRequests, &pagingInfo, nil
+}
+
+// SavePullRequestCommits links in a single statement the commits introduced by a merged pull request of a repository,
+// skipping the links already stored
+func (p *PostgresPullRequestRepository) SavePullRequestCommits(ctx context.Context, repo domain.RepoMetadata, number int, commitIDs []string) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+	if len(commitIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO pull_request_commits (repository_id, number, commit_id, created_at) VALUES `)
+	args := make([]interface{}, 0, len(commitIDs)*4)
+	for i, id := range commitIDs {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("(?, ?, ?, ?)")
+		args = append(args, repo.ID, number, id, now)
+	}
+	sb.WriteString(" ON CONFLICT DO NOTHING")
+
+	return p.DB.WithContext(ctx).Exec(sb.String(), args...).Error
+}
+
+// PullRequestsByCommitIDs fetches the pull request of a repository that introduced each commit, keyed by commit id,
+// a commit linked to several pull requests was introduced by the earliest merged one
+func (p *PostgresPullRequestRepository) PullRequestsByCommitIDs(ctx context.Context, repo domain.RepoMetadata, commitIDs []string) (map[string]domain.PullRequest, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	pullRequests := make(map[string]domain.PullRequest, len(commitIDs))
+	if len(commitIDs) == 0 {
+		return pullRequests, nil
+	}
+
+	var rows []struct {
+		PullRequest
+		CommitID string
+	}
+	err := p.DB.WithContext(ctx).Model(&PullRequestCommit{}).
+		Select("DISTINCT ON (pull_request_commits.commit_id) pull_request_commits.commit_id, pull_requests.*").
+		Joins("JOIN pull_requests ON pull_requests.repository_id = pull_request_commits.repository_id AND pull_requests.number = pull_request_commits.number").
+		Where("pull_request_commits.repository_id = ? AND pull_request_commits.commit_id IN ?", repo.ID, commitIDs).
+		Order("pull_request_commits.commit_id, pull_requests.merged_at ASC NULLS LAST, pull_requests.number ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range rows {
+		pullRequests[r.CommitID] = *r.PullRequest.ToDomain()
+	}
+	return pullRequests, nil
+}
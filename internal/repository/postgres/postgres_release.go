@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// Tag represents the Postgres model for the tags table, it holds a tag of a repository and the commit it points to.
+type Tag struct {
+	ID           uint   `gorm:"primaryKey"`
+	RepositoryID uint   `gorm:"uniqueIndex:idx_tags_repository_id_name"`
+	Name         string `gorm:"type:varchar;uniqueIndex:idx_tags_repository_id_name"`
+	SHA          string `gorm:"type:varchar(40)"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Release represents the Postgres model for the releases table, it holds a release of a repository
+// published from a tag.
+type Release struct {
+	ID            uint   `gorm:"primaryKey"`
+	RepositoryID  uint   `gorm:"uniqueIndex:idx_releases_repository_id_tag_name"`
+	TagName       string `gorm:"type:varchar;uniqueIndex:idx_releases_repository_id_tag_name"`
+	Name          string `gorm:"type:varchar"`
+	Notes         string `gorm:"type:text"`
+	Prerelease    bool
+	Draft         bool
+	PublishedAt   *time.Time `gorm:"index"`
+	CommitsSynced bool
+	// TagSHA and CommitCount are not columns, they are read from the tag and the commits of the release
+	TagSHA      string `gorm:"->;-:migration"`
+	CommitCount int    `gorm:"->;-:migration"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ToDomain converts a Postgres Release object to domain entity Release.
+func (pr *Release) ToDomain() *domain.Release {
+	return &domain.Release{
+		RepositoryID:  pr.RepositoryID,
+		Tag:           pr.TagName,
+		TagSHA:        pr.TagSHA,
+		Name:          pr.Name,
+		Notes:         pr.Notes,
+		Prerelease:    pr.Prerelease,
+		Draft:         pr.Draft,
+		PublishedAt:   pr.PublishedAt,
+		CommitCount:   pr.CommitCount,
+		CommitsSynced: pr.CommitsSynced,
+	}
+}
+
+// ToDomain converts a Postgres Tag object to domain entity Tag.
+func (pt *Tag) ToDomain() *domain.Tag {
+	return &domain.Tag{
+		RepositoryID: pt.RepositoryID,
+		Name:         pt.Name,
+		SHA:          pt.SHA,
+	}
+}
+
+// FromDomainRelease returns a Postgres Release object from domain entity Release.
+func FromDomainRelease(r *domain.Release) *Release {
+	return &Release{
+		RepositoryID: r.RepositoryID,
+		TagName:      r.Tag,
+		Name:         r.Name,
+		Notes:        r.Notes,
+		Prerelease:   r.Prerelease,
+		Draft:        r.Draft,
+		PublishedAt:  r.PublishedAt,
+	}
+}
+
+// FromDomainTag returns a Postgres Tag object from domain entity Tag.
+func FromDomainTag(t *domain.Tag) *Tag {
+	return &Tag{
+		RepositoryID: t.RepositoryID,
+		Name:         t.Name,
+		SHA:          t.SHA,
+	}
+}
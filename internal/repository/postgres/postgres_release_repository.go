@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// releaseColumns selects the releases of a repository along with the commit of their tag and the number of
+// commits first released in them
+const releaseColumns = `releases.*, tags.sha AS tag_sha,
+	(SELECT count(*) FROM repository_commits rc WHERE rc.repository_id = releases.repository_id AND rc.first_released_in = releases.tag_name) AS commit_count`
+
+type PostgresReleaseRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresReleaseRepository(db *gorm.DB) repository.ReleaseRepository {
+	return &PostgresReleaseRepository{DB: db}
+}
+
+// SaveTags stores the tags of a repository, updating the commit of the tags already stored
+func (r *PostgresReleaseRepository) SaveTags(ctx context.Context, tags []domain.Tag) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	dbTags := make([]*Tag, 0, len(tags))
+	for i := range tags {
+		dbTags = append(dbTags, FromDomainTag(&tags[i]))
+	}
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "repository_id"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"sha", "updated_at"}),
+	}).CreateInBatches(dbTags, commitInsertBatchSize).Error
+}
+
+// TagsByRepository fetches the stored tags of a repository
+func (r *PostgresReleaseRepository) TagsByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.Tag, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbTags []Tag
+	if err := r.DB.WithContext(ctx).Where("repository_id = ?", repo.ID).Find(&dbTags).Error; err != nil {
+		return nil, err
+	}
+
+	tags := make([]domain.Tag, 0, len(dbTags))
+	for _, t := range dbTags {
+		tags = append(tags, *t.ToDomain())
+	}
+	return tags, nil
+}
+
+// SaveReleases stores the releases of a repository, updating the releases already stored
+func (r *PostgresReleaseRepository) SaveReleases(ctx context.Context, releases []domain.Release) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+	if len(releases) == 0 {
+		return nil
+	}
+
+	dbReleases := make([]*Release, 0, len(releases))
+	for i := range releases {
+		dbReleases = append(dbReleases, FromDomainRelease(&releases[i]))
+	}
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "repository_id"}, {Name: "tag_name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "notes", "prerelease", "draft", "published_at", "updated_at"}),
+	}).CreateInBatches(dbReleases, commitInsertBatchSize).Error
+}
+
+// ReleasesByRepository fetches the releases of a repository, latest published first
+func (r *PostgresReleaseRepository) ReleasesByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.Release, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var dbReleases []Release
+	err := r.releases(ctx, repo).Order("releases.published_at DESC NULLS FIRST").Find(&dbReleases).Error
+	if err != nil {
+		return nil, err
+	}
+
+	releases := make([]domain.Release, 0, len(dbReleases))
+	for _, rl := range dbReleases {
+		releases = append(releases, *rl.ToDomain())
+	}
+	return releases, nil
+}
+
+// ReleaseByTag fetches the release of a repository published from a tag
+func (r *PostgresReleaseRepository) ReleaseByTag(ctx context.Context, repo domain.RepoMetadata, tag string) (*domain.Release, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var release Release
+	err := r.releases(ctx, repo).Where("releases.tag_name = ?", tag).Find(&release).Error
+	if err != nil {
+		return nil, err
+	}
+	if release.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	return release.ToDomain(), nil
+}
+
+// UpdateFirstReleases records the earliest release of the unreleased commits of a repository, keyed by commit id,
+// and marks the releases of syncedTags as synced in the same transaction; the commits already released are kept
+func (r *PostgresReleaseRepository) UpdateFirstReleases(ctx context.Context, repo domain.RepoMetadata, firstReleases map[string]string, syncedTags []string) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	commitsByTag := make(map[string][]string)
+	for commitID, tag := range firstReleases {
+		commitsByTag[tag] = append(commitsByTag[tag], commitID)
+	}
+
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for tag, commitIDs := range commitsByTag {
+			for start := 0; start < len(commitIDs); start += commitInsertBatchSize {
+				end := min(start+commitInsertBatchSize, len(commitIDs))
+				err := tx.Model(&RepositoryCommit{}).
+					Where("repository_id = ? AND commit_id IN ?", repo.ID, commitIDs[start:end]).
+					Where("(first_released_in IS NULL OR first_released_in = '')").
+					Update("first_released_in", tag).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(syncedTags) == 0 {
+			return nil
+		}
+		return tx.Model(&Release{}).
+			Where("repository_id = ? AND tag_name IN ?", repo.ID, syncedTags).
+			Update("commits_synced", true).Error
+	})
+}
+
+func (r *PostgresReleaseRepository) releases(ctx context.Context, repo domain.RepoMetadata) *gorm.DB {
+	return r.DB.WithContext(ctx).Model(&Release{}).
+		Select(releaseColumns).
+		Joins("LEFT JOIN tags ON tags.repository_id = releases.repository_id AND tags.name = releases.tag_name").
+		Where("releases.repository_id = ?", repo.ID)
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"strings"
 	"time"
 
 	"github.com/kenmobility/git-api-service/internal/domain"
@@ -8,59 +9,142 @@ import (
 
 // Repository represents the Postgres model for the repositories table.
 type Repository struct {
-	ID                uint   `gorm:"primarykey"`
-	PublicID          string `gorm:"type:varchar;uniqueIndex"`
-	Name              string `gorm:"type:varchar;unique"`
-	Description       string `gorm:"type:text"`
-	URL               string `gorm:"type:varchar"`
-	Language          string `gorm:"type:varchar"`
-	ForksCount        int
-	StarsCount        int
-	OpenIssuesCount   int
-	WatchersCount     int
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
-	LastFetchedCommit string `gorm:"type:varchar"`
-	IsFetching        bool
-	LastFetchedPage   int32 `gorm:"default:1"`
+	ID                     uint   `gorm:"primarykey"`
+	ProviderID             int64  `gorm:"index"`
+	PublicID               string `gorm:"type:varchar;uniqueIndex"`
+	Name                   string `gorm:"type:varchar;unique"`
+	Description            string `gorm:"type:text"`
+	URL                    string `gorm:"type:varchar"`
+	Language               string `gorm:"type:varchar"`
+	ForksCount             int
+	StarsCount             int
+	OpenIssuesCount        int
+	WatchersCount          int
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+	LastFetchedCommit      string `gorm:"type:varchar"`
+	IsFetching             bool
+	LastFetchedPage        int32 `gorm:"default:1"`
+	Paused                 bool  `gorm:"default:false"`
+	TrackSince             *time.Time
+	TrackUntil             *time.Time
+	Branch                 string `gorm:"type:varchar"`
+	DefaultBranch          string `gorm:"type:varchar"`
+	TrackedBranches        string `gorm:"type:text"`
+	FetchInterval          time.Duration
+	CommitsPerPage         int
+	Aliases                []RepositoryAlias
+	Archived               bool
+	Disabled               bool
+	Visibility             string `gorm:"type:varchar(20)"`
+	PushedAt               *time.Time
+	UpstreamState          string `gorm:"type:varchar(20);default:active;index"`
+	UpstreamStateChangedAt *time.Time
+	IdentitiesPending      bool   `gorm:"not null;default:false"`
+	MailmapETag            string `gorm:"column:mailmap_etag;type:varchar"`
+}
+
+// RepositoryAlias represents the Postgres model for the repository_aliases table, it holds
+// a previous name of a repository renamed or transferred upstream.
+type RepositoryAlias struct {
+	ID           uint   `gorm:"primaryKey"`
+	RepositoryID uint   `gorm:"index"`
+	Name         string `gorm:"type:varchar;index"`
+	Kind         string `gorm:"type:varchar(20)"`
+	CreatedAt    time.Time
 }
 
 // ToDomain converts a Postgres Repository object to domain entity RepoMetadata.
 func (pr *Repository) ToDomain() *domain.RepoMetadata {
 	return &domain.RepoMetadata{
-		PublicID:          pr.PublicID,
-		Name:              pr.Name,
-		Description:       pr.Description,
-		URL:               pr.URL,
-		Language:          pr.Language,
-		ForksCount:        pr.ForksCount,
-		StarsCount:        pr.StarsCount,
-		OpenIssuesCount:   pr.OpenIssuesCount,
-		WatchersCount:     pr.WatchersCount,
-		CreatedAt:         pr.CreatedAt,
-		UpdatedAt:         pr.UpdatedAt,
-		LastFetchedCommit: pr.LastFetchedCommit,
-		IsFetching:        pr.IsFetching,
-		LastFetchedPage:   pr.LastFetchedPage,
+		ID:                     pr.ID,
+		ProviderID:             pr.ProviderID,
+		PublicID:               pr.PublicID,
+		Name:                   pr.Name,
+		Description:            pr.Description,
+		URL:                    pr.URL,
+		Language:               pr.Language,
+		ForksCount:             pr.ForksCount,
+		StarsCount:             pr.StarsCount,
+		OpenIssuesCount:        pr.OpenIssuesCount,
+		WatchersCount:          pr.WatchersCount,
+		CreatedAt:              pr.CreatedAt,
+		UpdatedAt:              pr.UpdatedAt,
+		LastFetchedCommit:      pr.LastFetchedCommit,
+		IsFetching:             pr.IsFetching,
+		LastFetchedPage:        pr.LastFetchedPage,
+		Paused:                 pr.Paused,
+		TrackSince:             pr.TrackSince,
+		TrackUntil:             pr.TrackUntil,
+		Branch:                 pr.Branch,
+		DefaultBranch:          pr.DefaultBranch,
+		TrackedBranches:        splitLines(pr.TrackedBranches),
+		FetchInterval:          pr.FetchInterval,
+		CommitsPerPage:         pr.CommitsPerPage,
+		Aliases:                domainRepoAliases(pr.Aliases),
+		Archived:               pr.Archived,
+		Disabled:               pr.Disabled,
+		Visibility:             pr.Visibility,
+		PushedAt:               pr.PushedAt,
+		UpstreamState:          pr.UpstreamState,
+		UpstreamStateChangedAt: pr.UpstreamStateChangedAt,
+		IdentitiesPending:      pr.IdentitiesPending,
+		MailmapETag:            pr.MailmapETag,
 	}
 }
 
 // FromDomainRepo returns a Postgres Repository object from domain entity RepoMetadata.
 func FromDomainRepo(r *domain.RepoMetadata) *Repository {
 	return &Repository{
-		PublicID:          r.PublicID,
-		Name:              r.Name,
-		Description:       r.Description,
-		URL:               r.URL,
-		Language:          r.Language,
-		ForksCount:        r.ForksCount,
-		StarsCount:        r.StarsCount,
-		OpenIssuesCount:   r.OpenIssuesCount,
-		WatchersCount:     r.WatchersCount,
-		CreatedAt:         r.CreatedAt,
-		UpdatedAt:         r.UpdatedAt,
-		LastFetchedCommit: r.LastFetchedCommit,
-		IsFetching:        r.IsFetching,
-		LastFetchedPage:   r.LastFetchedPage,
+		ProviderID:             r.ProviderID,
+		PublicID:               r.PublicID,
+		Name:                   r.Name,
+		Description:            r.Description,
+		URL:                    r.URL,
+		Language:               r.Language,
+		ForksCount:             r.ForksCount,
+		StarsCount:             r.StarsCount,
+		OpenIssuesCount:        r.OpenIssuesCount,
+		WatchersCount:          r.WatchersCount,
+		CreatedAt:              r.CreatedAt,
+		UpdatedAt:              r.UpdatedAt,
+		LastFetchedCommit:      r.LastFetchedCommit,
+		IsFetching:             r.IsFetching,
+		LastFetchedPage:        r.LastFetchedPage,
+		Paused:                 r.Paused,
+		TrackSince:             r.TrackSince,
+		TrackUntil:             r.TrackUntil,
+		Branch:                 r.Branch,
+		DefaultBranch:          r.DefaultBranch,
+		TrackedBranches:        strings.Join(r.TrackedBranches, "\n"),
+		FetchInterval:          r.FetchInterval,
+		CommitsPerPage:         r.CommitsPerPage,
+		Archived:               r.Archived,
+		Disabled:               r.Disabled,
+		Visibility:             r.Visibility,
+		PushedAt:               r.PushedAt,
+		UpstreamState:          r.UpstreamState,
+		UpstreamStateChangedAt: r.UpstreamStateChangedAt,
+	}
+}
+
+func domainRepoAliases(dbAliases []RepositoryAlias) []domain.RepoAlias {
+	if len(dbAliases) == 0 {
+		return nil
+	}
+
+	aliases := make([]domain.RepoAlias, 0, len(dbAliases))
+	for _, a := range dbAliases {
+		aliases = append(aliases, domain.RepoAlias{Name: a.Name, Kind: a.Kind, CreatedAt: a.CreatedAt})
+	}
+	return aliases
+}
+
+// splitLines splits newline separated values such as the tracked branches of a repository, git forbids control
+// characters in branch names so a newline never appears in a name or pattern
+func splitLines(values string) []string {
+	if values == "" {
+		return nil
 	}
+	return strings.Split(values, "\n")
 }
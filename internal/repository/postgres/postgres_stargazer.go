@@ -0,0 +1,26 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// Stargazer represents the Postgres model for the stargazers table, it holds a user who starred
+// a repository and when.
+type Stargazer struct {
+	ID           uint      `gorm:"primaryKey"`
+	RepositoryID uint      `gorm:"uniqueIndex:idx_stargazers_repository_id_login"`
+	Login        string    `gorm:"type:varchar(100);uniqueIndex:idx_stargazers_repository_id_login"`
+	StarredAt    time.Time `gorm:"index"`
+	CreatedAt    time.Time
+}
+
+// ToDomain converts a Postgres Stargazer object to domain entity Stargazer.
+func (ps *Stargazer) ToDomain() *domain.Stargazer {
+	return &domain.Stargazer{
+		RepositoryID: ps.RepositoryID,
+		Login:        ps.Login,
+		StarredAt:    ps.StarredAt,
+	}
+}
@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+)
+
+// stargazerDeleteBatchSize bounds the logins bound to a single delete statement
+const stargazerDeleteBatchSize = 1000
+
+type PostgresStargazerRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresStargazerRepository(db *gorm.DB) repository.StargazerRepository {
+	return &PostgresStargazerRepository{DB: db}
+}
+
+// SaveStargazers stores the stargazers of a repository in a single statement, skipping the users already
+// stored as stargazers of the repository, and returns the number of stored stargazers
+func (s *PostgresStargazerRepository) SaveStargazers(ctx context.Context, stargazers []domain.Stargazer) (int, error) {
+	if ctx.Err() == context.Canceled {
+		return 0, message.ErrContextCancelled
+	}
+	if len(stargazers) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	var sb strings.Builder
+	sb.WriteString(`INSERT INTO stargazers (repository_id, login, starred_at, created_at) VALUES `)
+	args := make([]interface{}, 0, len(stargazers)*4)
+	for i, sg := range stargazers {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString("(?, ?, ?, ?)")
+		args = append(args, sg.RepositoryID, sg.Login, sg.StarredAt, now)
+	}
+	sb.WriteString(" ON CONFLICT DO NOTHING")
+
+	result := s.DB.WithContext(ctx).Exec(sb.String(), args...)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+// StargazerCount counts the stored stargazers of a repository
+func (s *PostgresStargazerRepository) StargazerCount(ctx context.Context, repo domain.RepoMetadata) (int, error) {
+	if ctx.Err() == context.Canceled {
+		return 0, message.ErrContextCancelled
+	}
+
+	var count int64
+	err := s.DB.WithContext(ctx).Model(&Stargazer{}).Where("repository_id = ?", repo.ID).Count(&count).Error
+	return int(count), err
+}
+
+// DeleteStargazersExcept removes the stargazers of a repository who starred it up to until and are not
+// listed in logins, the users who removed their star, and returns the number of removed stargazers
+func (s *PostgresStargazerRepository) DeleteStargazersExcept(ctx context.Context, repo domain.RepoMetadata, logins []string, until time.Time) (int, error) {
+	if ctx.Err() == context.Canceled {
+		return 0, message.ErrContextCancelled
+	}
+
+	var stored []string
+	err := s.DB.WithContext(ctx).Model(&Stargazer{}).
+		Where("repository_id = ? AND starred_at <= ?", repo.ID, until).
+		Pluck("login", &stored).Error
+	if err != nil {
+		return 0, err
+	}
+
+	listed := make(map[string]bool, len(logins))
+	for _, login := range logins {
+		listed[login] = true
+	}
+	unstarred := make([]string, 0)
+	for _, login := range stored {
+		if !listed[login] {
+			unstarred = append(unstarred, login)
+		}
+	}
+
+	removed := 0
+	for start := 0; start < len(unstarred); start += stargazerDeleteBatchSize {
+		end := min(start+stargazerDeleteBatchSize, len(unstarred))
+		result := s.DB.WithContext(ctx).
+			Where("repository_id = ? AND login IN ?", repo.ID, unstarred[start:end]).
+			Delete(&Stargazer{})
+		if result.Error != nil {
+			return removed, result.Error
+		}
+		removed += int(result.RowsAffected)
+	}
+	return removed, nil
+}
+
+// WeeklyStars counts the stars given to a repository per week, weeks start on Monday and weeks without
+// stars are left out
+func (s *PostgresStargazerRepository) WeeklyStars(ctx context.Context, repo domain.RepoMetadata) ([]domain.StarCount, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var weekly []domain.StarCount
+	err := s.DB.WithContext(ctx).Model(&Stargazer{}).
+		Select("date_trunc('week', starred_at) AS week, count(*) AS stars").
+		Where("repository_id = ?", repo.ID).
+		Group("week").
+		Order("week ASC").
+		Scan(&weekly).Error
+	if err != nil {
+		return nil, err
+	}
+	return weekly, nil
+}
+
+// FastestGrowingRepos ranks the tracked repositories by the stars they gained since a date
+func (s *PostgresStargazerRepository) FastestGrowingRepos(ctx context.Context, since time.Time, limit int) ([]domain.StarGrowth, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var rows []struct {
+		RepositoryID uint
+		Gained       int
+		Total        int
+	}
+	err := s.DB.WithContext(ctx).Model(&Stargazer{}).
+		Select("repository_id, count(*) FILTER (WHERE starred_at >= ?) AS gained, count(*) AS total", since).
+		Group("repository_id").
+		Order("gained DESC, total ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []domain.StarGrowth{}, nil
+	}
+
+	ids := make([]uint, 0, len(rows))
+	for _, r := range rows {
+		ids = append(ids, r.RepositoryID)
+	}
+
+	var dbRepos []Repository
+	if err := s.DB.WithContext(ctx).Where("id IN ?", ids).Find(&dbRepos).Error; err != nil {
+		return nil, err
+	}
+	repos := make(map[uint]Repository, len(dbRepos))
+	for _, r := range dbRepos {
+		repos[r.ID] = r
+	}
+
+	growth := make([]domain.StarGrowth, 0, len(rows))
+	for _, r := range rows {
+		repo, ok := repos[r.RepositoryID]
+		if !ok {
+			continue
+		}
+		growth = append(growth, domain.StarGrowth{Repository: *repo.ToDomain(), Gained: r.Gained, Total: r.Total})
+	}
+	return growth, nil
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"strings"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+// SyncCursor represents the Postgres model for the sync_cursors table, KnownHeads holds
+// comma separated commit shas.
+type SyncCursor struct {
+	ID            uint   `gorm:"primaryKey"`
+	RepositoryID  uint   `gorm:"uniqueIndex"`
+	HeadSHA       string `gorm:"type:varchar(100)"`
+	HighWaterMark time.Time
+	KnownHeads    string `gorm:"type:text"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ToDomain converts a Postgres SyncCursor object to domain entity SyncCursor.
+func (pc *SyncCursor) ToDomain() *domain.SyncCursor {
+	return &domain.SyncCursor{
+		RepositoryID:  pc.RepositoryID,
+		HeadSHA:       pc.HeadSHA,
+		HighWaterMark: pc.HighWaterMark,
+		KnownHeads:    splitSHAs(pc.KnownHeads),
+		UpdatedAt:     pc.UpdatedAt,
+	}
+}
+
+// FromDomainSyncCursor returns a Postgres SyncCursor object from domain entity SyncCursor.
+func FromDomainSyncCursor(c *domain.SyncCursor) *SyncCursor {
+	return &SyncCursor{
+		RepositoryID:  c.RepositoryID,
+		HeadSHA:       c.HeadSHA,
+		HighWaterMark: c.HighWaterMark,
+		KnownHeads:    strings.Join(c.KnownHeads, ","),
+	}
+}
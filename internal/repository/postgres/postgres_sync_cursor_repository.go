@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresSyncCursorRepository struct {
+	DB *gorm.DB
+}
+
+func NewPostgresSyncCursorRepository(db *gorm.DB) repository.SyncCursorRepository {
+	return &PostgresSyncCursorRepository{DB: db}
+}
+
+// SyncCursorByRepository fetches the live reconciliation cursor of a repository
+func (s *PostgresSyncCursorRepository) SyncCursorByRepository(ctx context.Context, repo domain.RepoMetadata) (*domain.SyncCursor, error) {
+	if ctx.Err() == context.Canceled {
+		return nil, message.ErrContextCancelled
+	}
+
+	var cursor SyncCursor
+	err := s.DB.WithContext(ctx).Where("repository_id = ?", repo.ID).Find(&cursor).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.ID == 0 {
+		return nil, message.ErrNoRecordFound
+	}
+	return cursor.ToDomain(), nil
+}
+
+// SaveSyncCursor creates or replaces the live reconciliation cursor of a repository
+func (s *PostgresSyncCursorRepository) SaveSyncCursor(ctx context.Context, cursor domain.SyncCursor) error {
+	if ctx.Err() == context.Canceled {
+		return message.ErrContextCancelled
+	}
+
+	dbCursor := FromDomainSyncCursor(&cursor)
+	return s.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "repository_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"head_sha", "high_water_mark", "known_heads", "updated_at"}),
+	}).Create(dbCursor).Error
+}
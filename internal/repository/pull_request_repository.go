@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type PullRequestRepository interface {
+	SavePullRequests(ctx context.Context, pullRequests []domain.PullRequest) error
+	PullRequestUpdates(ctx context.Context, repo domain.RepoMetadata, numbers []int) (map[int]time.Time, error)
+	PullRequestsSyncedUntil(ctx context.Context, repo domain.RepoMetadata) (*time.Time, error)
+	SavePullRequestsSyncedUntil(ctx context.Context, repo domain.RepoMetadata, syncedUntil time.Time) error
+	PullRequestsByRepository(ctx context.Context, repo domain.RepoMetadata, state string, query domain.APIPagingData) ([]domain.PullRequest, *domain.PagingInfo, error)
+	SavePullRequestCommits(ctx context.Context, repo domain.RepoMetadata, number int, commitIDs []string) error
+	PullRequestsByCommitIDs(ctx context.Context, repo domain.RepoMetadata, commitIDs []string) (map[string]domain.PullRequest, error)
+}
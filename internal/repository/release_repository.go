@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type ReleaseRepository interface {
+	SaveTags(ctx context.Context, tags []domain.Tag) error
+	TagsByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.Tag, error)
+	SaveReleases(ctx context.Context, releases []domain.Release) error
+	ReleasesByRepository(ctx context.Context, repo domain.RepoMetadata) ([]domain.Release, error)
+	ReleaseByTag(ctx context.Context, repo domain.RepoMetadata, tag string) (*domain.Release, error)
+	UpdateFirstReleases(ctx context.Context, repo domain.RepoMetadata, firstReleases map[string]string, syncedTags []string) error
+}
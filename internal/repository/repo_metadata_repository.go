@@ -11,6 +11,18 @@ type RepoMetadataRepository interface {
 	UpdateRepoMetadata(ctx context.Context, repo domain.RepoMetadata) (*domain.RepoMetadata, error)
 	RepoMetadataByPublicId(ctx context.Context, publicId string) (*domain.RepoMetadata, error)
 	RepoMetadataByName(ctx context.Context, name string) (*domain.RepoMetadata, error)
+	RepoMetadataByProviderID(ctx context.Context, providerID int64) (*domain.RepoMetadata, error)
+	RepoMetadataByCommitID(ctx context.Context, commitID string) ([]domain.RepoMetadata, error)
 	AllRepoMetadata(ctx context.Context) ([]domain.RepoMetadata, error)
 	UpdateFetchingStateForAllRepos(ctx context.Context, isFetching bool) error
+	UpdateTrackingSettings(ctx context.Context, repo domain.RepoMetadata) (*domain.RepoMetadata, error)
+	UpdateRepoStats(ctx context.Context, repo domain.RepoMetadata) (*domain.RepoMetadata, error)
+	UpdateFetchingState(ctx context.Context, publicId string, isFetching bool) error
+	ClaimFetching(ctx context.Context, publicId string) (bool, error)
+	UpdatePausedState(ctx context.Context, publicId string, paused bool) error
+	UpdateUpstreamState(ctx context.Context, publicId string, state string) error
+	UpdateIdentitiesPending(ctx context.Context, publicId string, pending bool) error
+	UpdateMailmapETag(ctx context.Context, publicId string, etag string) error
+	DeleteRepoMetadata(ctx context.Context, repo domain.RepoMetadata, retainCommits bool) error
+	UpdateRepoIdentity(ctx context.Context, repo domain.RepoMetadata, previousName string) (*domain.RepoMetadata, error)
 }
@@ -3,4 +3,18 @@ package repository
 type Repository interface {
 	CommitRepository
 	RepoMetadataRepository
+	BackfillRepository
+	SyncCursorRepository
+	IntegrityRepository
+	MetadataSnapshotRepository
+	StargazerRepository
+	OrganizationRepository
+	BranchRepository
+	ReleaseRepository
+	PullRequestRepository
+	IssueRepository
+	CheckRepository
+	EnrichmentRepository
+	FileRepository
+	ContributorRepository
 }
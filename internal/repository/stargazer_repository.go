@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type StargazerRepository interface {
+	SaveStargazers(ctx context.Context, stargazers []domain.Stargazer) (int, error)
+	StargazerCount(ctx context.Context, repo domain.RepoMetadata) (int, error)
+	DeleteStargazersExcept(ctx context.Context, repo domain.RepoMetadata, logins []string, until time.Time) (int, error)
+	WeeklyStars(ctx context.Context, repo domain.RepoMetadata) ([]domain.StarCount, error)
+	FastestGrowingRepos(ctx context.Context, since time.Time, limit int) ([]domain.StarGrowth, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+)
+
+type SyncCursorRepository interface {
+	SyncCursorByRepository(ctx context.Context, repo domain.RepoMetadata) (*domain.SyncCursor, error)
+	SaveSyncCursor(ctx context.Context, cursor domain.SyncCursor) error
+}
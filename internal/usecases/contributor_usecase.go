@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+)
+
+type ContributorUsecase interface {
+	GetContributor(ctx context.Context, contributorId string) (*domain.Contributor, error)
+	Merge(ctx context.Context, into string, authorKeys []string) (*domain.Contributor, error)
+}
+
+type contributorUsecase struct {
+	contributorRepository repository.ContributorRepository
+}
+
+func NewContributorUsecase(contributorRepo repository.ContributorRepository) ContributorUsecase {
+	return &contributorUsecase{
+		contributorRepository: contributorRepo,
+	}
+}
+
+// GetContributor returns a contributor with the identities resolved to it
+func (uc *contributorUsecase) GetContributor(ctx context.Context, contributorId string) (*domain.Contributor, error) {
+	contributor, err := uc.contributorRepository.ContributorByPublicId(ctx, contributorId)
+	if err == message.ErrNoRecordFound {
+		return nil, message.ErrContributorNotFound
+	}
+	return contributor, err
+}
+
+// Merge merges the contributors of author keys into the contributor of the author key into. An author key is the
+// key of a contributor, eg contributor:{id}, or an identity key, eg email:{email}; an identity not resolved to a
+// contributor yet is resolved to the merged contributor.
+func (uc *contributorUsecase) Merge(ctx context.Context, into string, authorKeys []string) (*domain.Contributor, error) {
+	target, err := uc.contributorByAuthorKey(ctx, into)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []domain.Contributor
+	var identities []domain.ContributorIdentity
+	for _, key := range authorKeys {
+		contributor, err := uc.contributorByAuthorKey(ctx, key)
+		if err == message.ErrContributorNotFound && !strings.HasPrefix(key, domain.ContributorKeyPrefix) {
+			identity, _ := domain.ParseIdentityKey(key)
+			identities = append(identities, domain.ContributorIdentity{
+				Key:    identity.Key(),
+				Name:   identity.Name,
+				Email:  identity.Email,
+				Login:  identity.Login,
+				Source: domain.IdentitySourceMerge,
+			})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, *contributor)
+	}
+
+	if err := uc.contributorRepository.MergeContributors(ctx, *target, sources); err != nil {
+		return nil, err
+	}
+	if err := uc.contributorRepository.AddContributorIdentities(ctx, *target, identities); err != nil {
+		return nil, err
+	}
+
+	return uc.contributorRepository.ContributorByPublicId(ctx, target.PublicID)
+}
+
+// contributorByAuthorKey returns the contributor of the key of a contributor or of an identity key
+func (uc *contributorUsecase) contributorByAuthorKey(ctx context.Context, key string) (*domain.Contributor, error) {
+	var contributor *domain.Contributor
+	var err error
+	if publicID, ok := strings.CutPrefix(key, domain.ContributorKeyPrefix); ok {
+		contributor, err = uc.contributorRepository.ContributorByPublicId(ctx, publicID)
+	} else {
+		identity, ok := domain.ParseIdentityKey(key)
+		if !ok {
+			return nil, message.ErrInvalidAuthorKey
+		}
+		contributor, err = uc.contributorRepository.ContributorByIdentityKey(ctx, identity.Key())
+	}
+
+	if err == message.ErrNoRecordFound {
+		return nil, message.ErrContributorNotFound
+	}
+	return contributor, err
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	repo_mocks "github.com/kenmobility/git-api-service/internal/repository/mocks"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMergeContributors(t *testing.T) {
+	store := repo_mocks.NewMockRepository(gomock.NewController(t))
+	uc := NewContributorUsecase(store)
+
+	target := domain.Contributor{ID: 1, PublicID: "target", Name: "Jane Doe"}
+	source := domain.Contributor{ID: 2, PublicID: "source", Name: "jdoe"}
+
+	store.EXPECT().ContributorByPublicId(gomock.Any(), "target").Return(&target, nil).Times(1)
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "login:jdoe").Return(&source, nil).Times(1)
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "email:jane@home.example.com").Return(nil, message.ErrNoRecordFound).Times(1)
+
+	store.EXPECT().MergeContributors(gomock.Any(), target, []domain.Contributor{source}).Return(nil).Times(1)
+	// the email has no commits yet, it is resolved to the target for the commits to come
+	store.EXPECT().
+		AddContributorIdentities(gomock.Any(), target, []domain.ContributorIdentity{
+			{Key: "email:jane@home.example.com", Email: "Jane@Home.example.com", Source: domain.IdentitySourceMerge},
+		}).
+		Return(nil).
+		Times(1)
+	store.EXPECT().ContributorByPublicId(gomock.Any(), "target").Return(&target, nil).Times(1)
+
+	merged, err := uc.Merge(context.Background(), "contributor:target", []string{"login:jdoe", "email:Jane@Home.example.com"})
+
+	require.NoError(t, err)
+	require.Equal(t, target, *merged)
+}
+
+func TestMergeContributorsInvalidAuthorKey(t *testing.T) {
+	store := repo_mocks.NewMockRepository(gomock.NewController(t))
+	uc := NewContributorUsecase(store)
+
+	_, err := uc.Merge(context.Background(), "jane", []string{"login:jdoe"})
+
+	require.Equal(t, message.ErrInvalidAuthorKey, err)
+}
@@ -2,6 +2,9 @@ package usecases
 
 import (
 	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,26 +18,79 @@ import (
 )
 
 type GitRepositoryUsecase interface {
-	StartIndexing(ctx context.Context, repositoryName string) (*domain.RepoMetadata, error)
+	StartIndexing(ctx context.Context, repositoryName string, opts domain.TrackingOptions) (*domain.RepoMetadata, error)
+	TrackRepository(ctx context.Context, repoMetadata domain.RepoMetadata, opts domain.TrackingOptions) (*domain.RepoMetadata, error)
+	IndexingJobs() int
+	UpdateTracking(ctx context.Context, repoId string, opts domain.TrackingOptions) (*domain.RepoMetadata, error)
+	Estimate(ctx context.Context, repositoryName string, opts domain.TrackingOptions) (*domain.IndexingEstimate, error)
 	GetById(ctx context.Context, repoId string) (*domain.RepoMetadata, error)
-	GetAll(ctx context.Context) ([]domain.RepoMetadata, error)
+	GetAll(ctx context.Context, state string) ([]domain.RepoMetadata, error)
 	ResumeFetching(ctx context.Context) error
+	Untrack(ctx context.Context, repoId string, retainCommits bool) error
+	Pause(ctx context.Context, repoId string) (*domain.RepoMetadata, error)
+	Resume(ctx context.Context, repoId string) (*domain.RepoMetadata, error)
+	Reindex(ctx context.Context, repoId string) (*domain.ReindexJob, error)
+	ReindexStatus(ctx context.Context, repoId string) (*domain.ReindexJob, error)
+	BackfillJobs(ctx context.Context, repoId string) ([]domain.SyncRange, []domain.BackfillJob, error)
+	Verify(ctx context.Context, repoId string) (*domain.IntegrityReport, error)
+	IntegrityReport(ctx context.Context, repoId string) (*domain.IntegrityReport, error)
+	MetadataHistory(ctx context.Context, repoId string, from time.Time, to time.Time, interval string) ([]domain.RepoMetadataSnapshot, error)
+	StarHistory(ctx context.Context, repoId string) (*domain.StarHistory, error)
+	FastestGrowing(ctx context.Context, since time.Time, limit int) ([]domain.StarGrowth, error)
+	StartEnrichment(ctx context.Context)
+	EnrichmentState(ctx context.Context, repoId string) (*domain.EnrichmentState, error)
 }
 
 type gitRepoUsecase struct {
 	repoMetadataRepository repository.RepoMetadataRepository
 	commitRepository       repository.CommitRepository
+	backfillRepository     repository.BackfillRepository
+	syncCursorRepository   repository.SyncCursorRepository
+	integrityRepository    repository.IntegrityRepository
+	snapshotRepository     repository.MetadataSnapshotRepository
+	stargazerRepository    repository.StargazerRepository
+	branchRepository       repository.BranchRepository
+	releaseRepository      repository.ReleaseRepository
+	pullRequestRepository  repository.PullRequestRepository
+	issueRepository        repository.IssueRepository
+	checkRepository        repository.CheckRepository
+	enrichmentRepository   repository.EnrichmentRepository
+	contributorRepository  repository.ContributorRepository
 	gitClient              git.GitManagerClient
 	config                 config.Config
+	monitors               *repoMonitors
+	syncRangesMu           sync.Mutex
+	// runningBackfills holds the ids of the backfill jobs being run, so that a job is never run twice at once
+	runningBackfills sync.Map
+	// indexingJobs counts the running indexing, reindexing and backfill jobs
+	indexingJobs atomic.Int32
 }
 
 func NewGitRepositoryUsecase(repoMetadataRepo repository.RepoMetadataRepository, commitRepo repository.CommitRepository,
-	gitClient git.GitManagerClient, config config.Config) GitRepositoryUsecase {
+	backfillRepo repository.BackfillRepository, syncCursorRepo repository.SyncCursorRepository,
+	integrityRepo repository.IntegrityRepository, snapshotRepo repository.MetadataSnapshotRepository,
+	stargazerRepo repository.StargazerRepository, branchRepo repository.BranchRepository, releaseRepo repository.ReleaseRepository,
+	pullRequestRepo repository.PullRequestRepository, issueRepo repository.IssueRepository, checkRepo repository.CheckRepository,
+	enrichmentRepo repository.EnrichmentRepository, contributorRepo repository.ContributorRepository, gitClient git.GitManagerClient,
+	config config.Config) GitRepositoryUsecase {
 	return &gitRepoUsecase{
 		repoMetadataRepository: repoMetadataRepo,
 		commitRepository:       commitRepo,
+		backfillRepository:     backfillRepo,
+		syncCursorRepository:   syncCursorRepo,
+		integrityRepository:    integrityRepo,
+		snapshotRepository:     snapshotRepo,
+		stargazerRepository:    stargazerRepo,
+		branchRepository:       branchRepo,
+		releaseRepository:      releaseRepo,
+		pullRequestRepository:  pullRequestRepo,
+		issueRepository:        issueRepo,
+		checkRepository:        checkRepo,
+		enrichmentRepository:   enrichmentRepo,
+		contributorRepository:  contributorRepo,
 		gitClient:              gitClient,
 		config:                 config,
+		monitors:               newRepoMonitors(),
 	}
 }
 
@@ -47,74 +103,174 @@ func (uc *gitRepoUsecase) GetById(ctx context.Context, repoId string) (*domain.R
 	return repo, nil
 }
 
-func (uc *gitRepoUsecase) GetAll(ctx context.Context) ([]domain.RepoMetadata, error) {
+// GetAll returns the tracked repositories, only those in the upstream lifecycle state when state is not empty
+func (uc *gitRepoUsecase) GetAll(ctx context.Context, state string) ([]domain.RepoMetadata, error) {
+	if state != "" && !domain.IsValidUpstreamState(state) {
+		return nil, message.ErrInvalidUpstreamState
+	}
+
 	repos, err := uc.repoMetadataRepository.AllRepoMetadata(ctx)
 	if err != nil {
 		return nil, err
 	}
 	repoDtoResponse := make([]domain.RepoMetadata, 0, len(repos))
-	repoDtoResponse = append(repoDtoResponse, repos...)
+	for _, repo := range repos {
+		if state == "" || repo.State() == state {
+			repoDtoResponse = append(repoDtoResponse, repo)
+		}
+	}
 
 	return repoDtoResponse, nil
 }
 
-func (uc *gitRepoUsecase) StartIndexing(ctx context.Context, repositoryName string) (*domain.RepoMetadata, error) {
+func (uc *gitRepoUsecase) StartIndexing(ctx context.Context, repositoryName string, opts domain.TrackingOptions) (*domain.RepoMetadata, error) {
 	//validate repository name to ensure it has owner and repo name
 	if !helpers.IsRepositoryNameValid(repositoryName) {
 		return nil, message.ErrInvalidRepositoryName
 	}
 
-	// ensure repo does not exist on the db
+	// ensure repo does not exist on the db, a name only kept as an alias of a moved repository may since
+	// belong to another repository upstream, which is told apart by its provider id below
 	repo, err := uc.repoMetadataRepository.RepoMetadataByName(ctx, repositoryName)
 	if err != nil && err != message.ErrNoRecordFound {
 		return nil, err
 	}
 
-	if repo != nil && repo.Name != "" {
+	if repo != nil && repo.Name == repositoryName {
 		return nil, message.ErrRepoAlreadyAdded
 	}
 
+	settings, err := uc.trackingSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
 	repoMetadata, err := uc.gitClient.FetchRepoMetadata(ctx, repositoryName)
 	if err != nil {
 		return nil, err
 	}
 
+	return uc.trackRepository(ctx, *repoMetadata, settings)
+}
+
+// TrackRepository adds a repository whose metadata was already fetched from the git provider, such as a
+// repository listed for an organization, and starts indexing its commits
+func (uc *gitRepoUsecase) TrackRepository(ctx context.Context, repoMetadata domain.RepoMetadata, opts domain.TrackingOptions) (*domain.RepoMetadata, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByName(ctx, repoMetadata.Name)
+	if err != nil && err != message.ErrNoRecordFound {
+		return nil, err
+	}
+
+	if repo != nil && repo.Name == repoMetadata.Name {
+		return nil, message.ErrRepoAlreadyAdded
+	}
+
+	settings, err := uc.trackingSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.trackRepository(ctx, repoMetadata, settings)
+}
+
+// IndexingJobs returns the number of running indexing, reindexing and backfill jobs
+func (uc *gitRepoUsecase) IndexingJobs() int {
+	return int(uc.indexingJobs.Load())
+}
+
+// trackRepository stores a repository with its tracking settings and starts indexing its commits
+func (uc *gitRepoUsecase) trackRepository(ctx context.Context, repoMetadata domain.RepoMetadata, settings domain.RepoMetadata) (*domain.RepoMetadata, error) {
+	// a repository added under a name it has since moved away from is tracked under its current name
+	tracked, err := uc.repoMetadataRepository.RepoMetadataByProviderID(ctx, repoMetadata.ProviderID)
+	if err != nil && err != message.ErrNoRecordFound {
+		return nil, err
+	}
+	if tracked != nil {
+		return nil, message.ErrRepoAlreadyAdded
+	}
+
 	// update other repository metadata
+	repoMetadata.TrackSince = settings.TrackSince
+	repoMetadata.TrackUntil = settings.TrackUntil
+	repoMetadata.Branch = settings.Branch
+	repoMetadata.FetchInterval = settings.FetchInterval
+	repoMetadata.CommitsPerPage = settings.CommitsPerPage
 	repoMetadata.PublicID = uuid.New().String()
 	repoMetadata.CreatedAt = time.Now()
 	repoMetadata.UpdatedAt = time.Now()
 	repoMetadata.IsFetching = true
 
-	sRepoMetadata, err := uc.repoMetadataRepository.SaveRepoMetadata(ctx, *repoMetadata)
+	sRepoMetadata, err := uc.repoMetadataRepository.SaveRepoMetadata(ctx, repoMetadata)
 	if err != nil {
 		return nil, err
 	}
 
-	// Start fetching commits for the new added repository in a Goroutine
-	go uc.startRepoIndexing(ctx, *sRepoMetadata)
+	// the metadata history starts with the metadata the repository was added with
+	if _, err := uc.snapshotRepository.SaveMetadataSnapshot(ctx, domain.NewRepoMetadataSnapshot(*sRepoMetadata, sRepoMetadata.CreatedAt)); err != nil {
+		log.Err(err).Msgf("Error saving metadata snapshot of repository %s: %v", sRepoMetadata.Name, err)
+	}
+
+	// Start fetching commits for the new added repository in a Goroutine, detached from the
+	// request's cancellation so that only untracking the repository stops it
+	repoCtx := uc.monitors.start(context.WithoutCancel(ctx), sRepoMetadata.PublicID)
+	go uc.startRepoIndexing(repoCtx, *sRepoMetadata)
 
 	return sRepoMetadata, nil
 }
 
+// startRepoIndexing indexes the commits of a repository, then monitors it periodically under the same context once
+// it is indexed
 func (uc *gitRepoUsecase) startRepoIndexing(ctx context.Context, repo domain.RepoMetadata) {
+	if !uc.indexCommits(ctx, repo) {
+		return
+	}
+	uc.startPeriodicFetching(ctx, repo)
+}
+
+// indexCommits fetches the commits of a repository page by page from its last fetched page and syncs the data
+// derived from them, it reports whether the repository was fully indexed
+func (uc *gitRepoUsecase) indexCommits(ctx context.Context, repo domain.RepoMetadata) bool {
+	defer uc.trackIndexing()()
+
 	page := repo.LastFetchedPage
 	lastFetchedCommit := ""
+	since, until := repo.TrackingWindow()
 	log.Info().Msgf("fetching commits for repo: %s, starting from page-%d", repo.Name, page)
 	for {
-		commits, morePages, err := uc.gitClient.FetchCommits(ctx, repo, uc.config.DefaultStartDate, uc.config.DefaultEndDate, "", int(page), uc.config.GitCommitFetchPerPage)
+		if ctx.Err() != nil {
+			log.Warn().Msgf("Git repository [%s] commits indexing stopped", repo.Name)
+			return false
+		}
+
+		commits, morePages, err := uc.gitClient.FetchCommits(ctx, repo, since, until, "", int(page), uc.perPage(repo))
+		if err == message.ErrRepoGone {
+			if err := uc.updateUpstreamState(ctx, &repo, domain.UpstreamStateGone); err != nil {
+				log.Err(err).Msgf("Error updating upstream state of repository %s: %v", repo.Name, err)
+			}
+			if err := uc.repoMetadataRepository.UpdateFetchingState(ctx, repo.PublicID, false); err != nil {
+				log.Err(err).Msgf("Error updating isFetching column of repository %s: %v", repo.Name, err)
+			}
+			return false
+		}
+		// a mistyped or deleted branch is never found by retrying the page
+		if err == message.ErrUnknownRef {
+			log.Error().Msgf("branch %s of repository %s not found upstream, indexing stopped", repo.Branch, repo.Name)
+			if err := uc.repoMetadataRepository.UpdateFetchingState(ctx, repo.PublicID, false); err != nil {
+				log.Err(err).Msgf("Error updating isFetching column of repository %s: %v", repo.Name, err)
+			}
+			return false
+		}
 		if err != nil {
 			log.Err(err).Msgf("Failed to fetch commits for repository %s: %v", repo.Name, err)
 			continue
 		}
 
-		// loop through commits and persist each
-		for _, commit := range commits {
-			_, err := uc.commitRepository.SaveCommit(ctx, commit)
-			if err != nil {
-				log.Err(err).Msgf("error saving commit-id:%s for repo %s", commit.CommitID, repo.Name)
-				continue
-			}
-			lastFetchedCommit = commit.CommitID
+		if _, err := uc.commitRepository.SaveCommits(ctx, commits); err != nil {
+			log.Err(err).Msgf("error saving commits page-%d for repo %s", page, repo.Name)
+			continue
+		}
+		if len(commits) > 0 {
+			lastFetchedCommit = commits[len(commits)-1].CommitID
 		}
 
 		// Update the repository's last fetched commit in the database
@@ -129,11 +285,47 @@ func (uc *gitRepoUsecase) startRepoIndexing(ctx context.Context, repo domain.Rep
 		if !morePages {
 			// update isFetching to false as flag for start of monitoring
 			repo.IsFetching = false
-			_, err = uc.repoMetadataRepository.UpdateRepoMetadata(ctx, repo)
+			err = uc.repoMetadataRepository.UpdateFetchingState(ctx, repo.PublicID, false)
 			if err != nil {
 				log.Err(err).Msgf("Error updating isFetching column of repository %s: %v", repo.Name, err)
 			}
-			break
+
+			if err := uc.recordSyncRange(ctx, repo, trackingRange(repo)); err != nil {
+				log.Err(err).Msgf("Error recording fetched range of repository %s: %v", repo.Name, err)
+			}
+
+			if err := uc.resetSyncCursor(ctx, repo); err != nil {
+				log.Err(err).Msgf("Error saving sync cursor of repository %s: %v", repo.Name, err)
+			}
+
+			if err := uc.syncBranches(ctx, repo); err != nil {
+				log.Err(err).Msgf("Error syncing branches of repository %s: %v", repo.Name, err)
+			}
+
+			if err := uc.syncReleases(ctx, repo); err != nil {
+				log.Err(err).Msgf("Error syncing releases of repository %s: %v", repo.Name, err)
+			}
+
+			if err := uc.syncPullRequests(ctx, repo); err != nil {
+				log.Err(err).Msgf("Error syncing pull requests of repository %s: %v", repo.Name, err)
+			}
+
+			if err := uc.syncIssues(ctx, repo); err != nil {
+				log.Err(err).Msgf("Error syncing issues of repository %s: %v", repo.Name, err)
+			}
+
+			if err := uc.syncCommitReferences(ctx, repo); err != nil {
+				log.Err(err).Msgf("Error parsing commit references of repository %s: %v", repo.Name, err)
+			}
+
+			if err := uc.syncContributors(ctx, repo); err != nil {
+				log.Err(err).Msgf("Error resolving contributors of repository %s: %v", repo.Name, err)
+			}
+
+			if err := uc.syncChecks(ctx, repo); err != nil {
+				log.Err(err).Msgf("Error syncing checks of repository %s: %v", repo.Name, err)
+			}
+			return true
 		}
 		page++
 	}
@@ -141,22 +333,322 @@ func (uc *gitRepoUsecase) startRepoIndexing(ctx context.Context, repo domain.Rep
 
 func (uc *gitRepoUsecase) ResumeFetching(ctx context.Context) error {
 	log.Info().Msg("Resume fetching started ")
+
+	// a reindex interrupted by a restart left the stored commits untouched, it has to be requested again; the
+	// claim of its repository is released before the repositories are read, so that their monitoring resumes
+	if err := uc.backfillRepository.FailRunningReindexJobs(ctx, "reindex interrupted by a restart"); err != nil {
+		log.Err(err).Msgf("Error failing interrupted reindex jobs: %v", err)
+	}
+
 	repos, err := uc.repoMetadataRepository.AllRepoMetadata(ctx)
 	if err != nil {
 		log.Info().Msgf("Error fetching repositories from database: %v", err)
 		return err
 	}
 	log.Info().Msgf("Saved repos %v", repos)
+
 	for _, repo := range repos {
-		go uc.startPeriodicFetching(ctx, repo)
+		if repo.Paused {
+			log.Info().Msgf("monitoring of repo %s is paused, skipping", repo.Name)
+			continue
+		}
+		// commits stored before author emails and logins were recorded are reindexed to record them, a repository
+		// that cannot be reindexed now stays pending until the next boot
+		if repo.IdentitiesPending {
+			if _, err := uc.Reindex(ctx, repo.PublicID); err != nil {
+				log.Err(err).Msgf("Error reindexing repository %s to record its author identities: %v", repo.Name, err)
+			}
+		}
+		// a repository whose indexing was interrupted is monitored once its indexing is resumed and done
+		repoCtx := uc.monitors.start(ctx, repo.PublicID)
+		if repo.IsFetching {
+			go uc.startRepoIndexing(repoCtx, repo)
+		} else {
+			go uc.startPeriodicFetching(repoCtx, repo)
+		}
+		uc.resumeBackfills(repoCtx, repo)
+	}
+	return nil
+}
+
+// Untrack stops the monitoring and any in-flight fetching of a repository, then deletes it together with its
+// commits, which are archived instead when retainCommits is true
+func (uc *gitRepoUsecase) Untrack(ctx context.Context, repoId string, retainCommits bool) error {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return err
+	}
+
+	uc.monitors.stop(repo.PublicID)
+
+	if err := uc.repoMetadataRepository.DeleteRepoMetadata(ctx, *repo, retainCommits); err != nil {
+		log.Err(err).Msgf("Error deleting repository %s: %v", repo.Name, err)
+		return err
 	}
+
+	log.Info().Msgf("repository %s untracked, commits retained: %v", repo.Name, retainCommits)
 	return nil
 }
 
+// Pause persists the paused state of a repository and stops its monitoring and any in-flight fetching
+func (uc *gitRepoUsecase) Pause(ctx context.Context, repoId string) (*domain.RepoMetadata, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repoMetadataRepository.UpdatePausedState(ctx, repo.PublicID, true); err != nil {
+		return nil, err
+	}
+	repo.Paused = true
+
+	uc.monitors.stop(repo.PublicID)
+
+	log.Info().Msgf("monitoring of repo %s paused", repo.Name)
+	return repo, nil
+}
+
+// Resume clears the paused state of a repository and restarts its indexing, if it was interrupted, or its monitoring
+func (uc *gitRepoUsecase) Resume(ctx context.Context, repoId string) (*domain.RepoMetadata, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !repo.Paused {
+		return repo, nil
+	}
+
+	if err := uc.repoMetadataRepository.UpdatePausedState(ctx, repo.PublicID, false); err != nil {
+		return nil, err
+	}
+	repo.Paused = false
+
+	uc.startMonitoring(ctx, *repo)
+
+	log.Info().Msgf("monitoring of repo %s resumed", repo.Name)
+	return repo, nil
+}
+
+// UpdateTracking changes the tracking window, branch, fetch interval or page size of a repository and
+// restarts its monitoring so that the new settings take effect
+func (uc *gitRepoUsecase) UpdateTracking(ctx context.Context, repoId string, opts domain.TrackingOptions) (*domain.RepoMetadata, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *repo
+	opts.Apply(repo)
+
+	if err := validateTrackingSettings(*repo); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.repoMetadataRepository.UpdateTrackingSettings(ctx, *repo); err != nil {
+		return nil, err
+	}
+
+	// a grown window of an indexed repository is filled by backfill jobs, an indexing repository
+	// picks the new window up when it restarts
+	if !repo.IsFetching {
+		if _, err := uc.scheduleBackfills(ctx, *repo, previous); err != nil {
+			log.Err(err).Msgf("Error scheduling backfills of repository %s: %v", repo.Name, err)
+			return nil, err
+		}
+	}
+
+	if !repo.Paused {
+		uc.monitors.stop(repo.PublicID)
+		uc.startMonitoring(ctx, *repo)
+	}
+
+	log.Info().Msgf("tracking settings of repo %s updated", repo.Name)
+	return repo, nil
+}
+
+// startMonitoring restarts the interrupted indexing of a repository, or its periodic monitoring once indexed,
+// detached from the caller's cancellation so that only pausing or untracking the repository stops it
+func (uc *gitRepoUsecase) startMonitoring(ctx context.Context, repo domain.RepoMetadata) {
+	repoCtx := uc.monitors.start(context.WithoutCancel(ctx), repo.PublicID)
+	if repo.IsFetching {
+		go uc.startRepoIndexing(repoCtx, repo)
+	} else {
+		go uc.startPeriodicFetching(repoCtx, repo)
+	}
+	uc.resumeBackfills(repoCtx, repo)
+}
+
+// Reindex claims a repository and starts a background job re-fetching its full commit range into a shadow set,
+// diffing it against the stored commits and atomically swapping the result in, the periodic monitoring is held
+// off while it runs
+func (uc *gitRepoUsecase) Reindex(ctx context.Context, repoId string) (*domain.ReindexJob, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.IsFetching {
+		return nil, message.ErrRepoFetchInProgress
+	}
+
+	// the check above is only a shortcut, two concurrent requests are told apart by the claim
+	claimed, err := uc.repoMetadataRepository.ClaimFetching(ctx, repo.PublicID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, message.ErrRepoFetchInProgress
+	}
+
+	job, err := uc.backfillRepository.SaveReindexJob(ctx, domain.ReindexJob{
+		RepositoryID: repo.ID,
+		Status:       domain.ReindexStatusRunning,
+	})
+	if err != nil {
+		if err := uc.repoMetadataRepository.UpdateFetchingState(context.WithoutCancel(ctx), repo.PublicID, false); err != nil {
+			log.Err(err).Msgf("Error updating isFetching column of repository %s: %v", repo.Name, err)
+		}
+		return nil, err
+	}
+
+	// the job is detached from the request's cancellation so that only pausing or untracking the repository stops it
+	repoCtx := uc.monitors.start(context.WithoutCancel(ctx), repo.PublicID)
+	go uc.runReindex(repoCtx, *repo, *job)
+
+	return job, nil
+}
+
+// ReindexStatus returns the latest reindex job of a repository
+func (uc *gitRepoUsecase) ReindexStatus(ctx context.Context, repoId string) (*domain.ReindexJob, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := uc.backfillRepository.LatestReindexJob(ctx, *repo)
+	if err == message.ErrNoRecordFound {
+		return nil, message.ErrRepoNotReindexed
+	}
+	return job, err
+}
+
+// runReindex runs a reindex job of a claimed repository, persisting its progress page by page and the number of
+// commits it added, removed and changed, the repository is released once it is done
+func (uc *gitRepoUsecase) runReindex(ctx context.Context, repo domain.RepoMetadata, job domain.ReindexJob) {
+	defer uc.trackIndexing()()
+	defer func() {
+		if err := uc.repoMetadataRepository.UpdateFetchingState(context.WithoutCancel(ctx), repo.PublicID, false); err != nil {
+			log.Err(err).Msgf("Error updating isFetching column of repository %s: %v", repo.Name, err)
+		}
+	}()
+
+	log.Info().Msgf("reindexing commits of repo: %s", repo.Name)
+
+	var fetched []domain.Commit
+	since, until := repo.TrackingWindow()
+	for job.Page = 1; ; job.Page++ {
+		commits, morePages, err := uc.gitClient.FetchCommits(ctx, repo, since, until, "", job.Page, uc.perPage(repo))
+		if err != nil {
+			log.Err(err).Msgf("Failed to fetch commits page-%d while reindexing repository %s: %v", job.Page, repo.Name, err)
+			uc.failReindex(ctx, repo, job, err)
+			return
+		}
+		fetched = append(fetched, commits...)
+		job.CommitsFetched = len(fetched)
+
+		if !morePages {
+			break
+		}
+		if err := uc.backfillRepository.UpdateReindexJob(ctx, job); err != nil {
+			log.Err(err).Msgf("Error updating reindex job of repository %s: %v", repo.Name, err)
+		}
+	}
+
+	stored, err := uc.commitRepository.CommitsByRepository(ctx, repo)
+	if err != nil {
+		uc.failReindex(ctx, repo, job, err)
+		return
+	}
+	// the commits of the other tracked branches are not part of the fetched history
+	stored, err = uc.primaryBranchCommits(ctx, repo, stored)
+	if err != nil {
+		uc.failReindex(ctx, repo, job, err)
+		return
+	}
+
+	diff := diffCommits(stored, fetched)
+	if !diff.IsEmpty() {
+		if err := uc.commitRepository.ApplyCommitDiff(ctx, repo, diff); err != nil {
+			log.Err(err).Msgf("Error swapping reindexed commits of repository %s: %v", repo.Name, err)
+			uc.failReindex(ctx, repo, job, err)
+			return
+		}
+	}
+
+	if err := uc.backfillRepository.ReplaceSyncRanges(ctx, repo, []domain.SyncRange{trackingRange(repo)}); err != nil {
+		log.Err(err).Msgf("Error recording fetched range of repository %s: %v", repo.Name, err)
+	}
+
+	if err := uc.resetSyncCursor(ctx, repo); err != nil {
+		log.Err(err).Msgf("Error saving sync cursor of repository %s: %v", repo.Name, err)
+	}
+
+	completedAt := time.Now()
+	job.Status = domain.ReindexStatusCompleted
+	job.Added, job.Removed, job.Changed = len(diff.Added), len(diff.Removed), len(diff.Changed)
+	job.CompletedAt = &completedAt
+	if err := uc.backfillRepository.UpdateReindexJob(ctx, job); err != nil {
+		log.Err(err).Msgf("Error updating reindex job of repository %s: %v", repo.Name, err)
+	}
+
+	if repo.IdentitiesPending {
+		if err := uc.repoMetadataRepository.UpdateIdentitiesPending(ctx, repo.PublicID, false); err != nil {
+			log.Err(err).Msgf("Error clearing pending identities of repository %s: %v", repo.Name, err)
+		}
+	}
+
+	log.Info().Msgf("reindexed repo %s: %d added, %d removed, %d changed", repo.Name, job.Added, job.Removed, job.Changed)
+}
+
+// failReindex marks a reindex job as failed, the stored commits are left untouched
+func (uc *gitRepoUsecase) failReindex(ctx context.Context, repo domain.RepoMetadata, job domain.ReindexJob, err error) {
+	job.Status = domain.ReindexStatusFailed
+	job.Error = err.Error()
+	if err := uc.backfillRepository.UpdateReindexJob(context.WithoutCancel(ctx), job); err != nil {
+		log.Err(err).Msgf("Error updating reindex job of repository %s: %v", repo.Name, err)
+	}
+}
+
 func (uc *gitRepoUsecase) startPeriodicFetching(ctx context.Context, repo domain.RepoMetadata) error {
-	ticker := time.NewTicker(uc.config.FetchInterval)
+	if repo.State() != domain.UpstreamStateGone {
+		if _, err := uc.syncStargazers(ctx, repo); err != nil {
+			log.Err(err).Msgf("Error fetching stargazers of repository %s: %v", repo.Name, err)
+		}
+		if err := uc.syncReleases(ctx, repo); err != nil {
+			log.Err(err).Msgf("Error syncing releases of repository %s: %v", repo.Name, err)
+		}
+	}
+
+	ticker := time.NewTicker(uc.fetchInterval(repo))
 	defer ticker.Stop()
 
+	// history verification is disabled without an integrity check interval
+	var verify <-chan time.Time
+	if uc.config.IntegrityInterval > 0 {
+		verifyTicker := time.NewTicker(uc.config.IntegrityInterval)
+		defer verifyTicker.Stop()
+		verify = verifyTicker.C
+	}
+
+	// metadata is only fetched once when the repository is added without a metadata refresh interval
+	var refresh <-chan time.Time
+	if uc.config.MetadataInterval > 0 {
+		refreshTicker := time.NewTicker(uc.config.MetadataInterval)
+		defer refreshTicker.Stop()
+		refresh = refreshTicker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -168,72 +660,405 @@ func (uc *gitRepoUsecase) startPeriodicFetching(ctx context.Context, repo domain
 				log.Debug().Msgf("error getting repo metadata for monitoring: %v", err)
 				return err
 			}
+			if r.Paused {
+				log.Info().Msgf("Commits periodic fetching skipped for paused repo %v", repo.Name)
+				continue
+			}
+			// archived and gone repositories receive no commits, their state is only checked by the metadata refresh
+			if r.State() != domain.UpstreamStateActive {
+				log.Info().Msgf("Commits periodic fetching skipped for %s repo %v", r.State(), r.Name)
+				continue
+			}
 			if !r.IsFetching {
-				log.Info().Msgf("Commits periodic fetching started for repo %v", repo.Name)
+				log.Info().Msgf("Commits periodic fetching started for repo %v", r.Name)
 				uc.fetchAndReconcileCommits(ctx, *r)
+				if err := uc.syncBranches(ctx, *r); err != nil {
+					log.Err(err).Msgf("Error syncing branches of repository %s: %v", r.Name, err)
+				}
+				if err := uc.syncPullRequests(ctx, *r); err != nil {
+					log.Err(err).Msgf("Error syncing pull requests of repository %s: %v", r.Name, err)
+				}
+				if err := uc.syncIssues(ctx, *r); err != nil {
+					log.Err(err).Msgf("Error syncing issues of repository %s: %v", r.Name, err)
+				}
+				if err := uc.syncCommitReferences(ctx, *r); err != nil {
+					log.Err(err).Msgf("Error parsing commit references of repository %s: %v", r.Name, err)
+				}
+				if err := uc.syncContributors(ctx, *r); err != nil {
+					log.Err(err).Msgf("Error resolving contributors of repository %s: %v", r.Name, err)
+				}
+				if err := uc.syncChecks(ctx, *r); err != nil {
+					log.Err(err).Msgf("Error syncing checks of repository %s: %v", r.Name, err)
+				}
+				uc.retryBackfills(ctx, *r)
+			}
+		case <-verify:
+			r, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repo.PublicID)
+			if err != nil {
+				log.Debug().Msgf("error getting repo metadata for verification: %v", err)
+				return err
+			}
+			if r.Paused || r.IsFetching || r.State() == domain.UpstreamStateGone {
+				continue
+			}
+			if _, err := uc.verifyHistory(ctx, *r); err != nil {
+				log.Err(err).Msgf("Error verifying commit history of repository %s: %v", repo.Name, err)
+			}
+		case <-refresh:
+			r, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repo.PublicID)
+			if err != nil {
+				log.Debug().Msgf("error getting repo metadata for metadata refresh: %v", err)
+				return err
+			}
+			if r.Paused {
+				continue
+			}
+			refreshed, err := uc.refreshRepoMetadata(ctx, *r)
+			if err != nil {
+				log.Err(err).Msgf("Error refreshing metadata of repository %s: %v", r.Name, err)
+				continue
+			}
+			if _, err := uc.syncStargazers(ctx, *refreshed); err != nil {
+				log.Err(err).Msgf("Error fetching stargazers of repository %s: %v", r.Name, err)
+			}
+			if err := uc.syncReleases(ctx, *refreshed); err != nil {
+				log.Err(err).Msgf("Error syncing releases of repository %s: %v", r.Name, err)
 			}
 		}
 	}
 }
 
+// fetchAndReconcileCommits fetches the commits pushed since the last reconciliation, walking the tracked branch
+// from its current head, pinned for the whole walk, until it reaches a previously seen head or a page of stored
+// commits older than the high-water mark, then persists the sync cursor of the repository. A merged branch may bring
+// commits dated before the previous head, listed after it, so the walk goes on until every parent of the new commits
+// is stored.
 func (uc *gitRepoUsecase) fetchAndReconcileCommits(ctx context.Context, repo domain.RepoMetadata) {
 	log.Info().Msgf("Resume fetching and reconciling commits for repo: %s", repo.Name)
-	page := repo.LastFetchedPage
 
-	lastFetchedCommit := repo.LastFetchedCommit
+	cursor, err := uc.syncCursor(ctx, repo)
+	if err != nil {
+		log.Err(err).Msgf("Error getting sync cursor of repository %s: %v", repo.Name, err)
+		return
+	}
 
-	until := uc.config.DefaultEndDate
+	since, until := repo.TrackingWindow()
+	headSHA := ""
+	var newest time.Time
+	saved := 0
+	reachedKnownHead := false
+	seen := make(map[string]bool)
+	missingParents := make(map[string]bool)
 
-	for {
-		select {
-		case <-ctx.Done():
+	for page := 1; ; page++ {
+		if ctx.Err() != nil {
 			log.Warn().Msgf("Git repository [%s] fetchAndReconcileCommits service stopped", repo.Name)
 			return
-		default:
-			commits, morePages, err := uc.gitClient.FetchCommits(ctx, repo, uc.config.DefaultStartDate, until, lastFetchedCommit, int(page), uc.config.GitCommitFetchPerPage)
-			if err != nil {
-				log.Error().Msgf("Error fetching commits for repo %s: %v", repo.Name, err)
-				return
-			}
+		}
 
-			if len(commits) == 0 {
-				log.Warn().Msgf("No new commits for repo %s, resetting page to 1", repo.Name)
-				page = 1               //reset the page
-				lastFetchedCommit = "" //don't use sha endpoint
-				continue
-			}
+		commits, morePages, err := uc.gitClient.FetchCommits(ctx, repo, since, until, headSHA, page, uc.perPage(repo))
+		if err != nil {
+			log.Error().Msgf("Error fetching commits for repo %s: %v", repo.Name, err)
+			return
+		}
 
-			for _, commit := range commits {
-				_, err = uc.commitRepository.GetByCommitID(ctx, commit.CommitID)
-				if err != nil && err != message.ErrNoRecordFound && err != message.ErrContextCancelled {
-					log.Err(err).Msgf("error getting commit by commit-id:%s", commit.CommitID)
-				}
-				if err == message.ErrNoRecordFound {
-					_, err := uc.commitRepository.SaveCommit(ctx, commit)
-					if err != nil {
-						log.Err(err).Msgf("error saving commit-id:%s for repo %s", commit.CommitID, repo.Name)
-						continue
-					}
-					lastFetchedCommit = commit.CommitID
-				}
+		if len(commits) == 0 {
+			break
+		}
+
+		if headSHA == "" {
+			headSHA = commits[0].CommitID
+		}
+
+		for _, commit := range commits {
+			seen[commit.CommitID] = true
+			delete(missingParents, commit.CommitID)
+			// commits from a previously seen head onwards were reconciled already, except the ones merged with
+			// the new commits, which are found through their parents
+			if cursor.IsKnownHead(commit.CommitID) {
+				reachedKnownHead = true
 			}
+		}
 
-			repo.LastFetchedCommit = lastFetchedCommit
-			repo.LastFetchedPage = page
-			_, err = uc.repoMetadataRepository.UpdateRepoMetadata(ctx, repo)
-			if err != nil && err != message.ErrContextCancelled {
-				log.Debug().Msgf("Error updating repository %s: %v", repo.Name, err)
-				return
+		newCommits, err := uc.commitRepository.SaveCommits(ctx, commits)
+		if err != nil {
+			log.Err(err).Msgf("error saving commits of repo %s", repo.Name)
+			return
+		}
+		saved += len(newCommits)
+
+		if err := uc.addMissingParents(ctx, repo, newCommits, seen, missingParents); err != nil {
+			log.Err(err).Msgf("Error checking parents of new commits of repo %s: %v", repo.Name, err)
+			return
+		}
+
+		isNew := make(map[string]bool, len(newCommits))
+		for _, commit := range newCommits {
+			isNew[commit.CommitID] = true
+		}
+
+		allReconciled := true
+		for _, commit := range commits {
+			if commit.CommittedAt.After(newest) {
+				newest = commit.CommittedAt
+			}
+			if isNew[commit.CommitID] || commit.CommittedAt.After(cursor.HighWaterMark) {
+				allReconciled = false
 			}
+		}
+
+		if (reachedKnownHead || allReconciled) && len(missingParents) == 0 || !morePages {
+			break
+		}
+	}
+
+	cursor.Advance(headSHA, newest)
+	if err := uc.syncCursorRepository.SaveSyncCursor(ctx, *cursor); err != nil {
+		log.Err(err).Msgf("Error saving sync cursor of repository %s: %v", repo.Name, err)
+		return
+	}
+
+	if cursor.HeadSHA != "" && cursor.HeadSHA != repo.LastFetchedCommit {
+		repo.LastFetchedCommit = cursor.HeadSHA
+		if _, err := uc.repoMetadataRepository.UpdateRepoMetadata(ctx, repo); err != nil && err != message.ErrContextCancelled {
+			log.Debug().Msgf("Error updating repository %s: %v", repo.Name, err)
+		}
+	}
+
+	if err := uc.extendSyncCoverage(ctx, repo); err != nil {
+		log.Err(err).Msgf("Error extending fetched range of repository %s: %v", repo.Name, err)
+	}
 
-			if !morePages {
-				log.Info().Msgf("no more page to fech for repo: %s", repo.Name)
-				break
+	log.Info().Msgf("reconciled repo %s up to head %s, %d new commits", repo.Name, cursor.HeadSHA, saved)
+}
+
+// addMissingParents records the parents of new commits neither fetched by the walk yet nor stored as missing
+func (uc *gitRepoUsecase) addMissingParents(ctx context.Context, repo domain.RepoMetadata, newCommits []domain.Commit, seen, missing map[string]bool) error {
+	var parents []string
+	for _, commit := range newCommits {
+		for _, parent := range commit.ParentSHAs {
+			if !seen[parent] && !missing[parent] {
+				missing[parent] = true
+				parents = append(parents, parent)
 			}
+		}
+	}
+	if len(parents) == 0 {
+		return nil
+	}
+
+	stored, err := uc.commitRepository.StoredCommitIDs(ctx, repo, parents)
+	if err != nil {
+		return err
+	}
+	for _, parent := range stored {
+		delete(missing, parent)
+	}
+	return nil
+}
+
+// syncCursor returns the sync cursor of a repository, repositories without one start from their newest stored commit
+func (uc *gitRepoUsecase) syncCursor(ctx context.Context, repo domain.RepoMetadata) (*domain.SyncCursor, error) {
+	cursor, err := uc.syncCursorRepository.SyncCursorByRepository(ctx, repo)
+	if err == nil {
+		return cursor, nil
+	}
+	if err != message.ErrNoRecordFound {
+		return nil, err
+	}
+
+	cursor = &domain.SyncCursor{RepositoryID: repo.ID}
+
+	latest, err := uc.commitRepository.LatestCommit(ctx, repo)
+	if err != nil && err != message.ErrNoRecordFound {
+		return nil, err
+	}
+	if latest != nil {
+		committedAt := latest.CommittedAt
+		if committedAt.IsZero() {
+			committedAt = latest.Date
+		}
+		cursor.Advance(latest.CommitID, committedAt)
+	}
+
+	return cursor, nil
+}
+
+// resetSyncCursor replaces the sync cursor of a repository with one starting from its newest stored commit
+func (uc *gitRepoUsecase) resetSyncCursor(ctx context.Context, repo domain.RepoMetadata) error {
+	cursor := domain.SyncCursor{RepositoryID: repo.ID}
+
+	latest, err := uc.commitRepository.LatestCommit(ctx, repo)
+	if err != nil {
+		if err == message.ErrNoRecordFound {
+			return nil
+		}
+		return err
+	}
+
+	committedAt := latest.CommittedAt
+	if committedAt.IsZero() {
+		committedAt = latest.Date
+	}
+	cursor.Advance(latest.CommitID, committedAt)
+
+	return uc.syncCursorRepository.SaveSyncCursor(ctx, cursor)
+}
+
+// fetchInterval returns the monitoring interval of a repository, falling back to the configured one
+func (uc *gitRepoUsecase) fetchInterval(repo domain.RepoMetadata) time.Duration {
+	if repo.FetchInterval > 0 {
+		return repo.FetchInterval
+	}
+	return uc.config.FetchInterval
+}
+
+// perPage returns the commits page size of a repository, falling back to the configured one
+func (uc *gitRepoUsecase) perPage(repo domain.RepoMetadata) int {
+	if repo.CommitsPerPage > 0 {
+		return repo.CommitsPerPage
+	}
+	return uc.config.GitCommitFetchPerPage
+}
+
+// trackingSettings returns the tracking settings of a new repository, which default to the
+// global configuration unless set in opts
+func (uc *gitRepoUsecase) trackingSettings(opts domain.TrackingOptions) (domain.RepoMetadata, error) {
+	defaultSince := uc.config.DefaultStartDate
+	settings := domain.RepoMetadata{
+		TrackSince:     &defaultSince,
+		FetchInterval:  uc.config.FetchInterval,
+		CommitsPerPage: uc.config.GitCommitFetchPerPage,
+	}
+	if !uc.config.DefaultEndDate.IsZero() {
+		defaultUntil := uc.config.DefaultEndDate
+		settings.TrackUntil = &defaultUntil
+	}
+	opts.Apply(&settings)
+
+	return settings, validateTrackingSettings(settings)
+}
+
+// validateTrackingSettings ensures the tracking settings of a repository are usable
+func validateTrackingSettings(repo domain.RepoMetadata) error {
+	if repo.TrackSince != nil && repo.TrackUntil != nil && !repo.TrackSince.Before(*repo.TrackUntil) {
+		return message.ErrInvalidTrackingWindow
+	}
 
-			page++
+	if repo.FetchInterval != 0 && repo.FetchInterval < time.Minute {
+		return message.ErrInvalidFetchInterval
+	}
+
+	if repo.CommitsPerPage < 0 || repo.CommitsPerPage > 100 {
+		return message.ErrInvalidCommitsPerPage
+	}
+
+	for _, pattern := range repo.TrackedBranches {
+		if !domain.IsValidBranchPattern(pattern) {
+			return message.ErrInvalidBranchPattern
+		}
+	}
+
+	return nil
+}
+
+// diffCommits compares stored commits against freshly fetched ones using the commit id as identity
+func diffCommits(stored, fetched []domain.Commit) domain.CommitDiff {
+	var diff domain.CommitDiff
+
+	storedByID := make(map[string]domain.Commit, len(stored))
+	for _, c := range stored {
+		storedByID[c.CommitID] = c
+	}
+
+	fetchedIDs := make(map[string]bool, len(fetched))
+	for _, c := range fetched {
+		if fetchedIDs[c.CommitID] {
+			continue
+		}
+		fetchedIDs[c.CommitID] = true
+
+		s, ok := storedByID[c.CommitID]
+		if !ok {
+			diff.Added = append(diff.Added, c)
+			continue
+		}
+		if s.Message != c.Message || s.URL != c.URL || !s.Date.Equal(c.Date) || !s.CommittedAt.Equal(c.CommittedAt) ||
+			!sameIdentity(s.AuthorIdentity(), c.AuthorIdentity()) || !sameIdentity(s.CommitterIdentity(), c.CommitterIdentity()) ||
+			strings.Join(s.ParentSHAs, ",") != strings.Join(c.ParentSHAs, ",") {
+			diff.Changed = append(diff.Changed, c)
+		}
+	}
+
+	for _, c := range stored {
+		if !fetchedIDs[c.CommitID] {
+			diff.Removed = append(diff.Removed, c)
+		}
+	}
+
+	return diff
+}
+
+// sameIdentity reports whether two identities have the same name, email and login, their dates are compared apart
+func sameIdentity(a, b domain.Identity) bool {
+	return a.Name == b.Name && a.Email == b.Email && a.Login == b.Login
+}
 
-			until = time.Now()
+// fetchUpstreamMetadata fetches the current metadata of a repository from the git provider, by its provider id when known,
+// a repository no longer found upstream is moved to the gone state
+func (uc *gitRepoUsecase) fetchUpstreamMetadata(ctx context.Context, repo *domain.RepoMetadata) (*domain.RepoMetadata, error) {
+	var upstream *domain.RepoMetadata
+	var err error
+	if repo.ProviderID != 0 {
+		upstream, err = uc.gitClient.FetchRepoMetadataByID(ctx, repo.ProviderID)
+	} else {
+		upstream, err = uc.gitClient.FetchRepoMetadata(ctx, repo.Name)
+	}
+
+	if err == message.ErrRepoGone {
+		if err := uc.updateUpstreamState(ctx, repo, domain.UpstreamStateGone); err != nil {
+			log.Err(err).Msgf("Error updating upstream state of repository %s: %v", repo.Name, err)
 		}
 	}
+	return upstream, err
+}
+
+// updateUpstreamState persists a change of the upstream lifecycle state of a repository
+func (uc *gitRepoUsecase) updateUpstreamState(ctx context.Context, repo *domain.RepoMetadata, state string) error {
+	if state == "" || repo.State() == state {
+		return nil
+	}
+
+	if err := uc.repoMetadataRepository.UpdateUpstreamState(ctx, repo.PublicID, state); err != nil {
+		return err
+	}
+
+	log.Warn().Msgf("repository %s moved upstream from %s to %s", repo.Name, repo.State(), state)
+	now := time.Now()
+	repo.UpstreamState = state
+	repo.UpstreamStateChangedAt = &now
+	return nil
+}
+
+// followUpstreamIdentity stores the upstream provider id and name of a repository when they changed
+func (uc *gitRepoUsecase) followUpstreamIdentity(ctx context.Context, repo *domain.RepoMetadata, upstream domain.RepoMetadata) error {
+	if upstream.ProviderID == repo.ProviderID && upstream.Name == repo.Name {
+		return nil
+	}
+
+	previousName := repo.Name
+	repo.ProviderID = upstream.ProviderID
+	repo.Name = upstream.Name
+	repo.URL = upstream.URL
+
+	updated, err := uc.repoMetadataRepository.UpdateRepoIdentity(ctx, *repo, previousName)
+	if err != nil {
+		return err
+	}
+
+	if previousName != updated.Name {
+		log.Info().Msgf("repository %s moved upstream to %s", previousName, updated.Name)
+	}
+	*repo = *updated
+	return nil
 }
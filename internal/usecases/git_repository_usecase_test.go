@@ -0,0 +1,1596 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kenmobility/git-api-service/infra/config"
+	git_mocks "github.com/kenmobility/git-api-service/infra/git/mocks"
+	"github.com/kenmobility/git-api-service/internal/domain"
+	repo_mocks "github.com/kenmobility/git-api-service/internal/repository/mocks"
+	"github.com/kenmobility/git-api-service/pkg/helpers"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestUsecase(t *testing.T) (*gitRepoUsecase, *repo_mocks.MockRepository, *git_mocks.MockGitManagerClient) {
+	ctrl := gomock.NewController(t)
+	store := repo_mocks.NewMockRepository(ctrl)
+	gitClient := git_mocks.NewMockGitManagerClient(ctrl)
+
+	uc := NewGitRepositoryUsecase(store, store, store, store, store, store, store, store, store, store, store, store, store, store, gitClient, config.Config{}).(*gitRepoUsecase)
+	return uc, store, gitClient
+}
+
+func TestUntrackStopsMonitoring(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repoCtx := uc.monitors.start(context.Background(), repo.PublicID)
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	store.EXPECT().
+		DeleteRepoMetadata(gomock.Any(), repo, true).
+		Return(nil).
+		Times(1)
+
+	err := uc.Untrack(context.Background(), repo.PublicID, true)
+
+	require.NoError(t, err)
+	require.Error(t, repoCtx.Err())
+}
+
+func TestUntrackUnknownRepository(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), gomock.Any()).
+		Return(nil, message.ErrNoRecordFound).
+		Times(1)
+
+	err := uc.Untrack(context.Background(), uuid.New().String(), false)
+
+	require.ErrorIs(t, err, message.ErrNoRecordFound)
+}
+
+func TestPauseStopsMonitoring(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repoCtx := uc.monitors.start(context.Background(), repo.PublicID)
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	store.EXPECT().
+		UpdatePausedState(gomock.Any(), repo.PublicID, true).
+		Return(nil).
+		Times(1)
+
+	pausedRepo, err := uc.Pause(context.Background(), repo.PublicID)
+
+	require.NoError(t, err)
+	require.True(t, pausedRepo.Paused)
+	require.Error(t, repoCtx.Err())
+}
+
+func TestResumeNotPausedRepository(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	resumedRepo, err := uc.Resume(context.Background(), repo.PublicID)
+
+	require.NoError(t, err)
+	require.False(t, resumedRepo.Paused)
+}
+
+func TestDiffCommits(t *testing.T) {
+	date := time.Now().Truncate(time.Second)
+	kept := domain.Commit{CommitID: "aaa", Message: "kept", Author: "jane", Date: date}
+	changed := domain.Commit{CommitID: "bbb", Message: "before rewrite", Author: "jane", Date: date}
+	removed := domain.Commit{CommitID: "ccc", Message: "removed", Author: "john", Date: date}
+	added := domain.Commit{CommitID: "ddd", Message: "added", Author: "john", Date: date}
+
+	rewritten := changed
+	rewritten.Message = "after rewrite"
+
+	diff := diffCommits([]domain.Commit{kept, changed, removed}, []domain.Commit{kept, rewritten, added, added})
+
+	require.Equal(t, []domain.Commit{added}, diff.Added)
+	require.Equal(t, []domain.Commit{removed}, diff.Removed)
+	require.Equal(t, []domain.Commit{rewritten}, diff.Changed)
+	require.True(t, diffCommits([]domain.Commit{kept}, []domain.Commit{kept}).IsEmpty())
+
+	// a commit stored before author identities were recorded is updated with them
+	identified := kept
+	identified.AuthorEmail, identified.AuthorLogin = "jane@example.com", "jane"
+	require.Equal(t, []domain.Commit{identified}, diffCommits([]domain.Commit{kept}, []domain.Commit{identified}).Changed)
+}
+
+func TestReindexRejectsFetchingRepository(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.IsFetching = true
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	_, err := uc.Reindex(context.Background(), repo.PublicID)
+
+	require.ErrorIs(t, err, message.ErrRepoFetchInProgress)
+}
+
+func TestRunBackfillSchedulesRetryOfFailedJob(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	job := domain.BackfillJob{ID: 3, RepositoryID: repo.ID, Status: domain.BackfillStatusFailed, Page: 4, Attempts: 1}
+
+	store.EXPECT().UpdateBackfillJob(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "", 4, gomock.Any()).
+		Return(nil, false, message.ErrRateLimitExceeded).
+		Times(1)
+
+	// the retry resumes from the last page, twice as late as the previous one
+	store.EXPECT().
+		UpdateBackfillJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, j domain.BackfillJob) error {
+			require.Equal(t, domain.BackfillStatusFailed, j.Status)
+			require.Equal(t, 2, j.Attempts)
+			require.Equal(t, 4, j.Page)
+			require.NotNil(t, j.RetryAt)
+			require.WithinDuration(t, time.Now().Add(2*backfillRetryDelay), *j.RetryAt, time.Minute)
+			return nil
+		}).
+		Times(1)
+
+	uc.runBackfill(context.Background(), repo, job)
+}
+
+func TestRunBackfillStoppedByRepositoryIsNotFailed(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	job := domain.BackfillJob{ID: 3, RepositoryID: repo.ID, Status: domain.BackfillStatusPending, Page: 4}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.EXPECT().UpdateBackfillJob(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	// pausing the repository aborts the fetch, the job is left running to be resumed, not retried
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "", 4, gomock.Any()).
+		DoAndReturn(func(context.Context, domain.RepoMetadata, time.Time, time.Time, string, int, int) ([]domain.Commit, bool, error) {
+			cancel()
+			return nil, false, context.Canceled
+		}).
+		Times(1)
+
+	uc.runBackfill(ctx, repo, job)
+}
+
+func TestRunBackfillSkipsRunningJob(t *testing.T) {
+	uc, _, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	job := domain.BackfillJob{ID: 3, RepositoryID: repo.ID, Status: domain.BackfillStatusRunning, Page: 4}
+	uc.runningBackfills.Store(job.ID, true)
+
+	// the job is left to its runner, nothing is fetched nor updated
+	uc.runBackfill(context.Background(), repo, job)
+}
+
+func TestReindexRejectsConcurrentClaim(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	// another request claimed the repository between the lookup and the claim
+	store.EXPECT().
+		ClaimFetching(gomock.Any(), repo.PublicID).
+		Return(false, nil).
+		Times(1)
+
+	_, err := uc.Reindex(context.Background(), repo.PublicID)
+
+	require.ErrorIs(t, err, message.ErrRepoFetchInProgress)
+}
+
+func TestRunReindexRecordsSummary(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	date := time.Now().Truncate(time.Second)
+	kept := domain.Commit{CommitID: "kept", Message: "kept", Date: date}
+	removed := domain.Commit{CommitID: "removed", Message: "removed", Date: date}
+	added := domain.Commit{CommitID: "added", Message: "added", Date: date}
+	job := domain.ReindexJob{ID: 7, RepositoryID: repo.ID, Status: domain.ReindexStatusRunning}
+
+	gitClient.EXPECT().FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "", 1, gomock.Any()).
+		Return([]domain.Commit{added}, true, nil).Times(1)
+	gitClient.EXPECT().FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "", 2, gomock.Any()).
+		Return([]domain.Commit{kept}, false, nil).Times(1)
+	store.EXPECT().UpdateReindexJob(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	store.EXPECT().CommitsByRepository(gomock.Any(), repo).Return([]domain.Commit{kept, removed}, nil).Times(1)
+	store.EXPECT().
+		ApplyCommitDiff(gomock.Any(), repo, domain.CommitDiff{Added: []domain.Commit{added}, Removed: []domain.Commit{removed}}).
+		Return(nil).
+		Times(1)
+	store.EXPECT().ReplaceSyncRanges(gomock.Any(), repo, gomock.Any()).Return(nil).Times(1)
+	store.EXPECT().LatestCommit(gomock.Any(), repo).Return(nil, message.ErrNoRecordFound).Times(1)
+
+	// the job only records how many commits changed, the repository is released once it is done
+	store.EXPECT().
+		UpdateReindexJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, j domain.ReindexJob) error {
+			require.Equal(t, domain.ReindexStatusCompleted, j.Status)
+			require.Equal(t, 2, j.CommitsFetched)
+			require.Equal(t, []int{1, 1, 0}, []int{j.Added, j.Removed, j.Changed})
+			require.NotNil(t, j.CompletedAt)
+			return nil
+		}).
+		Times(1)
+	store.EXPECT().UpdateFetchingState(gomock.Any(), repo.PublicID, false).Return(nil).Times(1)
+
+	uc.runReindex(context.Background(), repo, job)
+}
+
+func TestRunReindexRecordsPendingIdentities(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.IdentitiesPending = true
+	stored := domain.Commit{CommitID: "kept", Message: "kept", Author: "Jane", Date: time.Now().Truncate(time.Second)}
+	identified := stored
+	identified.AuthorEmail, identified.AuthorLogin = "jane@example.com", "jane"
+	job := domain.ReindexJob{ID: 7, RepositoryID: repo.ID, Status: domain.ReindexStatusRunning}
+
+	gitClient.EXPECT().FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "", 1, gomock.Any()).
+		Return([]domain.Commit{identified}, false, nil).Times(1)
+	store.EXPECT().CommitsByRepository(gomock.Any(), repo).Return([]domain.Commit{stored}, nil).Times(1)
+	store.EXPECT().
+		ApplyCommitDiff(gomock.Any(), repo, domain.CommitDiff{Changed: []domain.Commit{identified}}).
+		Return(nil).
+		Times(1)
+	store.EXPECT().ReplaceSyncRanges(gomock.Any(), repo, gomock.Any()).Return(nil).Times(1)
+	store.EXPECT().LatestCommit(gomock.Any(), repo).Return(nil, message.ErrNoRecordFound).Times(1)
+	store.EXPECT().UpdateReindexJob(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	// the repository is no longer reindexed on boot once its author identities are recorded
+	store.EXPECT().UpdateIdentitiesPending(gomock.Any(), repo.PublicID, false).Return(nil).Times(1)
+	store.EXPECT().UpdateFetchingState(gomock.Any(), repo.PublicID, false).Return(nil).Times(1)
+
+	uc.runReindex(context.Background(), repo, job)
+}
+
+func TestUpdateTrackingRejectsInvalidWindow(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	since := time.Now()
+	until := since.AddDate(0, -1, 0)
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	_, err := uc.UpdateTracking(context.Background(), repo.PublicID, domain.TrackingOptions{Since: &since, Until: &until})
+
+	require.ErrorIs(t, err, message.ErrInvalidTrackingWindow)
+}
+
+func TestUpdateTrackingPersistsSettings(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.Paused = true
+	repo.IsFetching = true
+	branch := "release"
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	store.EXPECT().
+		UpdateTrackingSettings(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r domain.RepoMetadata) (*domain.RepoMetadata, error) {
+			return &r, nil
+		}).
+		Times(1)
+
+	updated, err := uc.UpdateTracking(context.Background(), repo.PublicID, domain.TrackingOptions{
+		FullHistory:   true,
+		Branch:        &branch,
+		FetchInterval: 30 * time.Minute,
+		PerPage:       100,
+	})
+
+	require.NoError(t, err)
+	require.Nil(t, updated.TrackSince)
+	require.Equal(t, "release", updated.Branch)
+	require.Equal(t, 30*time.Minute, updated.FetchInterval)
+	require.Equal(t, 100, updated.CommitsPerPage)
+}
+
+func TestUpdateTrackingSchedulesBackfill(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := randomRepoMetadata()
+	repo.Paused = true
+	repo.TrackSince = &since
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	store.EXPECT().
+		UpdateTrackingSettings(gomock.Any(), gomock.Any()).
+		Return(&repo, nil).
+		Times(1)
+
+	store.EXPECT().
+		SyncRangesByRepository(gomock.Any(), gomock.Any()).
+		Return([]domain.SyncRange{{Since: since, Until: time.Now()}}, nil).
+		Times(1)
+
+	store.EXPECT().
+		BackfillJobsByRepository(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveBackfillJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, job domain.BackfillJob) (*domain.BackfillJob, error) {
+			require.True(t, job.Since.IsZero())
+			require.Equal(t, since, job.Until)
+			require.Equal(t, domain.BackfillStatusPending, job.Status)
+			return &job, nil
+		}).
+		Times(1)
+
+	_, err := uc.UpdateTracking(context.Background(), repo.PublicID, domain.TrackingOptions{FullHistory: true})
+
+	require.NoError(t, err)
+}
+
+func TestEstimateDuration(t *testing.T) {
+	now := time.Now()
+	rateLimit := domain.RateLimit{Limit: 5000, Remaining: 100, Reset: now.Add(20 * time.Minute)}
+
+	// within the remaining requests only the request time counts
+	require.Equal(t, 50*estimatedRequestDuration, estimateDuration(50, rateLimit, now))
+
+	// exceeding them waits for the reset, then a full window per extra limit of requests
+	require.Equal(t, 200*estimatedRequestDuration+20*time.Minute, estimateDuration(200, rateLimit, now))
+	require.Equal(t, 5200*estimatedRequestDuration+20*time.Minute+rateLimitWindow, estimateDuration(5200, rateLimit, now))
+}
+
+func TestStartRepoIndexingMonitorsIndexedRepository(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.IsFetching = true
+	repo.LastFetchedPage = 1
+	repo.FetchInterval = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	unavailable := errors.New("unavailable")
+
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "", 1, gomock.Any()).
+		Return([]domain.Commit{{CommitID: "c1"}}, false, nil).
+		Times(1)
+	store.EXPECT().SaveCommits(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	store.EXPECT().UpdateRepoMetadata(gomock.Any(), gomock.Any()).Return(&repo, nil).Times(1)
+	store.EXPECT().UpdateFetchingState(gomock.Any(), repo.PublicID, false).Return(nil).Times(1)
+
+	// the data derived from the commits is synced once they are indexed
+	store.EXPECT().SyncRangesByRepository(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(1)
+	store.EXPECT().LatestCommit(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(1)
+	gitClient.EXPECT().FetchBranches(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(nil, false, unavailable).Times(1)
+	store.EXPECT().TagsByRepository(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(2)
+	store.EXPECT().PullRequestsSyncedUntil(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(1)
+	store.EXPECT().LatestIssueUpdate(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(1)
+	store.EXPECT().UnparsedCommits(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(1)
+	store.EXPECT().UnresolvedAuthors(gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(1)
+	store.EXPECT().CommitsPendingChecks(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, unavailable).Times(1)
+
+	// the periodic monitoring starts under the same context, stopping it stops the monitoring
+	store.EXPECT().
+		StargazerCount(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, domain.RepoMetadata) (int, error) {
+			cancel()
+			return 0, unavailable
+		}).
+		Times(1)
+
+	uc.startRepoIndexing(ctx, repo)
+}
+
+func TestReconcileStopsAtKnownHead(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.LastFetchedCommit = "c3"
+	mark := time.Now().Add(-time.Hour)
+
+	store.EXPECT().
+		SyncCursorByRepository(gomock.Any(), repo).
+		Return(&domain.SyncCursor{HeadSHA: "c3", HighWaterMark: mark, KnownHeads: []string{"c3"}}, nil).
+		Times(1)
+
+	// n1 was pushed after c3 although it was authored long before
+	commits := []domain.Commit{
+		{CommitID: "n2", Date: mark.Add(20 * time.Minute), CommittedAt: mark.Add(30 * time.Minute)},
+		{CommitID: "n1", Date: mark.AddDate(0, -1, 0), CommittedAt: mark.Add(10 * time.Minute)},
+		{CommitID: "c3", Date: mark, CommittedAt: mark},
+		{CommitID: "c2", Date: mark.Add(-time.Hour), CommittedAt: mark.Add(-time.Hour)},
+	}
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "", 1, gomock.Any()).
+		Return(commits, true, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveCommits(gomock.Any(), commits).
+		Return(commits[:2], nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveSyncCursor(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, c domain.SyncCursor) error {
+			require.Equal(t, "n2", c.HeadSHA)
+			require.Equal(t, []string{"n2", "c3"}, c.KnownHeads)
+			require.Equal(t, mark.Add(30*time.Minute), c.HighWaterMark)
+			return nil
+		}).
+		Times(1)
+
+	store.EXPECT().
+		UpdateRepoMetadata(gomock.Any(), gomock.Any()).
+		Return(&repo, nil).
+		Times(1)
+
+	store.EXPECT().
+		SyncRangesByRepository(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(1)
+
+	uc.fetchAndReconcileCommits(context.Background(), repo)
+}
+
+func TestReconcileFetchesOldCommitsOfMergedBranch(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.LastFetchedCommit = "c3"
+	mark := time.Now().Add(-time.Hour)
+
+	store.EXPECT().
+		SyncCursorByRepository(gomock.Any(), repo).
+		Return(&domain.SyncCursor{HeadSHA: "c3", HighWaterMark: mark, KnownHeads: []string{"c3"}}, nil).
+		Times(1)
+
+	// m merges into c3 a branch forked from o1 whose commit o2 was committed long before c3, so it is listed after it
+	page1 := []domain.Commit{
+		{CommitID: "m", CommittedAt: mark.Add(10 * time.Minute), ParentSHAs: []string{"c3", "o2"}},
+		{CommitID: "c3", CommittedAt: mark, ParentSHAs: []string{"c2"}},
+		{CommitID: "c2", CommittedAt: mark.Add(-time.Hour), ParentSHAs: []string{"o1"}},
+	}
+	page2 := []domain.Commit{
+		{CommitID: "o2", CommittedAt: mark.AddDate(0, 0, -2), ParentSHAs: []string{"o1"}},
+		{CommitID: "o1", CommittedAt: mark.AddDate(0, 0, -3)},
+	}
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "", 1, gomock.Any()).
+		Return(page1, true, nil).
+		Times(1)
+	store.EXPECT().SaveCommits(gomock.Any(), page1).Return(page1[:1], nil).Times(1)
+	store.EXPECT().StoredCommitIDs(gomock.Any(), repo, []string{"o2"}).Return(nil, nil).Times(1)
+
+	// the walk goes past the known head until the merged commit is found
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "m", 2, gomock.Any()).
+		Return(page2, true, nil).
+		Times(1)
+	store.EXPECT().SaveCommits(gomock.Any(), page2).Return(page2[:1], nil).Times(1)
+
+	store.EXPECT().
+		SaveSyncCursor(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, c domain.SyncCursor) error {
+			require.Equal(t, "m", c.HeadSHA)
+			return nil
+		}).
+		Times(1)
+	store.EXPECT().UpdateRepoMetadata(gomock.Any(), gomock.Any()).Return(&repo, nil).Times(1)
+	store.EXPECT().SyncRangesByRepository(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+
+	uc.fetchAndReconcileCommits(context.Background(), repo)
+}
+
+func TestVerifyHistorySchedulesRefetch(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	repo := randomRepoMetadata()
+	repo.Paused = true
+	repo.TrackSince = &since
+	repo.TrackUntil = &until
+
+	store.EXPECT().
+		CommitsByRepository(gomock.Any(), repo).
+		Return([]domain.Commit{
+			{CommitID: "c2", CommittedAt: since.AddDate(0, 1, 5), ParentSHAs: []string{"c1"}},
+			{CommitID: "c1", CommittedAt: since.AddDate(0, 0, 5)},
+		}, nil).
+		Times(1)
+
+	// the whole window is counted first, then each half of a window whose count differs
+	gitClient.EXPECT().
+		CountCommits(gomock.Any(), repo, since, until).
+		Return(4, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		CountCommits(gomock.Any(), repo, since, since.AddDate(0, 1, 0)).
+		Return(3, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		CountCommits(gomock.Any(), repo, since.AddDate(0, 1, 0), until).
+		Return(1, nil).
+		Times(1)
+
+	store.EXPECT().
+		BackfillJobsByRepository(gomock.Any(), repo).
+		Return(nil, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveBackfillJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, job domain.BackfillJob) (*domain.BackfillJob, error) {
+			require.Equal(t, since, job.Since)
+			require.Equal(t, since.AddDate(0, 1, 0), job.Until)
+			return &job, nil
+		}).
+		Times(1)
+
+	store.EXPECT().
+		SaveIntegrityReport(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r domain.IntegrityReport) (*domain.IntegrityReport, error) {
+			return &r, nil
+		}).
+		Times(1)
+
+	report, err := uc.verifyHistory(context.Background(), repo)
+
+	require.NoError(t, err)
+	require.Equal(t, 0.5, report.Completeness)
+	require.Equal(t, []domain.SyncRange{{Since: since, Until: since.AddDate(0, 1, 0)}}, report.MissingRanges)
+	require.Empty(t, report.OrphanedCommits)
+	require.Equal(t, 1, report.RefetchJobs)
+}
+
+func TestVerifyHistoryCountsCompleteHistoryOnce(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	since := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := randomRepoMetadata()
+	repo.TrackSince = &since
+	repo.TrackUntil = &until
+
+	store.EXPECT().
+		CommitsByRepository(gomock.Any(), repo).
+		Return([]domain.Commit{
+			{CommitID: "c2", CommittedAt: since.AddDate(0, 7, 5), ParentSHAs: []string{"c1"}},
+			{CommitID: "c1", CommittedAt: since.AddDate(0, 0, 5)},
+		}, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		CountCommits(gomock.Any(), repo, since, until).
+		Return(2, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveIntegrityReport(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r domain.IntegrityReport) (*domain.IntegrityReport, error) {
+			return &r, nil
+		}).
+		Times(1)
+
+	report, err := uc.verifyHistory(context.Background(), repo)
+
+	require.NoError(t, err)
+	require.Len(t, report.Buckets, 12)
+	require.Equal(t, float64(1), report.Completeness)
+	require.Zero(t, report.RefetchJobs)
+}
+
+func TestScheduleRefetchesSkipsRefetchedRanges(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.Paused = true
+	month := func(m int) time.Time { return time.Date(2024, time.Month(m), 1, 0, 0, 0, 0, time.UTC) }
+
+	store.EXPECT().
+		BackfillJobsByRepository(gomock.Any(), repo).
+		Return([]domain.BackfillJob{
+			{Since: month(1), Until: month(3), Status: domain.BackfillStatusCompleted},
+			{Since: month(5), Until: month(6), Status: domain.BackfillStatusFailed, Attempts: 1},
+		}, nil).
+		Times(1)
+
+	// a missing range is refetched again once its refetch completed, unless a job is still open for it,
+	// the range of an orphaned commit is only ever refetched once
+	store.EXPECT().
+		SaveBackfillJob(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, job domain.BackfillJob) (*domain.BackfillJob, error) {
+			require.Equal(t, month(2), job.Since)
+			require.Equal(t, month(3), job.Until)
+			return &job, nil
+		}).
+		Times(1)
+
+	jobs, err := uc.scheduleRefetches(context.Background(), repo,
+		[]domain.SyncRange{{Since: month(2), Until: month(3)}, {Since: month(5), Until: month(6)}},
+		[]domain.SyncRange{{Since: month(1), Until: month(3)}})
+
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+}
+
+func TestRefreshRepoMetadataFollowsRename(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.ProviderID = 42
+	previousName := repo.Name
+
+	upstream := repo
+	upstream.Name = "new-owner/" + helpers.RandomString(8)
+
+	renamed := upstream
+	renamed.Aliases = []domain.RepoAlias{domain.NewRepoAlias(previousName, upstream.Name)}
+
+	gitClient.EXPECT().
+		FetchRepoMetadataByID(gomock.Any(), repo.ProviderID).
+		Return(&upstream, nil).
+		Times(1)
+
+	store.EXPECT().
+		UpdateRepoIdentity(gomock.Any(), gomock.Any(), previousName).
+		DoAndReturn(func(_ context.Context, r domain.RepoMetadata, _ string) (*domain.RepoMetadata, error) {
+			require.Equal(t, upstream.Name, r.Name)
+			return &renamed, nil
+		}).
+		Times(1)
+
+	store.EXPECT().
+		UpdateRepoStats(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r domain.RepoMetadata) (*domain.RepoMetadata, error) {
+			return &r, nil
+		}).
+		Times(1)
+
+	store.EXPECT().
+		SaveMetadataSnapshot(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, s domain.RepoMetadataSnapshot) (*domain.RepoMetadataSnapshot, error) {
+			return &s, nil
+		}).
+		Times(1)
+
+	refreshed, err := uc.refreshRepoMetadata(context.Background(), repo)
+
+	require.NoError(t, err)
+	require.Equal(t, upstream.Name, refreshed.Name)
+	require.Equal(t, domain.RepoAliasTransferred, refreshed.Aliases[0].Kind)
+}
+
+func TestRefreshRepoMetadataAppendsSnapshot(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.ProviderID = 42
+	upstream := repo
+	upstream.StarsCount = repo.StarsCount + 10
+	upstream.OpenIssuesCount = 0
+
+	gitClient.EXPECT().
+		FetchRepoMetadataByID(gomock.Any(), repo.ProviderID).
+		Return(&upstream, nil).
+		Times(1)
+
+	store.EXPECT().
+		UpdateRepoStats(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, r domain.RepoMetadata) (*domain.RepoMetadata, error) {
+			require.Equal(t, upstream.StarsCount, r.StarsCount)
+			require.Zero(t, r.OpenIssuesCount)
+			return &r, nil
+		}).
+		Times(1)
+
+	store.EXPECT().
+		SaveMetadataSnapshot(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, s domain.RepoMetadataSnapshot) (*domain.RepoMetadataSnapshot, error) {
+			require.Equal(t, repo.ID, s.RepositoryID)
+			require.Equal(t, upstream.StarsCount, s.StarsCount)
+			return &s, nil
+		}).
+		Times(1)
+
+	refreshed, err := uc.refreshRepoMetadata(context.Background(), repo)
+
+	require.NoError(t, err)
+	require.Equal(t, upstream.StarsCount, refreshed.StarsCount)
+}
+
+func TestMetadataHistoryRejectsInvalidInterval(t *testing.T) {
+	uc, _, _ := newTestUsecase(t)
+
+	_, err := uc.MetadataHistory(context.Background(), uuid.New().String(), time.Time{}, time.Time{}, "year")
+	require.ErrorIs(t, err, message.ErrInvalidInterval)
+
+	now := time.Now()
+	_, err = uc.MetadataHistory(context.Background(), uuid.New().String(), now, now.Add(-time.Hour), "")
+	require.ErrorIs(t, err, message.ErrInvalidHistoryWindow)
+}
+
+func TestSyncStargazersResumesFromStoredStars(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.StarsCount = 251
+	stargazers := []domain.Stargazer{{RepositoryID: repo.ID, Login: helpers.RandomString(8), StarredAt: time.Now()}}
+
+	store.EXPECT().
+		StargazerCount(gomock.Any(), repo).
+		Return(250, nil).
+		Times(1)
+
+	// resumes one page before the page of the first unstored star
+	gitClient.EXPECT().
+		FetchStargazers(gomock.Any(), repo, 2, stargazersPerPage).
+		Return(stargazers, true, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		FetchStargazers(gomock.Any(), repo, 3, stargazersPerPage).
+		Return(stargazers, false, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveStargazers(gomock.Any(), stargazers).
+		Return(0, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveStargazers(gomock.Any(), stargazers).
+		Return(1, nil).
+		Times(1)
+
+	saved, err := uc.syncStargazers(context.Background(), repo)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, saved)
+}
+
+func TestSyncStargazersRemovesUnstarredStars(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.StarsCount = 1
+	stargazers := []domain.Stargazer{{RepositoryID: repo.ID, Login: helpers.RandomString(8), StarredAt: time.Now()}}
+
+	store.EXPECT().
+		StargazerCount(gomock.Any(), repo).
+		Return(2, nil).
+		Times(1)
+
+	// the incremental sync and the full listing both fetch the first page
+	gitClient.EXPECT().
+		FetchStargazers(gomock.Any(), repo, 1, stargazersPerPage).
+		Return(stargazers, false, nil).
+		Times(2)
+
+	store.EXPECT().
+		SaveStargazers(gomock.Any(), stargazers).
+		Return(0, nil).
+		Times(1)
+
+	store.EXPECT().
+		DeleteStargazersExcept(gomock.Any(), repo, []string{stargazers[0].Login}, gomock.Any()).
+		Return(1, nil).
+		Times(1)
+
+	saved, err := uc.syncStargazers(context.Background(), repo)
+
+	require.NoError(t, err)
+	require.Zero(t, saved)
+}
+
+func TestSyncStargazersStopsAtPageLimit(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.StarsCount = 50000
+	stargazers := []domain.Stargazer{{RepositoryID: repo.ID, Login: helpers.RandomString(8), StarredAt: time.Now()}}
+
+	store.EXPECT().
+		StargazerCount(gomock.Any(), repo).
+		Return(stargazersMaxPages*stargazersPerPage, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		FetchStargazers(gomock.Any(), repo, stargazersMaxPages, stargazersPerPage).
+		Return(stargazers, true, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveStargazers(gomock.Any(), stargazers).
+		Return(0, nil).
+		Times(1)
+
+	saved, err := uc.syncStargazers(context.Background(), repo)
+
+	require.NoError(t, err)
+	require.Zero(t, saved)
+}
+
+func TestRefreshRepoMetadataMarksGoneRepository(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.ProviderID = 42
+
+	gitClient.EXPECT().
+		FetchRepoMetadataByID(gomock.Any(), repo.ProviderID).
+		Return(nil, message.ErrRepoGone).
+		Times(1)
+
+	store.EXPECT().
+		UpdateUpstreamState(gomock.Any(), repo.PublicID, domain.UpstreamStateGone).
+		Return(nil).
+		Times(1)
+
+	_, err := uc.refreshRepoMetadata(context.Background(), repo)
+
+	require.ErrorIs(t, err, message.ErrRepoGone)
+}
+
+func TestStartIndexingRejectsTrackedName(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+
+	store.EXPECT().
+		RepoMetadataByName(gomock.Any(), repo.Name).
+		Return(&repo, nil).
+		Times(1)
+
+	_, err := uc.StartIndexing(context.Background(), repo.Name, domain.TrackingOptions{})
+
+	require.ErrorIs(t, err, message.ErrRepoAlreadyAdded)
+}
+
+func TestStartIndexingRejectsPreviousNameOfTrackedRepository(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	previousName := "old-owner/" + helpers.RandomString(8)
+	repo := randomRepoMetadata()
+	repo.ProviderID = 42
+	repo.Aliases = []domain.RepoAlias{domain.NewRepoAlias(previousName, repo.Name)}
+
+	store.EXPECT().
+		RepoMetadataByName(gomock.Any(), previousName).
+		Return(&repo, nil).
+		Times(1)
+
+	// the previous name still redirects to the tracked repository upstream
+	gitClient.EXPECT().
+		FetchRepoMetadata(gomock.Any(), previousName).
+		Return(&repo, nil).
+		Times(1)
+
+	store.EXPECT().
+		RepoMetadataByProviderID(gomock.Any(), repo.ProviderID).
+		Return(&repo, nil).
+		Times(1)
+
+	_, err := uc.StartIndexing(context.Background(), previousName, domain.TrackingOptions{})
+
+	require.ErrorIs(t, err, message.ErrRepoAlreadyAdded)
+}
+
+func TestGetAllFiltersByUpstreamState(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	active := randomRepoMetadata()
+	archived := randomRepoMetadata()
+	archived.UpstreamState = domain.UpstreamStateArchived
+
+	store.EXPECT().
+		AllRepoMetadata(gomock.Any()).
+		Return([]domain.RepoMetadata{active, archived}, nil).
+		Times(2)
+
+	repos, err := uc.GetAll(context.Background(), domain.UpstreamStateArchived)
+	require.NoError(t, err)
+	require.Equal(t, []domain.RepoMetadata{archived}, repos)
+
+	repos, err = uc.GetAll(context.Background(), domain.UpstreamStateActive)
+	require.NoError(t, err)
+	require.Equal(t, []domain.RepoMetadata{active}, repos)
+
+	_, err = uc.GetAll(context.Background(), "deleted")
+	require.ErrorIs(t, err, message.ErrInvalidUpstreamState)
+}
+
+func TestSyncBranchesIngestsMatchingBranches(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.DefaultBranch = "main"
+	repo.TrackedBranches = []string{"release/*"}
+
+	gitClient.EXPECT().
+		FetchBranches(gomock.Any(), repo, 1, branchesPerPage).
+		Return([]domain.Branch{
+			{Name: "main", HeadSHA: "m1"},
+			{Name: "release/1.0", HeadSHA: "r1"},
+			{Name: "feature/x", HeadSHA: "f1"},
+		}, false, nil).
+		Times(1)
+
+	store.EXPECT().
+		BranchesByRepository(gomock.Any(), repo).
+		Return([]domain.Branch{
+			{Name: "main", HeadSHA: "m1", SyncedSHA: "m1"},
+			{Name: "release/1.0", HeadSHA: "c2", SyncedSHA: "c2"},
+			{Name: "release/0.9", SyncedSHA: "r0"},
+		}, nil).
+		Times(1)
+
+	newest := []domain.Commit{{CommitID: "r1"}, {CommitID: "c2"}}
+	shared := []domain.Commit{{CommitID: "c1"}}
+
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "r1", 1, gomock.Any()).
+		Return(newest, true, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "r1", 2, gomock.Any()).
+		Return(shared, true, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveCommits(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(2)
+
+	store.EXPECT().
+		SaveBranchCommits(gomock.Any(), repo, "release/1.0", []string{"r1", "c2"}).
+		Return(1, nil).
+		Times(1)
+
+	// the second page was already recorded on the branch after its previous head, the walk stops there
+	store.EXPECT().
+		SaveBranchCommits(gomock.Any(), repo, "release/1.0", []string{"c1"}).
+		Return(0, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveBranch(gomock.Any(), domain.Branch{RepositoryID: repo.ID, Name: "release/1.0", HeadSHA: "r1", SyncedSHA: "r1"}).
+		Return(&domain.Branch{Name: "release/1.0"}, nil).
+		Times(1)
+
+	store.EXPECT().
+		DeleteBranch(gomock.Any(), repo, "release/0.9").
+		Return(nil).
+		Times(1)
+
+	err := uc.syncBranches(context.Background(), repo)
+
+	require.NoError(t, err)
+}
+
+func TestSyncBranchPrunesCommitsOfRewrittenBranch(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	branch := domain.Branch{Name: "release/1.0", HeadSHA: "r1"}
+
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "r1", 1, gomock.Any()).
+		Return([]domain.Commit{{CommitID: "r1"}}, true, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "r1", 2, gomock.Any()).
+		Return([]domain.Commit{{CommitID: "c1"}}, true, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		FetchCommits(gomock.Any(), repo, gomock.Any(), gomock.Any(), "r1", 3, gomock.Any()).
+		Return([]domain.Commit{{CommitID: "c0"}}, false, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveCommits(gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(3)
+
+	store.EXPECT().
+		SaveBranchCommits(gomock.Any(), repo, branch.Name, []string{"r1"}).
+		Return(1, nil).
+		Times(1)
+
+	// the previous head was force-pushed away, the walk goes on past the recorded commits
+	store.EXPECT().
+		SaveBranchCommits(gomock.Any(), repo, branch.Name, gomock.Any()).
+		Return(0, nil).
+		Times(2)
+
+	store.EXPECT().
+		PruneBranchCommits(gomock.Any(), repo, branch.Name, []string{"r1", "c1", "c0"}).
+		Return(1, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveBranch(gomock.Any(), domain.Branch{RepositoryID: repo.ID, Name: branch.Name, HeadSHA: "r1", SyncedSHA: "r1"}).
+		Return(&branch, nil).
+		Times(1)
+
+	err := uc.syncBranch(context.Background(), repo, branch, "rewritten")
+
+	require.NoError(t, err)
+}
+
+func TestPrimaryBranchCommitsDropsOtherBranchCommits(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.DefaultBranch = "main"
+	repo.TrackedBranches = []string{"release/*"}
+	commits := []domain.Commit{{CommitID: "a"}, {CommitID: "b"}, {CommitID: "c"}}
+
+	store.EXPECT().
+		BranchesByCommitIDs(gomock.Any(), repo, []string{"a", "b", "c"}).
+		Return(map[string][]string{"a": {"main", "release/1.0"}, "b": {"release/1.0"}}, nil).
+		Times(1)
+
+	kept, err := uc.primaryBranchCommits(context.Background(), repo, commits)
+
+	require.NoError(t, err)
+	require.Equal(t, []domain.Commit{{CommitID: "a"}, {CommitID: "c"}}, kept)
+}
+
+func TestSyncReleasesRecordsFirstReleases(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	published := time.Now()
+	tags := []domain.Tag{{RepositoryID: repo.ID, Name: "v1.0", SHA: "b"}}
+	releases := []domain.Release{{RepositoryID: repo.ID, Tag: "v1.0", PublishedAt: &published}}
+
+	store.EXPECT().TagsByRepository(gomock.Any(), repo).Return(nil, nil).Times(1)
+	gitClient.EXPECT().
+		FetchTags(gomock.Any(), repo, 1, releasesPerPage).
+		Return(tags, false, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		FetchReleases(gomock.Any(), repo, 1, releasesPerPage).
+		Return(releases, false, nil).
+		Times(1)
+
+	store.EXPECT().SaveTags(gomock.Any(), tags).Return(nil).Times(1)
+	store.EXPECT().SaveReleases(gomock.Any(), releases).Return(nil).Times(1)
+
+	store.EXPECT().
+		ReleasesByRepository(gomock.Any(), repo).
+		Return(nil, nil).
+		Times(1)
+
+	stored := releases[0]
+	stored.TagSHA = "b"
+	store.EXPECT().
+		ReleasesByRepository(gomock.Any(), repo).
+		Return([]domain.Release{stored}, nil).
+		Times(1)
+
+	store.EXPECT().
+		UnreleasedCommits(gomock.Any(), repo).
+		Return([]domain.Commit{{CommitID: "a"}, {CommitID: "b", ParentSHAs: []string{"a"}}, {CommitID: "c", ParentSHAs: []string{"b"}}}, nil).
+		Times(1)
+	store.EXPECT().ReleasedChildren(gomock.Any(), repo).Return(nil, nil).Times(1)
+
+	store.EXPECT().
+		UpdateFirstReleases(gomock.Any(), repo, map[string]string{"a": "v1.0", "b": "v1.0"}, []string{"v1.0"}).
+		Return(nil).
+		Times(1)
+
+	err := uc.syncReleases(context.Background(), repo)
+
+	require.NoError(t, err)
+}
+
+func TestSyncReleasesSkipsSyncedReleases(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	published := time.Now()
+	tag := domain.Tag{RepositoryID: repo.ID, Name: "v1.0", SHA: "b"}
+	synced := domain.Release{RepositoryID: repo.ID, Tag: "v1.0", TagSHA: "b", PublishedAt: &published, CommitsSynced: true}
+
+	// the first pages hold the stored tag and release, the older pages are not listed
+	store.EXPECT().TagsByRepository(gomock.Any(), repo).Return([]domain.Tag{tag}, nil).Times(1)
+	gitClient.EXPECT().
+		FetchTags(gomock.Any(), repo, 1, releasesPerPage).
+		Return([]domain.Tag{tag}, true, nil).
+		Times(1)
+
+	store.EXPECT().SaveTags(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	store.EXPECT().
+		ReleasesByRepository(gomock.Any(), repo).
+		Return([]domain.Release{synced}, nil).
+		Times(2)
+
+	gitClient.EXPECT().
+		FetchReleases(gomock.Any(), repo, 1, releasesPerPage).
+		Return([]domain.Release{synced}, true, nil).
+		Times(1)
+
+	store.EXPECT().SaveReleases(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	store.EXPECT().UnreleasedCommits(gomock.Any(), repo).Return(nil, nil).Times(1)
+
+	err := uc.syncReleases(context.Background(), repo)
+
+	require.NoError(t, err)
+}
+
+func TestSyncReleasesReleasesCommitsStoredAfterTheirRelease(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	published := time.Now()
+	tag := domain.Tag{RepositoryID: repo.ID, Name: "v1.0", SHA: "b"}
+	synced := domain.Release{RepositoryID: repo.ID, Tag: "v1.0", TagSHA: "b", PublishedAt: &published, CommitsSynced: true}
+
+	store.EXPECT().TagsByRepository(gomock.Any(), repo).Return([]domain.Tag{tag}, nil).Times(1)
+	gitClient.EXPECT().FetchTags(gomock.Any(), repo, 1, releasesPerPage).Return([]domain.Tag{tag}, false, nil).Times(1)
+	store.EXPECT().SaveTags(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	store.EXPECT().ReleasesByRepository(gomock.Any(), repo).Return([]domain.Release{synced}, nil).Times(2)
+	gitClient.EXPECT().FetchReleases(gomock.Any(), repo, 1, releasesPerPage).Return([]domain.Release{synced}, false, nil).Times(1)
+	store.EXPECT().SaveReleases(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	// a was backfilled after v1.0 was synced, it is released with its released child b
+	store.EXPECT().UnreleasedCommits(gomock.Any(), repo).Return([]domain.Commit{{CommitID: "a"}}, nil).Times(1)
+	store.EXPECT().
+		ReleasedChildren(gomock.Any(), repo).
+		Return([]domain.Commit{{CommitID: "b", ParentSHAs: []string{"a"}, FirstReleasedIn: "v1.0"}}, nil).
+		Times(1)
+	store.EXPECT().UpdateFirstReleases(gomock.Any(), repo, map[string]string{"a": "v1.0"}, nil).Return(nil).Times(1)
+
+	err := uc.syncReleases(context.Background(), repo)
+
+	require.NoError(t, err)
+}
+
+func TestSyncPullRequestsStopsAtLastSync(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	lastSync := time.Now().Add(-2 * time.Hour)
+	mergedAt := time.Now()
+	merged := domain.PullRequest{Number: 2, State: domain.PullRequestMerged, MergeCommitSHA: "squash", MergedAt: &mergedAt, UpdatedAt: time.Now()}
+	open := domain.PullRequest{Number: 3, State: domain.PullRequestOpen, UpdatedAt: time.Now().Add(time.Minute)}
+	interrupted := domain.PullRequest{Number: 4, State: domain.PullRequestClosed, UpdatedAt: time.Now().Add(-time.Hour)}
+	synced := domain.PullRequest{Number: 1, State: domain.PullRequestClosed, UpdatedAt: lastSync}
+
+	store.EXPECT().
+		PullRequestsSyncedUntil(gomock.Any(), repo).
+		Return(&lastSync, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		FetchPullRequests(gomock.Any(), repo, 1, pullRequestsPerPage).
+		Return([]domain.PullRequest{open, merged}, true, nil).
+		Times(1)
+
+	store.EXPECT().
+		PullRequestUpdates(gomock.Any(), repo, []int{3, 2}).
+		Return(map[int]time.Time{2: lastSync}, nil).
+		Times(1)
+
+	gitClient.EXPECT().CountPullRequestReviews(gomock.Any(), repo, 3).Return(0, nil).Times(1)
+	gitClient.EXPECT().CountPullRequestReviews(gomock.Any(), repo, 2).Return(2, nil).Times(1)
+
+	// only the merged pull request is linked to its commits and merge commit
+	gitClient.EXPECT().
+		FetchPullRequestCommits(gomock.Any(), repo, 2, 1, pullRequestsPerPage).
+		Return([]string{"a", "b"}, false, nil).
+		Times(1)
+
+	store.EXPECT().
+		SavePullRequestCommits(gomock.Any(), repo, 2, []string{"a", "b", "squash"}).
+		Return(nil).
+		Times(1)
+
+	// each page is saved as soon as it is linked
+	merged.ReviewCount = 2
+	store.EXPECT().
+		SavePullRequests(gomock.Any(), []domain.PullRequest{open, merged}).
+		Return(nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		FetchPullRequests(gomock.Any(), repo, 2, pullRequestsPerPage).
+		Return([]domain.PullRequest{interrupted, synced}, true, nil).
+		Times(1)
+
+	// the pull request saved by an interrupted sync is not fetched again
+	store.EXPECT().
+		PullRequestUpdates(gomock.Any(), repo, []int{4, 1}).
+		Return(map[int]time.Time{4: interrupted.UpdatedAt, 1: lastSync}, nil).
+		Times(1)
+
+	store.EXPECT().
+		SavePullRequests(gomock.Any(), gomock.Nil()).
+		Return(nil).
+		Times(1)
+
+	store.EXPECT().
+		SavePullRequestsSyncedUntil(gomock.Any(), repo, open.UpdatedAt).
+		Return(nil).
+		Times(1)
+
+	err := uc.syncPullRequests(context.Background(), repo)
+
+	require.NoError(t, err)
+}
+
+func TestSyncCommitReferencesParsesUnparsedCommits(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+	uc.config.TicketPatterns = []*regexp.Regexp{regexp.MustCompile(`[A-Z][A-Z0-9]+-[0-9]+`)}
+
+	repo := randomRepoMetadata()
+	commits := []domain.Commit{
+		{CommitID: "a", Message: "Fixes #4 and OPS-12"},
+		{CommitID: "b", Message: "Bump dependencies"},
+	}
+
+	store.EXPECT().
+		UnparsedCommits(gomock.Any(), repo, referenceParseBatchSize).
+		Return(commits, nil).
+		Times(1)
+
+	// every commit is marked parsed, including those without references
+	store.EXPECT().
+		SaveCommitReferences(gomock.Any(), repo, []string{"a", "b"}, []domain.CommitReference{
+			{RepositoryID: repo.ID, CommitID: "a", Kind: domain.ReferenceIssue, Key: repo.Name + "#4", IssueNumber: 4, Closing: true},
+			{RepositoryID: repo.ID, CommitID: "a", Kind: domain.ReferenceTicket, Key: "OPS-12"},
+		}).
+		Return(nil).
+		Times(1)
+
+	err := uc.syncCommitReferences(context.Background(), repo)
+
+	require.NoError(t, err)
+}
+
+func TestSyncChecksSettlesCompletedChecksOfOldCommits(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	recent := domain.Commit{CommitID: "recent", CommittedAt: time.Now().Add(-time.Hour)}
+	old := domain.Commit{CommitID: "old", CommittedAt: time.Now().Add(-2 * checksSettleAfter)}
+	running := domain.Commit{CommitID: "running", CommittedAt: time.Now().Add(-2 * checksSettleAfter)}
+
+	store.EXPECT().
+		CommitsPendingChecks(gomock.Any(), repo, gomock.Any(), checksSyncBatchSize).
+		Return([]domain.Commit{recent, old, running}, nil).
+		Times(1)
+
+	completedAt := time.Now()
+	passed := func(sha string) []domain.CheckRun {
+		return []domain.CheckRun{{CommitID: sha, Kind: domain.CheckKindCheckRun, Name: "test", Conclusion: "success", CompletedAt: &completedAt}}
+	}
+	for _, c := range []domain.Commit{recent, old} {
+		gitClient.EXPECT().FetchCommitStatuses(gomock.Any(), repo, c.CommitID, 1, checksPerPage).Return(nil, false, nil).Times(1)
+		gitClient.EXPECT().FetchCheckRuns(gomock.Any(), repo, c.CommitID, 1, checksPerPage).Return(passed(c.CommitID), false, nil).Times(1)
+	}
+	pending := []domain.CheckRun{{CommitID: "running", Kind: domain.CheckKindStatus, Name: "ci/build"}}
+	gitClient.EXPECT().FetchCommitStatuses(gomock.Any(), repo, "running", 1, checksPerPage).Return(pending, false, nil).Times(1)
+	gitClient.EXPECT().FetchCheckRuns(gomock.Any(), repo, "running", 1, checksPerPage).Return(nil, false, nil).Times(1)
+
+	// the checks of a recent commit may still be re-run, and running checks are not final
+	store.EXPECT().SaveCheckRuns(gomock.Any(), repo, "recent", passed("recent"), false).Return(nil).Times(1)
+	store.EXPECT().SaveCheckRuns(gomock.Any(), repo, "old", passed("old"), true).Return(nil).Times(1)
+	store.EXPECT().SaveCheckRuns(gomock.Any(), repo, "running", pending, false).Return(nil).Times(1)
+
+	err := uc.syncChecks(context.Background(), repo)
+
+	require.NoError(t, err)
+}
+
+func TestEnrichRepositoryStaysWithinBudget(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	store.EXPECT().
+		UnenrichedCommits(gomock.Any(), repo, 4).
+		Return([]domain.Commit{{CommitID: "a"}, {CommitID: "b"}, {CommitID: "c"}}, nil).
+		Times(1)
+
+	// the files of a are listed on two pages
+	gitClient.EXPECT().
+		FetchCommitDetail(gomock.Any(), repo, "a", 1, commitFilesPerPage).
+		Return(&domain.CommitDetail{Stats: domain.CommitStats{Additions: 3, Deletions: 1}, Files: []domain.FileChange{{CommitID: "a", Path: "x.go"}}}, true, nil).
+		Times(1)
+	gitClient.EXPECT().
+		FetchCommitDetail(gomock.Any(), repo, "a", 2, commitFilesPerPage).
+		Return(&domain.CommitDetail{Stats: domain.CommitStats{Additions: 3, Deletions: 1}, Files: []domain.FileChange{{CommitID: "a", Path: "y.go"}}}, false, nil).
+		Times(1)
+	store.EXPECT().
+		SaveCommitDetail(gomock.Any(), "a", domain.CommitDetail{
+			Stats: domain.CommitStats{Additions: 3, Deletions: 1, FilesChanged: 2},
+			Files: []domain.FileChange{{CommitID: "a", Path: "x.go"}, {CommitID: "a", Path: "y.go"}},
+		}).
+		Return(nil).
+		Times(1)
+
+	// b is not available upstream and is not retried
+	gitClient.EXPECT().
+		FetchCommitDetail(gomock.Any(), repo, "b", 1, commitFilesPerPage).
+		Return(nil, false, message.ErrCommitDetailUnavailable).
+		Times(1)
+	store.EXPECT().
+		SaveEnrichmentFailure(gomock.Any(), "b", message.ErrCommitDetailUnavailable.Error()).
+		Return(nil).
+		Times(1)
+
+	// c fails with a transient error and is retried later, the budget is then spent
+	gitClient.EXPECT().
+		FetchCommitDetail(gomock.Any(), repo, "c", 1, commitFilesPerPage).
+		Return(nil, false, errors.New("failed to fetch commit detail; status code: 502")).
+		Times(1)
+	store.EXPECT().
+		SaveEnrichmentRetry(gomock.Any(), "c", "failed to fetch commit detail; status code: 502", gomock.Any(), enrichmentMaxAttempts).
+		Return(nil).
+		Times(1)
+
+	used, err := uc.enrichRepository(context.Background(), repo, 4)
+
+	require.NoError(t, err)
+	require.Equal(t, 4, used)
+}
+
+func TestEnrichCommitsSkipsFailingRepository(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+	uc.config.EnrichmentBudget = 10
+
+	failing, next := randomRepoMetadata(), randomRepoMetadata()
+
+	gitClient.EXPECT().FetchRateLimit(gomock.Any()).Return(&domain.RateLimit{Limit: 100, Remaining: 100}, nil).Times(1)
+	store.EXPECT().AllRepoMetadata(gomock.Any()).Return([]domain.RepoMetadata{failing, next}, nil).Times(1)
+
+	dbErr := errors.New("connection reset")
+	store.EXPECT().UnenrichedCommits(gomock.Any(), failing, 5).Return(nil, dbErr).Times(1)
+	store.EXPECT().SaveEnrichmentRun(gomock.Any(), failing, gomock.Any(), dbErr.Error()).Return(nil).Times(1)
+
+	// the next repository is still enriched
+	store.EXPECT().UnenrichedCommits(gomock.Any(), next, 10).Return(nil, nil).Times(1)
+	store.EXPECT().SaveEnrichmentRun(gomock.Any(), next, gomock.Any(), "").Return(nil).Times(1)
+
+	uc.enrichCommits(context.Background())
+}
+
+func TestEnrichCommitsYieldsToIndexingJobs(t *testing.T) {
+	uc, _, _ := newTestUsecase(t)
+	uc.config.EnrichmentBudget = 100
+
+	done := uc.trackIndexing()
+	defer done()
+
+	// no request is sent while a repository is being indexed
+	uc.enrichCommits(context.Background())
+}
+
+func TestResolveAuthorMergesEmailContributorIntoLogin(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	author := domain.Identity{Name: "Jane Doe", Email: "jane@example.com", Login: "jane"}
+	byEmail := domain.Contributor{ID: 2, PublicID: "by-email", Name: "Jane", Email: "jane@example.com"}
+	identities := []domain.ContributorIdentity{
+		{Key: "login:jane", Name: "Jane Doe", Email: "jane@example.com", Login: "jane", Source: domain.IdentitySourceCommit},
+		{Key: "email:jane@example.com", Name: "Jane Doe", Email: "jane@example.com", Source: domain.IdentitySourceLogin},
+	}
+
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "login:jane").Return(nil, message.ErrNoRecordFound).Times(1)
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "email:jane@example.com").Return(&byEmail, nil).Times(1)
+	store.EXPECT().MergeContributors(gomock.Any(), byEmail, []domain.Contributor{}).Return(nil).Times(1)
+
+	// the contributor known by the email learns the login of the author
+	withLogin := byEmail
+	withLogin.Login = "jane"
+	store.EXPECT().UpdateContributor(gomock.Any(), withLogin).Return(nil).Times(1)
+	store.EXPECT().AddContributorIdentities(gomock.Any(), withLogin, identities).Return(nil).Times(1)
+
+	err := uc.resolveAuthor(context.Background(), author)
+
+	require.NoError(t, err)
+}
+
+func TestResolveAuthorSkipsEmailOfAnotherLogin(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	// a shared email already resolved to the contributor of another login
+	author := domain.Identity{Name: "Jane Doe", Email: "dev@example.com", Login: "jane"}
+	other := domain.Contributor{ID: 2, PublicID: "john", Name: "John", Email: "dev@example.com", Login: "john"}
+
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "login:jane").Return(nil, message.ErrNoRecordFound).Times(1)
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "email:dev@example.com").Return(&other, nil).Times(1)
+
+	// the login gets a contributor of its own, the email stays with the other login
+	store.EXPECT().
+		CreateContributor(gomock.Any(), gomock.Any(), []domain.ContributorIdentity{
+			{Key: "login:jane", Name: "Jane Doe", Email: "dev@example.com", Login: "jane", Source: domain.IdentitySourceCommit},
+		}).
+		DoAndReturn(func(_ context.Context, c domain.Contributor, _ []domain.ContributorIdentity) (*domain.Contributor, error) {
+			require.Equal(t, "jane", c.Login)
+			return &c, nil
+		}).
+		Times(1)
+
+	err := uc.resolveAuthor(context.Background(), author)
+
+	require.NoError(t, err)
+}
+
+func TestApplyMailmapEntryMergesIntoProperEmail(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	old := domain.Contributor{ID: 1, PublicID: "old", Name: "jdoe", Email: "jdoe@laptop.local", Identities: []domain.ContributorIdentity{
+		{Key: "email:jdoe@laptop.local", Name: "jdoe", Email: "jdoe@laptop.local"},
+	}}
+	proper := domain.Contributor{ID: 2, PublicID: "proper", Name: "Jane", Email: "jane@example.com", Identities: []domain.ContributorIdentity{
+		{Key: "email:jane@example.com", Name: "Jane", Email: "jane@example.com"},
+	}}
+
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "email:jdoe@laptop.local").Return(&old, nil).Times(1)
+	store.EXPECT().AuthoredOutsideRepository(gomock.Any(), repo, []string{"email:jdoe@laptop.local"}).Return(false, nil).Times(1)
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "email:jane@example.com").Return(&proper, nil).Times(1)
+	store.EXPECT().MergeContributors(gomock.Any(), proper, []domain.Contributor{old}).Return(nil).Times(1)
+	store.EXPECT().AuthoredOutsideRepository(gomock.Any(), repo, []string{"email:jane@example.com"}).Return(false, nil).Times(1)
+
+	renamed := proper
+	renamed.Name = "Jane Doe"
+	store.EXPECT().UpdateContributor(gomock.Any(), renamed).Return(nil).Times(1)
+
+	err := uc.applyMailmapEntry(context.Background(), repo, domain.MailmapEntry{
+		ProperName:  "Jane Doe",
+		ProperEmail: "jane@example.com",
+		CommitEmail: "jdoe@laptop.local",
+	})
+
+	require.NoError(t, err)
+}
+
+func TestApplyMailmapEntrySkipsContributorOfOtherRepositories(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	shared := domain.Contributor{ID: 1, PublicID: "shared", Name: "jdoe", Identities: []domain.ContributorIdentity{
+		{Key: "email:jdoe@laptop.local", Name: "jdoe", Email: "jdoe@laptop.local"},
+	}}
+
+	// the contributor also authors in other repositories, the .mailmap of this one does not rename it
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "email:jdoe@laptop.local").Return(&shared, nil).Times(1)
+	store.EXPECT().AuthoredOutsideRepository(gomock.Any(), repo, []string{"email:jdoe@laptop.local"}).Return(true, nil).Times(1)
+
+	err := uc.applyMailmapEntry(context.Background(), repo, domain.MailmapEntry{
+		ProperName:  "Jane Doe",
+		CommitEmail: "jdoe@laptop.local",
+	})
+
+	require.NoError(t, err)
+}
+
+func TestSyncContributorsSkipsUnchangedMailmap(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.MailmapETag = `"abc"`
+
+	// no author was resolved and the file is unchanged, no contributor is mapped again
+	store.EXPECT().UnresolvedAuthors(gomock.Any(), repo).Return(nil, nil).Times(1)
+	gitClient.EXPECT().FetchMailmap(gomock.Any(), repo, `"abc"`).Return(nil, nil).Times(1)
+
+	err := uc.syncContributors(context.Background(), repo)
+
+	require.NoError(t, err)
+}
+
+func TestSyncContributorsAppliesChangedMailmap(t *testing.T) {
+	uc, store, gitClient := newTestUsecase(t)
+
+	repo := randomRepoMetadata()
+	repo.MailmapETag = `"abc"`
+
+	store.EXPECT().UnresolvedAuthors(gomock.Any(), repo).Return(nil, nil).Times(1)
+	gitClient.EXPECT().
+		FetchMailmap(gomock.Any(), repo, `"abc"`).
+		Return(&domain.Mailmap{Content: "Jane Doe <jdoe@laptop.local>\n", ETag: `"def"`}, nil).
+		Times(1)
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "email:jdoe@laptop.local").Return(nil, message.ErrNoRecordFound).Times(1)
+
+	// the new content is not applied again until it changes
+	store.EXPECT().UpdateMailmapETag(gomock.Any(), repo.PublicID, `"def"`).Return(nil).Times(1)
+
+	err := uc.syncContributors(context.Background(), repo)
+
+	require.NoError(t, err)
+}
+
+func TestApplyMailmapEntrySkipsOtherCommitName(t *testing.T) {
+	uc, store, _ := newTestUsecase(t)
+
+	shared := domain.Contributor{ID: 1, PublicID: "shared", Identities: []domain.ContributorIdentity{
+		{Key: "email:build@example.com", Name: "Build Bot", Email: "build@example.com"},
+	}}
+	store.EXPECT().ContributorByIdentityKey(gomock.Any(), "email:build@example.com").Return(&shared, nil).Times(1)
+
+	err := uc.applyMailmapEntry(context.Background(), randomRepoMetadata(), domain.MailmapEntry{
+		ProperName:  "John Roe",
+		CommitName:  "Johnny",
+		CommitEmail: "build@example.com",
+	})
+
+	require.NoError(t, err)
+}
+
+func randomRepoMetadata() domain.RepoMetadata {
+	return domain.RepoMetadata{
+		PublicID: uuid.New().String(),
+		Name:     helpers.RandomRepositoryName(),
+		URL:      helpers.RandomRepositoryUrl(),
+		Language: "Go",
+	}
+}
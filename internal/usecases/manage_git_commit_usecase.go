@@ -2,42 +2,373 @@ package usecases
 
 import (
 	"context"
+	"time"
 
+	"github.com/kenmobility/git-api-service/infra/config"
 	"github.com/kenmobility/git-api-service/internal/domain"
 	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
 )
 
+// defaultHotspotLimit is the number of files and of directories ranked when no limit is given
+const defaultHotspotLimit = 20
+
 type ManageGitCommitUsecase interface {
-	GetAllCommitsByRepository(ctx context.Context, repoId string, query domain.APIPagingData) (*string, []domain.Commit, *domain.PagingInfo, error)
+	GetAllCommitsByRepository(ctx context.Context, repoId string, filter domain.CommitFilter, query domain.APIPagingData) (*string, []domain.Commit, *domain.PagingInfo, error)
+	GetBranchesByRepository(ctx context.Context, repoId string) (*string, []domain.Branch, error)
+	GetReleasesByRepository(ctx context.Context, repoId string) (*string, []domain.Release, error)
+	GetPullRequestsByRepository(ctx context.Context, repoId string, state string, query domain.APIPagingData) (*string, []domain.PullRequest, *domain.PagingInfo, error)
+	GetCommitsByIssue(ctx context.Context, repoId string, number int) (*string, *domain.Issue, []domain.ReferencingCommit, error)
+	GetReferencesByCommit(ctx context.Context, commitID string) ([]domain.CommitReference, error)
+	GetCheckRunsByCommit(ctx context.Context, repoId string, commitID string) (*string, []domain.CheckRun, error)
+	GetCIHealth(ctx context.Context, repoId string, from, to time.Time) (*string, *domain.CIHealth, error)
+	GetHotspots(ctx context.Context, repoId string, from, to time.Time, pathPrefix string, sort string, limit int) (*string, []domain.Hotspot, []domain.Hotspot, error)
+	GetFileHistory(ctx context.Context, repoId string, path string, query domain.APIPagingData) (*string, *domain.FileHistory, error)
+	GetOwnership(ctx context.Context, repoId string, path string) (*string, *domain.Ownership, error)
+	GetCodeowners(ctx context.Context, repoId string) (*string, string, []string, error)
 	GetTopRepositoryCommitAuthors(ctx context.Context, repoId string, limit int) (*string, []domain.AuthorCommitCount, error)
+	GetRepositoriesByCommit(ctx context.Context, commitID string) ([]domain.RepoMetadata, error)
 }
 
 type manageGitCommitUsecase struct {
 	commitRepository       repository.CommitRepository
 	repoMetadataRepository repository.RepoMetadataRepository
+	branchRepository       repository.BranchRepository
+	releaseRepository      repository.ReleaseRepository
+	pullRequestRepository  repository.PullRequestRepository
+	issueRepository        repository.IssueRepository
+	checkRepository        repository.CheckRepository
+	fileRepository         repository.FileRepository
+	enrichmentRepository   repository.EnrichmentRepository
+	config                 config.Config
 }
 
-func NewManageGitCommitUsecase(commitRepo repository.CommitRepository, repoMetadataRepository repository.RepoMetadataRepository) ManageGitCommitUsecase {
+func NewManageGitCommitUsecase(commitRepo repository.CommitRepository, repoMetadataRepository repository.RepoMetadataRepository,
+	branchRepo repository.BranchRepository, releaseRepo repository.ReleaseRepository, pullRequestRepo repository.PullRequestRepository,
+	issueRepo repository.IssueRepository, checkRepo repository.CheckRepository, fileRepo repository.FileRepository,
+	enrichmentRepo repository.EnrichmentRepository, config config.Config) ManageGitCommitUsecase {
 	return &manageGitCommitUsecase{
 		commitRepository:       commitRepo,
 		repoMetadataRepository: repoMetadataRepository,
+		branchRepository:       branchRepo,
+		releaseRepository:      releaseRepo,
+		pullRequestRepository:  pullRequestRepo,
+		issueRepository:        issueRepo,
+		checkRepository:        checkRepo,
+		fileRepository:         fileRepo,
+		enrichmentRepository:   enrichmentRepo,
+		config:                 config,
 	}
 }
 
-func (uc *manageGitCommitUsecase) GetAllCommitsByRepository(ctx context.Context, repoId string, query domain.APIPagingData) (*string, []domain.Commit, *domain.PagingInfo, error) {
+// GetAllCommitsByRepository returns a page of the commits of a repository matching the filter, each with the tracked
+// branches it is reachable from and the pull request that introduced it
+func (uc *manageGitCommitUsecase) GetAllCommitsByRepository(ctx context.Context, repoId string, filter domain.CommitFilter, query domain.APIPagingData) (*string, []domain.Commit, *domain.PagingInfo, error) {
 	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	commits, pagingInfo, err := uc.commitRepository.AllCommitsByRepository(ctx, *repoMetaData, query)
+	if filter.Branch != "" {
+		_, err := uc.branchRepository.BranchByName(ctx, *repoMetaData, filter.Branch)
+		if err == message.ErrNoRecordFound {
+			return nil, nil, nil, message.ErrBranchNotTracked
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if filter.Release != "" {
+		_, err := uc.releaseRepository.ReleaseByTag(ctx, *repoMetaData, filter.Release)
+		if err == message.ErrNoRecordFound {
+			return nil, nil, nil, message.ErrReleaseNotFound
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	commits, pagingInfo, err := uc.commitRepository.AllCommitsByRepository(ctx, *repoMetaData, filter, query)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	branches, err := uc.branchRepository.BranchesByCommitIDs(ctx, *repoMetaData, commitIDs(commits))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pullRequests, err := uc.pullRequestRepository.PullRequestsByCommitIDs(ctx, *repoMetaData, commitIDs(commits))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for i := range commits {
+		commits[i].Branches = branches[commits[i].CommitID]
+		if pr, ok := pullRequests[commits[i].CommitID]; ok {
+			commits[i].PullRequest = &pr
+		}
+	}
+
 	return &repoMetaData.Name, commits, pagingInfo, nil
 }
 
+// GetBranchesByRepository returns the tracked branches of a repository
+func (uc *manageGitCommitUsecase) GetBranchesByRepository(ctx context.Context, repoId string) (*string, []domain.Branch, error) {
+	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	branches, err := uc.branchRepository.BranchesByRepository(ctx, *repoMetaData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &repoMetaData.Name, branches, nil
+}
+
+// GetReleasesByRepository returns the releases of a repository, latest published first
+func (uc *manageGitCommitUsecase) GetReleasesByRepository(ctx context.Context, repoId string) (*string, []domain.Release, error) {
+	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	releases, err := uc.releaseRepository.ReleasesByRepository(ctx, *repoMetaData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &repoMetaData.Name, releases, nil
+}
+
+// GetPullRequestsByRepository returns a page of the pull requests of a repository, only those in state when it is not empty
+func (uc *manageGitCommitUsecase) GetPullRequestsByRepository(ctx context.Context, repoId string, state string, query domain.APIPagingData) (*string, []domain.PullRequest, *domain.PagingInfo, error) {
+	if state != "" && !domain.IsValidPullRequestState(state) {
+		return nil, nil, nil, message.ErrInvalidPullRequestState
+	}
+
+	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	pullRequests, pagingInfo, err := uc.pullRequestRepository.PullRequestsByRepository(ctx, *repoMetaData, state, query)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return &repoMetaData.Name, pullRequests, pagingInfo, nil
+}
+
+// GetCommitsByIssue returns an issue of a repository, nil when it was not synced, with the commits referencing it
+func (uc *manageGitCommitUsecase) GetCommitsByIssue(ctx context.Context, repoId string, number int) (*string, *domain.Issue, []domain.ReferencingCommit, error) {
+	if number <= 0 {
+		return nil, nil, nil, message.ErrInvalidIssueNumber
+	}
+
+	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	issue, err := uc.issueRepository.IssueByNumber(ctx, *repoMetaData, number)
+	if err != nil && err != message.ErrNoRecordFound {
+		return nil, nil, nil, err
+	}
+
+	commits, err := uc.issueRepository.CommitsByIssue(ctx, *repoMetaData, number)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return &repoMetaData.Name, issue, commits, nil
+}
+
+// GetReferencesByCommit returns the issue and ticket references found in the message of a commit in every tracked
+// repository containing it
+func (uc *manageGitCommitUsecase) GetReferencesByCommit(ctx context.Context, commitID string) ([]domain.CommitReference, error) {
+	repos, err := uc.repoMetadataRepository.RepoMetadataByCommitID(ctx, commitID)
+	if err != nil {
+		return nil, err
+	}
+	if len(repos) == 0 {
+		return nil, message.ErrCommitNotFound
+	}
+
+	return uc.issueRepository.ReferencesByCommitID(ctx, commitID)
+}
+
+// GetCheckRunsByCommit returns the statuses and check runs of a commit of a repository, re-runs included
+func (uc *manageGitCommitUsecase) GetCheckRunsByCommit(ctx context.Context, repoId string, commitID string) (*string, []domain.CheckRun, error) {
+	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	runs, err := uc.checkRepository.CheckRunsByCommitID(ctx, *repoMetaData, commitID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &repoMetaData.Name, runs, nil
+}
+
+// GetCIHealth returns the pass rate, the flaky checks and the mean time to green of the commits of a repository
+// from the checks started within the given window, a zero date leaves it open
+func (uc *manageGitCommitUsecase) GetCIHealth(ctx context.Context, repoId string, from, to time.Time) (*string, *domain.CIHealth, error) {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, nil, message.ErrInvalidHistoryWindow
+	}
+
+	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	runs, err := uc.checkRepository.CheckRunsByRepository(ctx, *repoMetaData, from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	health := domain.ComputeCIHealth(runs)
+	return &repoMetaData.Name, &health, nil
+}
+
+// GetHotspots returns the files and the directories of a repository under a path prefix ranked by sort, at most limit
+// of each, from the file changes of the enriched commits committed within the given window, a zero date leaves it open.
+// The changes are aggregated per path by the database, only the ranking is done here.
+func (uc *manageGitCommitUsecase) GetHotspots(ctx context.Context, repoId string, from, to time.Time, pathPrefix string, sort string, limit int) (*string, []domain.Hotspot, []domain.Hotspot, error) {
+	if sort == "" {
+		sort = domain.HotspotSortScore
+	}
+	if !domain.IsValidHotspotSort(sort) {
+		return nil, nil, nil, message.ErrInvalidHotspotSort
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, nil, nil, message.ErrInvalidHistoryWindow
+	}
+	if limit <= 0 {
+		limit = defaultHotspotLimit
+	}
+
+	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	stats, err := uc.fileRepository.PathStatsByRepository(ctx, *repoMetaData, from, to, pathPrefix)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	files, dirs := domain.Hotspots(stats, sort, limit, time.Now())
+	return &repoMetaData.Name, files, dirs, nil
+}
+
+// GetFileHistory returns a page of the changes of a file of a repository, newest first, following it across renames to
+// the changes of its previous paths, with the enrichment progress of the commits of the repository
+func (uc *manageGitCommitUsecase) GetFileHistory(ctx context.Context, repoId string, path string, query domain.APIPagingData) (*string, *domain.FileHistory, error) {
+	if path == "" {
+		return nil, nil, message.ErrPathRequired
+	}
+
+	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the file had each previous path until it was renamed away from it, the rename itself being the oldest
+	// change of the newer path
+	var spans []domain.FilePathSpan
+	var until time.Time
+	seen := make(map[string]bool)
+	for path != "" && !seen[path] {
+		seen[path] = true
+
+		span := domain.FilePathSpan{Path: path, Until: until}
+		rename, err := uc.fileRepository.LatestFileRename(ctx, *repoMetaData, path, until)
+		if err != nil && err != message.ErrNoRecordFound {
+			return nil, nil, err
+		}
+
+		path = ""
+		if rename != nil {
+			span.From = rename.Commit.CommittedAt
+			path, until = rename.Change.PreviousPath, rename.Commit.CommittedAt
+		}
+		spans = append(spans, span)
+	}
+
+	page, pagingInfo, err := uc.fileRepository.FileRevisionsBySpans(ctx, *repoMetaData, spans, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enrichment, err := uc.enrichmentRepository.EnrichmentStateByRepository(ctx, *repoMetaData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &repoMetaData.Name, &domain.FileHistory{Revisions: page, PagingInfo: *pagingInfo, Enrichment: *enrichment}, nil
+}
+
+// GetOwnership returns the owners of a directory of a repository, the root when path is empty, weighted by the
+// lines of their changes to it and by how recent they are
+func (uc *manageGitCommitUsecase) GetOwnership(ctx context.Context, repoId string, path string) (*string, *domain.Ownership, error) {
+	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dir := domain.CleanOwnershipPath(path)
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+	policy := uc.ownershipPolicy()
+	changes, err := uc.fileRepository.AuthorChangesByRepository(ctx, *repoMetaData, prefix, policy.HalfLife, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, o := range domain.ComputeOwnership(changes, policy) {
+		if o.Path == dir {
+			return &repoMetaData.Name, &o, nil
+		}
+	}
+	return &repoMetaData.Name, &domain.Ownership{Path: dir, Owners: []domain.Owner{}}, nil
+}
+
+// GetCodeowners renders a suggested CODEOWNERS file for a repository from the ownership of its directories, with the
+// authors that would have been listed but have no GitHub handle mapped
+func (uc *manageGitCommitUsecase) GetCodeowners(ctx context.Context, repoId string) (*string, string, []string, error) {
+	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	policy := uc.ownershipPolicy()
+	changes, err := uc.fileRepository.AuthorChangesByRepository(ctx, *repoMetaData, "", policy.HalfLife, time.Now())
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	content, unmapped := domain.RenderCodeowners(domain.ComputeOwnership(changes, policy), policy)
+	return &repoMetaData.Name, content, unmapped, nil
+}
+
+// ownershipPolicy returns the ownership policy set by the config
+func (uc *manageGitCommitUsecase) ownershipPolicy() domain.OwnershipPolicy {
+	return domain.OwnershipPolicy{
+		HalfLife:   uc.config.OwnershipHalfLife,
+		MinShare:   uc.config.OwnershipMinShare,
+		MaxOwners:  uc.config.OwnershipMaxOwners,
+		MinChanges: uc.config.OwnershipMinChanges,
+		Handles:    uc.config.OwnerHandles,
+	}
+}
+
 func (uc *manageGitCommitUsecase) GetTopRepositoryCommitAuthors(ctx context.Context, repoId string, limit int) (*string, []domain.AuthorCommitCount, error) {
 	repoMetaData, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
 	if err != nil {
@@ -51,3 +382,16 @@ func (uc *manageGitCommitUsecase) GetTopRepositoryCommitAuthors(ctx context.Cont
 
 	return &repoMetaData.Name, authors, nil
 }
+
+// GetRepositoriesByCommit returns the tracked repositories containing a commit
+func (uc *manageGitCommitUsecase) GetRepositoriesByCommit(ctx context.Context, commitID string) ([]domain.RepoMetadata, error) {
+	repos, err := uc.repoMetadataRepository.RepoMetadataByCommitID(ctx, commitID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(repos) == 0 {
+		return nil, message.ErrCommitNotFound
+	}
+	return repos, nil
+}
@@ -0,0 +1,172 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kenmobility/git-api-service/infra/config"
+	"github.com/kenmobility/git-api-service/internal/domain"
+	repo_mocks "github.com/kenmobility/git-api-service/internal/repository/mocks"
+	"github.com/kenmobility/git-api-service/pkg/helpers"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetRepositoriesByCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := repo_mocks.NewMockRepository(ctrl)
+	uc := NewManageGitCommitUsecase(store, store, store, store, store, store, store, store, store, config.Config{})
+
+	upstream, fork := randomRepoMetadata(), randomRepoMetadata()
+	sha := helpers.RandomString(40)
+
+	store.EXPECT().
+		RepoMetadataByCommitID(gomock.Any(), sha).
+		Return([]domain.RepoMetadata{upstream, fork}, nil).
+		Times(1)
+
+	repos, err := uc.GetRepositoriesByCommit(context.Background(), sha)
+
+	require.NoError(t, err)
+	require.Equal(t, []domain.RepoMetadata{upstream, fork}, repos)
+}
+
+func TestGetRepositoriesByUnknownCommit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := repo_mocks.NewMockRepository(ctrl)
+	uc := NewManageGitCommitUsecase(store, store, store, store, store, store, store, store, store, config.Config{})
+
+	store.EXPECT().
+		RepoMetadataByCommitID(gomock.Any(), gomock.Any()).
+		Return([]domain.RepoMetadata{}, nil).
+		Times(1)
+
+	_, err := uc.GetRepositoriesByCommit(context.Background(), helpers.RandomString(40))
+
+	require.ErrorIs(t, err, message.ErrCommitNotFound)
+}
+
+func TestGetAllCommitsByUntrackedBranch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := repo_mocks.NewMockRepository(ctrl)
+	uc := NewManageGitCommitUsecase(store, store, store, store, store, store, store, store, store, config.Config{})
+
+	repo := randomRepoMetadata()
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	store.EXPECT().
+		BranchByName(gomock.Any(), repo, "feature/x").
+		Return(nil, message.ErrNoRecordFound).
+		Times(1)
+
+	_, _, _, err := uc.GetAllCommitsByRepository(context.Background(), repo.PublicID, domain.CommitFilter{Branch: "feature/x"}, domain.APIPagingData{})
+
+	require.ErrorIs(t, err, message.ErrBranchNotTracked)
+}
+
+func TestGetFileHistoryFollowsRenames(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := repo_mocks.NewMockRepository(ctrl)
+	uc := NewManageGitCommitUsecase(store, store, store, store, store, store, store, store, store, config.Config{})
+
+	repo := randomRepoMetadata()
+	now := time.Now()
+	revision := func(path, previousPath, status string, age time.Duration) domain.FileRevision {
+		commit := domain.Commit{CommitID: helpers.RandomString(40), CommittedAt: now.Add(-age)}
+		return domain.FileRevision{
+			Commit: commit,
+			Change: domain.FileChange{CommitID: commit.CommitID, Path: path, PreviousPath: previousPath, Status: status},
+		}
+	}
+
+	rename := revision("pkg/client.go", "client.go", domain.FileRenamed, 2*time.Hour)
+	created := revision("client.go", "", domain.FileAdded, 3*time.Hour)
+	// a later file at the previous path is not part of the history of the renamed file
+	spans := []domain.FilePathSpan{
+		{Path: "pkg/client.go", From: rename.Commit.CommittedAt},
+		{Path: "client.go", Until: rename.Commit.CommittedAt},
+	}
+	query := domain.APIPagingData{Page: 2, Limit: 2}
+	pagingInfo := domain.PagingInfo{TotalCount: 3, Page: 2, Count: 1}
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	store.EXPECT().
+		LatestFileRename(gomock.Any(), repo, "pkg/client.go", time.Time{}).
+		Return(&rename, nil).
+		Times(1)
+
+	store.EXPECT().
+		LatestFileRename(gomock.Any(), repo, "client.go", rename.Commit.CommittedAt).
+		Return(nil, message.ErrNoRecordFound).
+		Times(1)
+
+	store.EXPECT().
+		FileRevisionsBySpans(gomock.Any(), repo, spans, query).
+		Return([]domain.FileRevision{created}, &pagingInfo, nil).
+		Times(1)
+
+	store.EXPECT().
+		EnrichmentStateByRepository(gomock.Any(), repo).
+		Return(&domain.EnrichmentState{TotalCommits: 10, EnrichedCommits: 8}, nil).
+		Times(1)
+
+	_, history, err := uc.GetFileHistory(context.Background(), repo.PublicID, "pkg/client.go", query)
+
+	require.NoError(t, err)
+	require.Equal(t, []domain.FileRevision{created}, history.Revisions)
+	require.Equal(t, pagingInfo, history.PagingInfo)
+	require.Equal(t, 8, history.Enrichment.EnrichedCommits)
+}
+
+func TestGetHotspotsInvalidSort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := repo_mocks.NewMockRepository(ctrl)
+	uc := NewManageGitCommitUsecase(store, store, store, store, store, store, store, store, store, config.Config{})
+
+	_, _, _, err := uc.GetHotspots(context.Background(), helpers.RandomString(10), time.Time{}, time.Time{}, "", "size", 0)
+
+	require.ErrorIs(t, err, message.ErrInvalidHotspotSort)
+}
+
+func TestGetOwnershipOfDirectory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := repo_mocks.NewMockRepository(ctrl)
+	uc := NewManageGitCommitUsecase(store, store, store, store, store, store, store, store, store,
+		config.Config{OwnerHandles: map[string]string{"jane doe": "jane"}})
+
+	repo := randomRepoMetadata()
+	now := time.Now()
+
+	store.EXPECT().
+		RepoMetadataByPublicId(gomock.Any(), repo.PublicID).
+		Return(&repo, nil).
+		Times(1)
+
+	store.EXPECT().
+		AuthorChangesByRepository(gomock.Any(), repo, "pkg/api/", time.Duration(0), gomock.Any()).
+		Return([]domain.AuthorChanges{
+			{Dir: "pkg/api", Author: "Jane Doe", AuthorKey: "name:jane doe", Commits: 1, Lines: 30, Weight: 30, LastChangedAt: now},
+			{Dir: "pkg/api", Author: "John Roe", AuthorKey: "name:john roe", Commits: 1, Lines: 10, Weight: 10, LastChangedAt: now},
+			{Dir: "pkg/api/v2", Author: "John Roe", AuthorKey: "name:john roe", Commits: 1, Lines: 10, Weight: 10, LastChangedAt: now},
+		}, nil).
+		Times(1)
+
+	_, ownership, err := uc.GetOwnership(context.Background(), repo.PublicID, "/pkg/api/")
+
+	require.NoError(t, err)
+	require.Equal(t, "pkg/api", ownership.Path)
+	require.Equal(t, 2, ownership.Changes)
+	require.Len(t, ownership.Owners, 2)
+	require.Equal(t, "@jane", ownership.Owners[0].Handle)
+	require.InDelta(t, 0.75, ownership.Owners[0].Share, 0.0001)
+}
@@ -0,0 +1,316 @@
+package usecases
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kenmobility/git-api-service/infra/config"
+	"github.com/kenmobility/git-api-service/infra/git"
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/internal/repository"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// ownerReposPerPage is the largest page size of the repository listings
+	ownerReposPerPage = 100
+	// orgImportMaxIndexing is the number of indexing jobs an import waits to drop under before adding a repository,
+	// so that importing a large organization does not index all its repositories at once
+	orgImportMaxIndexing = 2
+	// orgImportIndexingPoll is how often an import waiting for an indexing job to end checks the running jobs
+	orgImportIndexingPoll = 10 * time.Second
+)
+
+type OrganizationUsecase interface {
+	Import(ctx context.Context, owner string, filter domain.OrgImportFilter, syncMembership bool) (*domain.OrgImport, error)
+	ImportStatus(ctx context.Context, owner string) (*domain.OrgImport, error)
+	ResumeMembershipSync(ctx context.Context) error
+}
+
+type organizationUsecase struct {
+	// serviceCtx is cancelled when the service shuts down, stopping the imports running in the background
+	serviceCtx             context.Context
+	organizationRepository repository.OrganizationRepository
+	gitRepositoryUsecase   GitRepositoryUsecase
+	gitClient              git.GitManagerClient
+	config                 config.Config
+	syncs                  *repoMonitors
+	importsMu              sync.Mutex
+}
+
+func NewOrganizationUsecase(serviceCtx context.Context, organizationRepo repository.OrganizationRepository, gitRepositoryUsecase GitRepositoryUsecase,
+	gitClient git.GitManagerClient, config config.Config) OrganizationUsecase {
+	return &organizationUsecase{
+		serviceCtx:             serviceCtx,
+		organizationRepository: organizationRepo,
+		gitRepositoryUsecase:   gitRepositoryUsecase,
+		gitClient:              gitClient,
+		config:                 config,
+		syncs:                  newRepoMonitors(),
+	}
+}
+
+// Import starts a background import of every repository of an organization or user selected by the filter, when
+// syncMembership is true the organization or user is then watched so that new repositories are added as well.
+// An organization or user is imported once at a time.
+func (uc *organizationUsecase) Import(ctx context.Context, owner string, filter domain.OrgImportFilter, syncMembership bool) (*domain.OrgImport, error) {
+	if owner == "" || strings.Contains(owner, "/") {
+		return nil, message.ErrInvalidOwnerName
+	}
+
+	namePattern, err := compileNamePattern(filter.NamePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.importsMu.Lock()
+	defer uc.importsMu.Unlock()
+
+	latest, err := uc.organizationRepository.LatestOrgImport(ctx, owner)
+	if err != nil && err != message.ErrNoRecordFound {
+		return nil, err
+	}
+	if latest != nil && latest.Status == domain.OrgImportStatusRunning {
+		return nil, message.ErrOrgImportInProgress
+	}
+
+	now := time.Now()
+	orgImport, err := uc.organizationRepository.SaveOrgImport(ctx, domain.OrgImport{
+		Owner:          owner,
+		Filter:         filter,
+		SyncMembership: syncMembership,
+		Status:         domain.OrgImportStatusRunning,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the import outlives the request that started it, until the service shuts down
+	go uc.runImport(uc.serviceCtx, *orgImport, namePattern)
+
+	return orgImport, nil
+}
+
+// ImportStatus returns the latest import of an organization or user
+func (uc *organizationUsecase) ImportStatus(ctx context.Context, owner string) (*domain.OrgImport, error) {
+	orgImport, err := uc.organizationRepository.LatestOrgImport(ctx, owner)
+	if err == message.ErrNoRecordFound {
+		return nil, message.ErrOrgNotImported
+	}
+	return orgImport, err
+}
+
+// runImport adds the repositories of an import, saving the results of every listed page, then starts watching the
+// organization or user when the import syncs its membership
+func (uc *organizationUsecase) runImport(ctx context.Context, orgImport domain.OrgImport, namePattern *regexp.Regexp) {
+	err := uc.importRepos(ctx, orgImport.Owner, orgImport.Filter, namePattern, nil, func(results []domain.OrgImportResult) error {
+		orgImport.Results = append(orgImport.Results, results...)
+		return uc.organizationRepository.SaveOrgImportResults(ctx, orgImport, results)
+	})
+
+	if err == nil && orgImport.SyncMembership {
+		err = uc.watchMembership(ctx, orgImport.Owner, orgImport.Filter)
+	}
+
+	now := time.Now()
+	orgImport.Status = domain.OrgImportStatusCompleted
+	orgImport.CompletedAt = &now
+	if err != nil {
+		log.Err(err).Msgf("Error importing repositories of %s: %v", orgImport.Owner, err)
+		orgImport.Status = domain.OrgImportStatusFailed
+		orgImport.Error = err.Error()
+	}
+	// an import stopped by a shutdown is still recorded
+	if err := uc.organizationRepository.UpdateOrgImport(context.WithoutCancel(ctx), orgImport); err != nil {
+		log.Err(err).Msgf("Error updating import of %s: %v", orgImport.Owner, err)
+		return
+	}
+
+	log.Info().Msgf("import of %s %s, %d repositories added", orgImport.Owner, orgImport.Status, orgImport.Count(domain.OrgImportAdded))
+}
+
+// watchMembership saves the membership of an organization or user and starts watching its repositories
+func (uc *organizationUsecase) watchMembership(ctx context.Context, owner string, filter domain.OrgImportFilter) error {
+	now := time.Now()
+	membership, err := uc.organizationRepository.SaveOrgMembership(ctx, domain.OrgMembership{
+		Owner:        owner,
+		Filter:       filter,
+		LastSyncedAt: &now,
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return err
+	}
+
+	// a new filter replaces the one the membership was watched with
+	uc.syncs.stop(owner)
+	uc.startMembershipSync(ctx, *membership)
+	return nil
+}
+
+// ResumeMembershipSync restarts the watching of every organization and user imported with membership sync, the
+// imports interrupted by a restart are marked failed
+func (uc *organizationUsecase) ResumeMembershipSync(ctx context.Context) error {
+	if err := uc.organizationRepository.FailRunningOrgImports(ctx, "import interrupted by a restart"); err != nil {
+		log.Err(err).Msgf("Error failing interrupted organization imports: %v", err)
+	}
+
+	memberships, err := uc.organizationRepository.AllOrgMemberships(ctx)
+	if err != nil {
+		log.Err(err).Msgf("Error fetching organization memberships: %v", err)
+		return err
+	}
+
+	for _, membership := range memberships {
+		uc.startMembershipSync(ctx, membership)
+	}
+	return nil
+}
+
+// startMembershipSync watches the repositories of an organization or user, nothing is watched without an
+// organization sync interval. The repositories untracked by a user are not added back.
+func (uc *organizationUsecase) startMembershipSync(ctx context.Context, membership domain.OrgMembership) {
+	if uc.config.OrgSyncInterval <= 0 {
+		return
+	}
+
+	namePattern, err := compileNamePattern(membership.Filter.NamePattern)
+	if err != nil {
+		log.Err(err).Msgf("Error watching repositories of %s: %v", membership.Owner, err)
+		return
+	}
+
+	syncCtx := uc.syncs.start(ctx, membership.Owner)
+	go func() {
+		ticker := time.NewTicker(uc.config.OrgSyncInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-syncCtx.Done():
+				log.Warn().Msgf("repositories of %s are no longer watched", membership.Owner)
+				return
+			case <-ticker.C:
+				untracked, err := uc.organizationRepository.UntrackedProviderIDs(syncCtx)
+				if err != nil {
+					log.Err(err).Msgf("Error fetching untracked repositories: %v", err)
+					continue
+				}
+
+				added := 0
+				err = uc.importRepos(syncCtx, membership.Owner, membership.Filter, namePattern, untracked, func(results []domain.OrgImportResult) error {
+					added += domain.OrgImport{Results: results}.Count(domain.OrgImportAdded)
+					return nil
+				})
+				if err != nil {
+					log.Err(err).Msgf("Error syncing repositories of %s: %v", membership.Owner, err)
+					continue
+				}
+
+				if added > 0 {
+					log.Info().Msgf("%d new repositories of %s added", added, membership.Owner)
+				}
+				if err := uc.organizationRepository.UpdateOrgMembershipSyncedAt(syncCtx, membership.Owner, time.Now()); err != nil {
+					log.Err(err).Msgf("Error updating sync date of %s: %v", membership.Owner, err)
+				}
+			}
+		}
+	}()
+}
+
+// importRepos lists the repositories of an organization or user and adds the ones selected by the filter with
+// the metadata they were listed with, skipping the untracked and already tracked ones, and passes the results of every
+// listed page to saveResults. A repository is only added once fewer than orgImportMaxIndexing indexing jobs are running.
+func (uc *organizationUsecase) importRepos(ctx context.Context, owner string, filter domain.OrgImportFilter, namePattern *regexp.Regexp,
+	untracked map[int64]bool, saveResults func([]domain.OrgImportResult) error) error {
+	tracked, err := uc.organizationRepository.TrackedRepoNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	for page := 1; ; page++ {
+		repos, morePages, err := uc.gitClient.FetchOwnerRepos(ctx, owner, page, ownerReposPerPage)
+		if err != nil {
+			return err
+		}
+
+		results := make([]domain.OrgImportResult, 0, len(repos))
+		for _, r := range repos {
+			result := domain.OrgImportResult{Name: r.Metadata.Name}
+			if ok, reason := filter.Matches(r, namePattern); !ok {
+				result.Status = domain.OrgImportSkipped
+				result.Reason = reason
+				results = append(results, result)
+				continue
+			}
+			if untracked[r.Metadata.ProviderID] {
+				result.Status = domain.OrgImportSkipped
+				result.Reason = "repository was untracked"
+				results = append(results, result)
+				continue
+			}
+			// a tracked repository is not added again, so the import does not wait for the indexing jobs to end for it
+			if tracked[r.Metadata.Name] {
+				result.Status = domain.OrgImportAlreadyAdded
+				results = append(results, result)
+				continue
+			}
+
+			if err := uc.waitForIndexing(ctx); err != nil {
+				return err
+			}
+
+			repo, err := uc.gitRepositoryUsecase.TrackRepository(ctx, r.Metadata, domain.TrackingOptions{})
+			switch {
+			case err == message.ErrRepoAlreadyAdded:
+				result.Status = domain.OrgImportAlreadyAdded
+			case err != nil:
+				result.Status = domain.OrgImportFailed
+				result.Reason = err.Error()
+			default:
+				result.Status = domain.OrgImportAdded
+				result.Repository = repo
+			}
+			results = append(results, result)
+		}
+
+		if err := saveResults(results); err != nil {
+			return err
+		}
+
+		if !morePages {
+			return nil
+		}
+	}
+}
+
+// waitForIndexing waits until fewer than orgImportMaxIndexing indexing jobs are running
+func (uc *organizationUsecase) waitForIndexing(ctx context.Context) error {
+	for uc.gitRepositoryUsecase.IndexingJobs() >= orgImportMaxIndexing {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(orgImportIndexingPoll):
+		}
+	}
+	return nil
+}
+
+// compileNamePattern compiles the repository name pattern of an import filter, an empty pattern matches every name
+func compileNamePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+
+	namePattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, message.ErrInvalidNamePattern
+	}
+	return namePattern, nil
+}
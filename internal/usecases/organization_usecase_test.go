@@ -0,0 +1,152 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kenmobility/git-api-service/infra/config"
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRunImportFiltersRepositories(t *testing.T) {
+	repoUsecase, store, gitClient := newTestUsecase(t)
+	uc := &organizationUsecase{organizationRepository: store, gitRepositoryUsecase: repoUsecase, gitClient: gitClient, syncs: newRepoMonitors()}
+
+	orgImport := domain.OrgImport{ID: 1, Owner: "owner", Filter: domain.OrgImportFilter{NamePattern: "^(fork|tracked)$"}, Status: domain.OrgImportStatusRunning}
+
+	gitClient.EXPECT().
+		FetchOwnerRepos(gomock.Any(), "owner", 1, ownerReposPerPage).
+		Return([]domain.OwnerRepo{
+			{Metadata: domain.RepoMetadata{Name: "owner/fork"}, Fork: true},
+			{Metadata: domain.RepoMetadata{Name: "owner/tracked"}},
+		}, true, nil).
+		Times(1)
+
+	gitClient.EXPECT().
+		FetchOwnerRepos(gomock.Any(), "owner", 2, ownerReposPerPage).
+		Return([]domain.OwnerRepo{
+			{Metadata: domain.RepoMetadata{Name: "owner/docs"}},
+		}, false, nil).
+		Times(1)
+
+	store.EXPECT().
+		TrackedRepoNames(gomock.Any()).
+		Return(map[string]bool{"owner/tracked": true}, nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveOrgImportResults(gomock.Any(), gomock.Any(), gomock.Len(2)).
+		Return(nil).
+		Times(1)
+
+	store.EXPECT().
+		SaveOrgImportResults(gomock.Any(), gomock.Any(), gomock.Len(1)).
+		Return(nil).
+		Times(1)
+
+	store.EXPECT().
+		UpdateOrgImport(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, i domain.OrgImport) error {
+			require.Equal(t, domain.OrgImportStatusCompleted, i.Status)
+			require.NotNil(t, i.CompletedAt)
+			require.Len(t, i.Results, 3)
+			require.Equal(t, domain.OrgImportSkipped, i.Results[0].Status)
+			require.Equal(t, domain.OrgImportAlreadyAdded, i.Results[1].Status)
+			require.Equal(t, domain.OrgImportSkipped, i.Results[2].Status)
+			require.Equal(t, 2, i.Count(domain.OrgImportSkipped))
+			return nil
+		}).
+		Times(1)
+
+	namePattern, err := compileNamePattern(orgImport.Filter.NamePattern)
+	require.NoError(t, err)
+
+	uc.runImport(context.Background(), orgImport, namePattern)
+}
+
+func TestImportReposDoesNotWaitForTrackedRepositories(t *testing.T) {
+	repoUsecase, store, gitClient := newTestUsecase(t)
+	uc := &organizationUsecase{organizationRepository: store, gitRepositoryUsecase: repoUsecase, gitClient: gitClient, syncs: newRepoMonitors()}
+
+	// an import waiting for the indexing jobs to end fails with the cancelled context
+	repoUsecase.indexingJobs.Store(orgImportMaxIndexing)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	gitClient.EXPECT().
+		FetchOwnerRepos(gomock.Any(), "owner", 1, ownerReposPerPage).
+		Return([]domain.OwnerRepo{
+			{Metadata: domain.RepoMetadata{Name: "owner/tracked", ProviderID: 42}},
+		}, false, nil).
+		Times(1)
+
+	store.EXPECT().
+		TrackedRepoNames(gomock.Any()).
+		Return(map[string]bool{"owner/tracked": true}, nil).
+		Times(1)
+
+	var results []domain.OrgImportResult
+	err := uc.importRepos(ctx, "owner", domain.OrgImportFilter{}, nil, nil, func(page []domain.OrgImportResult) error {
+		results = append(results, page...)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, domain.OrgImportAlreadyAdded, results[0].Status)
+}
+
+func TestImportReposSkipsUntrackedRepositories(t *testing.T) {
+	repoUsecase, store, gitClient := newTestUsecase(t)
+	uc := &organizationUsecase{organizationRepository: store, gitRepositoryUsecase: repoUsecase, gitClient: gitClient, syncs: newRepoMonitors()}
+
+	gitClient.EXPECT().
+		FetchOwnerRepos(gomock.Any(), "owner", 1, ownerReposPerPage).
+		Return([]domain.OwnerRepo{
+			{Metadata: domain.RepoMetadata{Name: "owner/untracked", ProviderID: 42}},
+		}, false, nil).
+		Times(1)
+
+	store.EXPECT().
+		TrackedRepoNames(gomock.Any()).
+		Return(map[string]bool{}, nil).
+		Times(1)
+
+	var results []domain.OrgImportResult
+	err := uc.importRepos(context.Background(), "owner", domain.OrgImportFilter{}, nil, map[int64]bool{42: true}, func(page []domain.OrgImportResult) error {
+		results = append(results, page...)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, domain.OrgImportSkipped, results[0].Status)
+	require.Equal(t, "repository was untracked", results[0].Reason)
+}
+
+func TestImportOrganizationRejectsRunningImport(t *testing.T) {
+	repoUsecase, store, gitClient := newTestUsecase(t)
+	uc := NewOrganizationUsecase(context.Background(), store, repoUsecase, gitClient, config.Config{})
+
+	store.EXPECT().
+		LatestOrgImport(gomock.Any(), "owner").
+		Return(&domain.OrgImport{Owner: "owner", Status: domain.OrgImportStatusRunning}, nil).
+		Times(1)
+
+	_, err := uc.Import(context.Background(), "owner", domain.OrgImportFilter{}, false)
+	require.ErrorIs(t, err, message.ErrOrgImportInProgress)
+}
+
+func TestImportOrganizationRejectsInvalidInput(t *testing.T) {
+	repoUsecase, store, gitClient := newTestUsecase(t)
+	uc := NewOrganizationUsecase(context.Background(), store, repoUsecase, gitClient, config.Config{})
+
+	_, err := uc.Import(context.Background(), "owner/repo", domain.OrgImportFilter{}, false)
+	require.ErrorIs(t, err, message.ErrInvalidOwnerName)
+
+	_, err = uc.Import(context.Background(), "owner", domain.OrgImportFilter{NamePattern: "("}, false)
+	require.ErrorIs(t, err, message.ErrInvalidNamePattern)
+}
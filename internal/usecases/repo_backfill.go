@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+// BackfillJobs returns the fetched commit date ranges and the backfill jobs of a repository
+func (uc *gitRepoUsecase) BackfillJobs(ctx context.Context, repoId string) ([]domain.SyncRange, []domain.BackfillJob, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ranges, err := uc.backfillRepository.SyncRangesByRepository(ctx, *repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jobs, err := uc.backfillRepository.BackfillJobsByRepository(ctx, *repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ranges, jobs, nil
+}
+
+// trackingRange returns the tracking window of a repository as a sync range ending now when it is open ended
+func trackingRange(repo domain.RepoMetadata) domain.SyncRange {
+	since, until := repo.TrackingWindow()
+	if until.IsZero() {
+		until = time.Now()
+	}
+	return domain.SyncRange{Since: since, Until: until}
+}
+
+// recordSyncRange adds a fetched commit date range to the coverage of a repository
+func (uc *gitRepoUsecase) recordSyncRange(ctx context.Context, repo domain.RepoMetadata, r domain.SyncRange) error {
+	uc.syncRangesMu.Lock()
+	defer uc.syncRangesMu.Unlock()
+
+	ranges, err := uc.backfillRepository.SyncRangesByRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	return uc.backfillRepository.ReplaceSyncRanges(ctx, repo, domain.MergeSyncRanges(append(ranges, r)))
+}
+
+// extendSyncCoverage moves the end of the newest fetched range of an open ended repository up to now,
+// it is called once live reconciliation has caught up with the latest commits
+func (uc *gitRepoUsecase) extendSyncCoverage(ctx context.Context, repo domain.RepoMetadata) error {
+	if repo.TrackUntil != nil {
+		return nil
+	}
+
+	uc.syncRangesMu.Lock()
+	defer uc.syncRangesMu.Unlock()
+
+	ranges, err := uc.backfillRepository.SyncRangesByRepository(ctx, repo)
+	if err != nil || len(ranges) == 0 {
+		return err
+	}
+
+	ranges = domain.MergeSyncRanges(ranges)
+	ranges[len(ranges)-1].Until = time.Now()
+
+	return uc.backfillRepository.ReplaceSyncRanges(ctx, repo, ranges)
+}
+
+// scheduleBackfills creates a backfill job for every part of the tracking window of a repository that is neither
+// fetched nor already scheduled, previous holds the tracking settings of the repository before they changed
+func (uc *gitRepoUsecase) scheduleBackfills(ctx context.Context, repo domain.RepoMetadata, previous domain.RepoMetadata) ([]domain.BackfillJob, error) {
+	covered, err := uc.backfillRepository.SyncRangesByRepository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// repositories indexed before coverage was recorded are assumed to hold their previous window
+	if len(covered) == 0 {
+		if err := uc.recordSyncRange(ctx, repo, trackingRange(previous)); err != nil {
+			return nil, err
+		}
+		covered = []domain.SyncRange{trackingRange(previous)}
+	}
+
+	window := trackingRange(repo)
+	// the newest commits of a repository that was already open ended are fetched by live reconciliation
+	if repo.TrackUntil == nil && previous.TrackUntil == nil {
+		merged := domain.MergeSyncRanges(covered)
+		window.Until = merged[len(merged)-1].Until
+	}
+
+	jobs, err := uc.backfillRepository.BackfillJobsByRepository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.IsActive() {
+			covered = append(covered, domain.SyncRange{Since: job.Since, Until: job.Until})
+		}
+	}
+
+	var scheduled []domain.BackfillJob
+	for _, missing := range domain.MissingSyncRanges(window, covered) {
+		job, err := uc.backfillRepository.SaveBackfillJob(ctx, domain.BackfillJob{
+			RepositoryID: repo.ID,
+			Since:        missing.Since,
+			Until:        missing.Until,
+			Status:       domain.BackfillStatusPending,
+			Page:         1,
+		})
+		if err != nil {
+			return scheduled, err
+		}
+		log.Info().Msgf("backfill of repo %s scheduled from %v to %v", repo.Name, missing.Since, missing.Until)
+		scheduled = append(scheduled, *job)
+	}
+
+	return scheduled, nil
+}
+
+// backfillRetryDelay is the delay before the first retry of a failed backfill job, doubled on every further failure
+const backfillRetryDelay = 5 * time.Minute
+
+// resumeBackfills starts every pending or interrupted backfill job of a repository
+func (uc *gitRepoUsecase) resumeBackfills(ctx context.Context, repo domain.RepoMetadata) {
+	jobs, err := uc.backfillRepository.BackfillJobsByRepository(ctx, repo)
+	if err != nil {
+		log.Err(err).Msgf("Error fetching backfill jobs of repository %s: %v", repo.Name, err)
+		return
+	}
+
+	for _, job := range jobs {
+		if job.IsActive() {
+			go uc.runBackfill(ctx, repo, job)
+		}
+	}
+}
+
+// retryBackfills restarts the failed backfill jobs of a repository whose retry is due
+func (uc *gitRepoUsecase) retryBackfills(ctx context.Context, repo domain.RepoMetadata) {
+	jobs, err := uc.backfillRepository.BackfillJobsByRepository(ctx, repo)
+	if err != nil {
+		log.Err(err).Msgf("Error fetching backfill jobs of repository %s: %v", repo.Name, err)
+		return
+	}
+
+	now := time.Now()
+	for _, job := range jobs {
+		if job.IsRetryable(now) {
+			log.Info().Msgf("retrying backfill of repo %s from %v to %v, attempt %d", repo.Name, job.Since, job.Until, job.Attempts+1)
+			go uc.runBackfill(ctx, repo, job)
+		}
+	}
+}
+
+// runBackfill fetches the commits of a backfill job's range page by page, persisting its progress
+// so that an interrupted job resumes from its last page. A job already being run is left to its runner, and a job
+// stopped by pausing, untracking or retracking its repository is left running, to be resumed rather than retried.
+func (uc *gitRepoUsecase) runBackfill(ctx context.Context, repo domain.RepoMetadata, job domain.BackfillJob) {
+	if _, running := uc.runningBackfills.LoadOrStore(job.ID, true); running {
+		log.Debug().Msgf("backfill job %d of repo %s is already running", job.ID, repo.Name)
+		return
+	}
+	defer uc.runningBackfills.Delete(job.ID)
+	defer uc.trackIndexing()()
+
+	if job.Page < 1 {
+		job.Page = 1
+	}
+	job.Status = domain.BackfillStatusRunning
+	if err := uc.backfillRepository.UpdateBackfillJob(ctx, job); err != nil {
+		return
+	}
+
+	log.Info().Msgf("backfilling commits of repo %s from %v to %v, starting from page-%d", repo.Name, job.Since, job.Until, job.Page)
+	for {
+		if ctx.Err() != nil {
+			log.Warn().Msgf("Git repository [%s] backfill stopped", repo.Name)
+			return
+		}
+
+		commits, morePages, err := uc.gitClient.FetchCommits(ctx, repo, job.Since, job.Until, "", job.Page, uc.perPage(repo))
+		if err != nil && ctx.Err() != nil {
+			log.Warn().Msgf("Git repository [%s] backfill stopped", repo.Name)
+			return
+		}
+		if err != nil {
+			log.Err(err).Msgf("Failed to backfill commits for repository %s: %v", repo.Name, err)
+			uc.failBackfill(ctx, repo, job, err)
+			return
+		}
+
+		saved, err := uc.commitRepository.SaveCommits(ctx, commits)
+		if err != nil && ctx.Err() != nil {
+			log.Warn().Msgf("Git repository [%s] backfill stopped", repo.Name)
+			return
+		}
+		if err != nil {
+			log.Err(err).Msgf("Failed to save backfilled commits for repository %s: %v", repo.Name, err)
+			uc.failBackfill(ctx, repo, job, err)
+			return
+		}
+		job.CommitsSaved += len(saved)
+
+		if !morePages {
+			break
+		}
+
+		job.Page++
+		if err := uc.backfillRepository.UpdateBackfillJob(ctx, job); err != nil {
+			log.Err(err).Msgf("Error updating backfill job of repository %s: %v", repo.Name, err)
+		}
+	}
+
+	if err := uc.recordSyncRange(ctx, repo, domain.SyncRange{Since: job.Since, Until: job.Until}); err != nil {
+		log.Err(err).Msgf("Error recording backfilled range of repository %s: %v", repo.Name, err)
+		return
+	}
+
+	completedAt := time.Now()
+	job.Status = domain.BackfillStatusCompleted
+	job.CompletedAt = &completedAt
+	if err := uc.backfillRepository.UpdateBackfillJob(ctx, job); err != nil {
+		log.Err(err).Msgf("Error updating backfill job of repository %s: %v", repo.Name, err)
+	}
+
+	log.Info().Msgf("backfill of repo %s completed, %d commits saved", repo.Name, job.CommitsSaved)
+}
+
+// failBackfill marks a backfill job as failed and schedules its retry from its last page, with a delay doubling on
+// every failure. A job failing domain.BackfillMaxAttempts times is left for a later history verification to reschedule.
+func (uc *gitRepoUsecase) failBackfill(ctx context.Context, repo domain.RepoMetadata, job domain.BackfillJob, err error) {
+	job.Status = domain.BackfillStatusFailed
+	job.Error = err.Error()
+	job.Attempts++
+	job.RetryAt = nil
+	if job.Attempts < domain.BackfillMaxAttempts {
+		retryAt := time.Now().Add(backfillRetryDelay << (job.Attempts - 1))
+		job.RetryAt = &retryAt
+	}
+	if err := uc.backfillRepository.UpdateBackfillJob(context.WithoutCancel(ctx), job); err != nil {
+		log.Err(err).Msgf("Error updating backfill job of repository %s: %v", repo.Name, err)
+	}
+}
@@ -0,0 +1,163 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+// branchesPerPage is the largest page size of the branches API
+const branchesPerPage = 100
+
+// syncBranches ingests the commits of the branches of a repository tracked by its primary branch and branch
+// patterns whose head moved since their last sync, and stops tracking the branches that were deleted upstream
+// or no longer match a pattern
+func (uc *gitRepoUsecase) syncBranches(ctx context.Context, repo domain.RepoMetadata) error {
+	var upstream []domain.Branch
+	for page := 1; ; page++ {
+		branches, morePages, err := uc.gitClient.FetchBranches(ctx, repo, page, branchesPerPage)
+		if err != nil {
+			return err
+		}
+		upstream = append(upstream, branches...)
+
+		if !morePages {
+			break
+		}
+	}
+
+	stored, err := uc.branchRepository.BranchesByRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	storedByName := make(map[string]domain.Branch, len(stored))
+	for _, b := range stored {
+		storedByName[b.Name] = b
+	}
+
+	tracked := make(map[string]bool)
+	for _, b := range upstream {
+		if !repo.TracksBranch(b.Name) {
+			continue
+		}
+		tracked[b.Name] = true
+
+		if s, ok := storedByName[b.Name]; ok && s.SyncedSHA == b.HeadSHA {
+			continue
+		}
+		if err := uc.syncBranch(ctx, repo, b, storedByName[b.Name].SyncedSHA); err != nil {
+			log.Err(err).Msgf("Error syncing branch %s of repository %s: %v", b.Name, repo.Name, err)
+		}
+	}
+
+	for _, b := range stored {
+		if tracked[b.Name] {
+			continue
+		}
+		if err := uc.branchRepository.DeleteBranch(ctx, repo, b.Name); err != nil {
+			log.Err(err).Msgf("Error removing branch %s of repository %s: %v", b.Name, repo.Name, err)
+			continue
+		}
+		log.Info().Msgf("branch %s of repository %s is no longer tracked", b.Name, repo.Name)
+	}
+	return nil
+}
+
+// syncBranch walks the commits reachable from the head of a branch within the tracking window, newest first, saving
+// the commits and their membership of the branch until a page holds no commit new to the branch. A branch synced for
+// the first time, or whose previously synced head is not reached by then because it was force-pushed, is walked to
+// the end of its history and the memberships of the commits no longer reachable from it are removed.
+func (uc *gitRepoUsecase) syncBranch(ctx context.Context, repo domain.RepoMetadata, branch domain.Branch, syncedSHA string) error {
+	since, until := repo.TrackingWindow()
+	fullWalk := syncedSHA == ""
+	reachedSynced := false
+	var reachable []string
+	added := 0
+	for page := 1; ; page++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		commits, morePages, err := uc.gitClient.FetchCommits(ctx, repo, since, until, branch.HeadSHA, page, uc.perPage(repo))
+		if err != nil {
+			return err
+		}
+
+		if _, err := uc.commitRepository.SaveCommits(ctx, commits); err != nil {
+			return err
+		}
+
+		ids := commitIDs(commits)
+		n, err := uc.branchRepository.SaveBranchCommits(ctx, repo, branch.Name, ids)
+		if err != nil {
+			return err
+		}
+		added += n
+		reachable = append(reachable, ids...)
+		reachedSynced = reachedSynced || slices.Contains(ids, syncedSHA)
+
+		if !morePages {
+			break
+		}
+		if n == 0 && !fullWalk {
+			if reachedSynced {
+				break
+			}
+			log.Info().Msgf("branch %s of repository %s was rewritten since %s, walking its whole history", branch.Name, repo.Name, syncedSHA)
+			fullWalk = true
+		}
+	}
+
+	if fullWalk {
+		removed, err := uc.branchRepository.PruneBranchCommits(ctx, repo, branch.Name, reachable)
+		if err != nil {
+			return err
+		}
+		if removed > 0 {
+			log.Info().Msgf("%d commits no longer reachable from branch %s of repository %s removed from it", removed, branch.Name, repo.Name)
+		}
+	}
+
+	branch.RepositoryID = repo.ID
+	branch.SyncedSHA = branch.HeadSHA
+	branch.IsDefault = branch.Name == repo.DefaultBranch
+	if _, err := uc.branchRepository.SaveBranch(ctx, branch); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("branch %s of repository %s synced to %s, %d commits added", branch.Name, repo.Name, branch.HeadSHA, added)
+	return nil
+}
+
+// primaryBranchCommits drops the commits only reachable from tracked branches other than the primary branch,
+// the commits recorded on no branch are kept
+func (uc *gitRepoUsecase) primaryBranchCommits(ctx context.Context, repo domain.RepoMetadata, commits []domain.Commit) ([]domain.Commit, error) {
+	primary := repo.PrimaryBranch()
+	if len(repo.TrackedBranches) == 0 || primary == "" {
+		return commits, nil
+	}
+
+	branches, err := uc.branchRepository.BranchesByCommitIDs(ctx, repo, commitIDs(commits))
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]domain.Commit, 0, len(commits))
+	for _, c := range commits {
+		if names := branches[c.CommitID]; len(names) > 0 && !slices.Contains(names, primary) {
+			continue
+		}
+		kept = append(kept, c)
+	}
+	return kept, nil
+}
+
+func commitIDs(commits []domain.Commit) []string {
+	ids := make([]string, 0, len(commits))
+	for _, c := range commits {
+		ids = append(ids, c.CommitID)
+	}
+	return ids
+}
@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// checksPerPage is the largest page size of the statuses and check runs APIs
+	checksPerPage = 100
+	// checksSyncBatchSize is the number of commits whose checks are synced per sync
+	checksSyncBatchSize = 50
+	// checksLookback bounds the commits whose checks are synced to the recent ones, the checks of older
+	// commits are not synced when a repository is first indexed
+	checksLookback = 7 * 24 * time.Hour
+	// checksSettleAfter is how long after its commit date the checks of a commit keep being synced once
+	// they are all completed, so the checks re-run on the commit are recorded
+	checksSettleAfter = 24 * time.Hour
+)
+
+// syncChecks stores the statuses and check runs of the recent commits of the tracked branches of a repository
+// whose checks are not settled yet
+func (uc *gitRepoUsecase) syncChecks(ctx context.Context, repo domain.RepoMetadata) error {
+	since := time.Now().Add(-checksLookback)
+	if trackSince, _ := repo.TrackingWindow(); trackSince.After(since) {
+		since = trackSince
+	}
+
+	commits, err := uc.checkRepository.CommitsPendingChecks(ctx, repo, since, checksSyncBatchSize)
+	if err != nil {
+		return err
+	}
+
+	settled := 0
+	for _, c := range commits {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		runs, err := uc.fetchCheckRuns(ctx, repo, c.CommitID)
+		if err != nil {
+			return err
+		}
+
+		done := time.Since(c.CommitDate()) > checksSettleAfter
+		for _, r := range runs {
+			if r.Outcome() == domain.CheckPending {
+				done = false
+				break
+			}
+		}
+
+		if err := uc.checkRepository.SaveCheckRuns(ctx, repo, c.CommitID, runs, done); err != nil {
+			return err
+		}
+		if done {
+			settled++
+		}
+	}
+
+	if len(commits) > 0 {
+		log.Info().Msgf("checks of %d commits of repository %s synced, %d settled", len(commits), repo.Name, settled)
+	}
+	return nil
+}
+
+// fetchCheckRuns fetches the statuses and the check runs of a commit
+func (uc *gitRepoUsecase) fetchCheckRuns(ctx context.Context, repo domain.RepoMetadata, sha string) ([]domain.CheckRun, error) {
+	var runs []domain.CheckRun
+	for page := 1; ; page++ {
+		statuses, morePages, err := uc.gitClient.FetchCommitStatuses(ctx, repo, sha, page, checksPerPage)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, statuses...)
+
+		if !morePages {
+			break
+		}
+	}
+
+	for page := 1; ; page++ {
+		checkRuns, morePages, err := uc.gitClient.FetchCheckRuns(ctx, repo, sha, page, checksPerPage)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, checkRuns...)
+
+		if !morePages {
+			break
+		}
+	}
+	return runs, nil
+}
@@ -0,0 +1,198 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/rs/zerolog/log"
+)
+
+// syncContributors resolves the authors of the commits of a repository not resolved yet to contributors, then
+// applies the .mailmap file of the repository to the contributors when it changed or new authors were resolved
+func (uc *gitRepoUsecase) syncContributors(ctx context.Context, repo domain.RepoMetadata) error {
+	authors, err := uc.contributorRepository.UnresolvedAuthors(ctx, repo)
+	if err != nil {
+		return err
+	}
+	for _, author := range authors {
+		if err := uc.resolveAuthor(ctx, author); err != nil {
+			return err
+		}
+	}
+	if len(authors) > 0 {
+		log.Info().Msgf("%d author identities of repository %s resolved", len(authors), repo.Name)
+	}
+
+	// the contributors of the authors just resolved are not mapped yet, the file is applied again
+	etag := repo.MailmapETag
+	if len(authors) > 0 {
+		etag = ""
+	}
+	mailmap, err := uc.gitClient.FetchMailmap(ctx, repo, etag)
+	if err != nil {
+		return err
+	}
+	if mailmap == nil {
+		return nil
+	}
+	for _, entry := range domain.ParseMailmap(mailmap.Content) {
+		if err := uc.applyMailmapEntry(ctx, repo, entry); err != nil {
+			return err
+		}
+	}
+	if mailmap.ETag == repo.MailmapETag {
+		return nil
+	}
+	return uc.repoMetadataRepository.UpdateMailmapETag(ctx, repo.PublicID, mailmap.ETag)
+}
+
+// resolveAuthor resolves an author identity to a contributor. An author with a login is also known by the email of
+// their commits, so that the commits of that email not linked to the login resolve to the same contributor; the
+// contributors the identity and the email were resolved to apart are merged. An email already resolved to a
+// contributor with another login stays with it, so that a login never joins a contributor of another login.
+func (uc *gitRepoUsecase) resolveAuthor(ctx context.Context, author domain.Identity) error {
+	identities := []domain.ContributorIdentity{
+		{Key: author.Key(), Name: author.Name, Email: author.Email, Login: author.Login, Source: domain.IdentitySourceCommit},
+	}
+	if author.Login != "" && author.Email != "" {
+		identities = append(identities, domain.ContributorIdentity{
+			Key:    domain.Identity{Email: author.Email}.Key(),
+			Name:   author.Name,
+			Email:  author.Email,
+			Source: domain.IdentitySourceLogin,
+		})
+	}
+
+	var contributors []domain.Contributor
+	resolved := make([]domain.ContributorIdentity, 0, len(identities))
+	for _, identity := range identities {
+		c, err := uc.contributorRepository.ContributorByIdentityKey(ctx, identity.Key)
+		if err == message.ErrNoRecordFound {
+			resolved = append(resolved, identity)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if identity.Source == domain.IdentitySourceLogin && loginConflicts(*c, author.Login) {
+			log.Debug().Msgf("email %s of login %s is already claimed by login %s", author.Email, author.Login, c.Login)
+			continue
+		}
+		resolved = append(resolved, identity)
+		contributors = append(contributors, *c)
+	}
+
+	if len(contributors) == 0 {
+		_, err := uc.contributorRepository.CreateContributor(ctx, domain.Contributor{
+			PublicID: uuid.New().String(),
+			Name:     author.Name,
+			Email:    author.Email,
+			Login:    author.Login,
+		}, resolved)
+		return err
+	}
+
+	target := contributors[0]
+	if err := uc.contributorRepository.MergeContributors(ctx, target, contributors[1:]); err != nil {
+		return err
+	}
+	if target.Login == "" && author.Login != "" {
+		target.Login = author.Login
+		if err := uc.contributorRepository.UpdateContributor(ctx, target); err != nil {
+			return err
+		}
+	}
+	return uc.contributorRepository.AddContributorIdentities(ctx, target, resolved)
+}
+
+// loginConflicts reports whether a contributor already has a login other than login
+func loginConflicts(contributor domain.Contributor, login string) bool {
+	return login != "" && contributor.Login != "" && !strings.EqualFold(contributor.Login, login)
+}
+
+// applyMailmapEntry maps the contributor of the commit email of a .mailmap entry to its proper name and email,
+// merging it into the contributor of the proper email when there is one. The file of a repository only maps the
+// contributors authoring in that repository alone, the contributors of other repositories are left as they are.
+func (uc *gitRepoUsecase) applyMailmapEntry(ctx context.Context, repo domain.RepoMetadata, entry domain.MailmapEntry) error {
+	commitKey := domain.Identity{Email: entry.CommitEmail}.Key()
+	contributor, err := uc.contributorRepository.ContributorByIdentityKey(ctx, commitKey)
+	if err == message.ErrNoRecordFound {
+		// no stored commit was authored with the email
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !mailmapMatches(entry, *contributor, commitKey) {
+		return nil
+	}
+	outside, err := uc.contributorRepository.AuthoredOutsideRepository(ctx, repo, identityKeys(*contributor))
+	if err != nil || outside {
+		return err
+	}
+
+	target := *contributor
+	if entry.ProperEmail != "" && !strings.EqualFold(entry.ProperEmail, entry.CommitEmail) {
+		properKey := domain.Identity{Email: entry.ProperEmail}.Key()
+		proper, err := uc.contributorRepository.ContributorByIdentityKey(ctx, properKey)
+		switch {
+		case err == message.ErrNoRecordFound:
+			err := uc.contributorRepository.AddContributorIdentities(ctx, target, []domain.ContributorIdentity{
+				{Key: properKey, Name: entry.ProperName, Email: entry.ProperEmail, Source: domain.IdentitySourceMailmap},
+			})
+			if err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		case proper.ID != contributor.ID:
+			if loginConflicts(*proper, contributor.Login) {
+				log.Debug().Msgf("mailmap of repository %s maps login %s to login %s, skipped", repo.Name, contributor.Login, proper.Login)
+				return nil
+			}
+			if err := uc.contributorRepository.MergeContributors(ctx, *proper, []domain.Contributor{*contributor}); err != nil {
+				return err
+			}
+			// the proper contributor keeps its name and email when it also authors in other repositories
+			outside, err := uc.contributorRepository.AuthoredOutsideRepository(ctx, repo, identityKeys(*proper))
+			if err != nil || outside {
+				return err
+			}
+			target = *proper
+		}
+	}
+
+	updated := target
+	if entry.ProperName != "" {
+		updated.Name = entry.ProperName
+	}
+	if entry.ProperEmail != "" {
+		updated.Email = entry.ProperEmail
+	}
+	if updated.Name == target.Name && updated.Email == target.Email {
+		return nil
+	}
+	return uc.contributorRepository.UpdateContributor(ctx, updated)
+}
+
+// identityKeys returns the identity keys resolved to a contributor
+func identityKeys(contributor domain.Contributor) []string {
+	keys := make([]string, 0, len(contributor.Identities))
+	for _, identity := range contributor.Identities {
+		keys = append(keys, identity.Key)
+	}
+	return keys
+}
+
+// mailmapMatches reports whether a .mailmap entry maps the identity key of a contributor
+func mailmapMatches(entry domain.MailmapEntry, contributor domain.Contributor, key string) bool {
+	for _, identity := range contributor.Identities {
+		if identity.Key == key {
+			return entry.Matches(domain.Identity{Name: identity.Name, Email: identity.Email})
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// commitFilesPerPage is the largest page size of the files of a commit
+	commitFilesPerPage = 300
+	// enrichmentBatchSize is the number of commits of a repository loaded at once to be enriched
+	enrichmentBatchSize = 100
+	// enrichmentRateLimitReserve is the share of the rate limit the enrichment worker leaves to the indexing jobs
+	enrichmentRateLimitReserve = 0.25
+	// enrichmentRetryAfter is how long the detail of a commit that failed with a transient error waits to be fetched again
+	enrichmentRetryAfter = time.Hour
+	// enrichmentMaxAttempts is the number of transient failures after which the detail of a commit is no longer fetched
+	enrichmentMaxAttempts = 5
+)
+
+// EnrichmentState returns the progress of the enrichment of the commits of a repository with their file changes
+func (uc *gitRepoUsecase) EnrichmentState(ctx context.Context, repoId string) (*domain.EnrichmentState, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.enrichmentRepository.EnrichmentStateByRepository(ctx, *repo)
+}
+
+// StartEnrichment enriches the stored commits with their stats and file changes every enrichment interval,
+// within the request budget of a run, until ctx is done
+func (uc *gitRepoUsecase) StartEnrichment(ctx context.Context) {
+	if uc.config.EnrichmentInterval <= 0 || uc.config.EnrichmentBudget <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(uc.config.EnrichmentInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			uc.enrichCommits(ctx)
+		}
+	}
+}
+
+// enrichCommits runs an enrichment of the commits of the active repositories, the budget of the run is capped by the
+// rate limit left to the indexing jobs and shared between the repositories, and the run yields to any indexing job.
+// A repository the run fails on is skipped until the next run.
+func (uc *gitRepoUsecase) enrichCommits(ctx context.Context) {
+	if uc.indexingJobs.Load() > 0 {
+		log.Debug().Msg("commits enrichment skipped while commits are being indexed")
+		return
+	}
+
+	rateLimit, err := uc.gitClient.FetchRateLimit(ctx)
+	if err != nil {
+		log.Err(err).Msgf("Error fetching rate limit for commits enrichment: %v", err)
+		return
+	}
+	budget := domain.EnrichmentBudget(uc.config.EnrichmentBudget, *rateLimit, enrichmentRateLimitReserve)
+	if budget == 0 {
+		log.Debug().Msg("commits enrichment skipped, the rate limit is left to the indexing jobs")
+		return
+	}
+
+	repos, err := uc.repoMetadataRepository.AllRepoMetadata(ctx)
+	if err != nil {
+		log.Err(err).Msgf("Error fetching repositories for commits enrichment: %v", err)
+		return
+	}
+	var active []domain.RepoMetadata
+	for _, r := range repos {
+		if !r.Paused && r.State() == domain.UpstreamStateActive {
+			active = append(active, r)
+		}
+	}
+
+	for i, repo := range active {
+		// the budget left by the previous repositories is shared with the next ones
+		used, err := uc.enrichRepository(ctx, repo, budget/(len(active)-i))
+		budget -= used
+
+		runErr := ""
+		if err != nil {
+			runErr = err.Error()
+		}
+		if err := uc.enrichmentRepository.SaveEnrichmentRun(context.WithoutCancel(ctx), repo, time.Now(), runErr); err != nil {
+			log.Err(err).Msgf("Error saving enrichment run of repository %s: %v", repo.Name, err)
+		}
+
+		if err == message.ErrRateLimitExceeded || err == message.ErrEnrichmentYielded || ctx.Err() != nil {
+			log.Info().Msgf("commits enrichment stopped on repository %s: %v", repo.Name, err)
+			return
+		}
+		if err != nil && err != message.ErrRepoGone {
+			log.Err(err).Msgf("Error enriching commits of repository %s: %v", repo.Name, err)
+		}
+	}
+}
+
+// enrichRepository enriches the commits of a repository not enriched yet, newest first, within budget requests and
+// returns the number of requests sent. A commit whose detail is not found or not available upstream is recorded as
+// failed, one whose detail fails with a transient error is fetched again after enrichmentRetryAfter.
+func (uc *gitRepoUsecase) enrichRepository(ctx context.Context, repo domain.RepoMetadata, budget int) (int, error) {
+	used, enriched := 0, 0
+	defer func() {
+		if enriched > 0 {
+			log.Info().Msgf("%d commits of repository %s enriched with %d requests", enriched, repo.Name, used)
+		}
+	}()
+
+	for used < budget {
+		commits, err := uc.enrichmentRepository.UnenrichedCommits(ctx, repo, min(enrichmentBatchSize, budget-used))
+		if err != nil {
+			return used, err
+		}
+		if len(commits) == 0 {
+			return used, nil
+		}
+
+		for _, c := range commits {
+			if ctx.Err() != nil {
+				return used, ctx.Err()
+			}
+			if uc.indexingJobs.Load() > 0 {
+				return used, message.ErrEnrichmentYielded
+			}
+			if used >= budget {
+				return used, nil
+			}
+
+			detail, requests, err := uc.fetchCommitDetail(ctx, repo, c.CommitID)
+			used += requests
+			if err == message.ErrRateLimitExceeded || err == message.ErrRepoGone || ctx.Err() != nil {
+				return used, err
+			}
+			if err == message.ErrUnknownRef || err == message.ErrCommitDetailUnavailable {
+				if err := uc.enrichmentRepository.SaveEnrichmentFailure(ctx, c.CommitID, err.Error()); err != nil {
+					return used, err
+				}
+				continue
+			}
+			if err != nil {
+				retryAt := time.Now().Add(enrichmentRetryAfter)
+				if err := uc.enrichmentRepository.SaveEnrichmentRetry(ctx, c.CommitID, err.Error(), retryAt, enrichmentMaxAttempts); err != nil {
+					return used, err
+				}
+				continue
+			}
+
+			if err := uc.enrichmentRepository.SaveCommitDetail(ctx, c.CommitID, *detail); err != nil {
+				return used, err
+			}
+			enriched++
+		}
+	}
+	return used, nil
+}
+
+// fetchCommitDetail fetches the stats and every page of the file changes of a commit, along with the number of requests sent
+func (uc *gitRepoUsecase) fetchCommitDetail(ctx context.Context, repo domain.RepoMetadata, sha string) (*domain.CommitDetail, int, error) {
+	var detail domain.CommitDetail
+	requests := 0
+	for page := 1; ; page++ {
+		d, morePages, err := uc.gitClient.FetchCommitDetail(ctx, repo, sha, page, commitFilesPerPage)
+		requests++
+		if err != nil {
+			return nil, requests, err
+		}
+		detail.Stats = d.Stats
+		detail.Files = append(detail.Files, d.Files...)
+
+		if !morePages {
+			break
+		}
+	}
+
+	detail.Stats.FilesChanged = len(detail.Files)
+	return &detail, requests, nil
+}
+
+// trackIndexing records a running indexing job, which the enrichment worker yields to, until the returned func is called
+func (uc *gitRepoUsecase) trackIndexing() func() {
+	uc.indexingJobs.Add(1)
+	return func() {
+		uc.indexingJobs.Add(-1)
+	}
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/pkg/helpers"
+	"github.com/kenmobility/git-api-service/pkg/message"
+)
+
+const (
+	// estimatedRequestDuration is the average time a git provider request takes
+	estimatedRequestDuration = 500 * time.Millisecond
+	// estimatedCommitBytes is the average storage footprint of a commit row including its indexes
+	estimatedCommitBytes = 600
+	// rateLimitWindow is the time after which the git provider rate limit resets
+	rateLimitWindow = time.Hour
+)
+
+// Estimate probes the git provider for the cost of indexing a repository with the given tracking
+// settings without persisting anything
+func (uc *gitRepoUsecase) Estimate(ctx context.Context, repositoryName string, opts domain.TrackingOptions) (*domain.IndexingEstimate, error) {
+	if !helpers.IsRepositoryNameValid(repositoryName) {
+		return nil, message.ErrInvalidRepositoryName
+	}
+
+	settings, err := uc.trackingSettings(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := uc.gitClient.FetchRepoMetadata(ctx, repositoryName)
+	if err != nil {
+		return nil, err
+	}
+	repo.Branch = settings.Branch
+
+	since, until := settings.TrackingWindow()
+	commits, err := uc.gitClient.CountCommits(ctx, *repo, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimit, err := uc.gitClient.FetchRateLimit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the repository metadata request plus one request per page of commits
+	perPage := uc.perPage(settings)
+	requests := 1 + (commits+perPage-1)/perPage
+	if commits == 0 {
+		requests++
+	}
+
+	return &domain.IndexingEstimate{
+		RepositoryName:    repo.Name,
+		Since:             settings.TrackSince,
+		Until:             settings.TrackUntil,
+		Branch:            settings.Branch,
+		CommitsPerPage:    perPage,
+		Commits:           commits,
+		Requests:          requests,
+		EstimatedDuration: estimateDuration(requests, *rateLimit, time.Now()),
+		StorageBytes:      int64(commits) * estimatedCommitBytes,
+		RateLimit:         *rateLimit,
+	}, nil
+}
+
+// estimateDuration returns the time needed to make a number of requests, including the waits
+// for the rate limit to reset once the remaining requests are used up
+func estimateDuration(requests int, rateLimit domain.RateLimit, now time.Time) time.Duration {
+	duration := time.Duration(requests) * estimatedRequestDuration
+	if requests <= rateLimit.Remaining || rateLimit.Limit <= 0 {
+		return duration
+	}
+
+	// wait for the current window to reset, then one more full window for every limit of requests left over
+	overflow := requests - rateLimit.Remaining
+	windows := (overflow - 1) / rateLimit.Limit
+
+	if wait := rateLimit.Reset.Sub(now); wait > 0 {
+		duration += wait
+	}
+	return duration + time.Duration(windows)*rateLimitWindow
+}
@@ -0,0 +1,222 @@
+package usecases
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/rs/zerolog/log"
+)
+
+// Verify checks the stored commit history of a repository against the git provider and schedules
+// backfill jobs refetching the date ranges found to be incomplete
+func (uc *gitRepoUsecase) Verify(ctx context.Context, repoId string) (*domain.IntegrityReport, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, err
+	}
+
+	if repo.IsFetching {
+		return nil, message.ErrRepoFetchInProgress
+	}
+
+	return uc.verifyHistory(ctx, *repo)
+}
+
+// IntegrityReport returns the latest history verification of a repository
+func (uc *gitRepoUsecase) IntegrityReport(ctx context.Context, repoId string) (*domain.IntegrityReport, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := uc.integrityRepository.LatestIntegrityReport(ctx, *repo)
+	if err == message.ErrNoRecordFound {
+		return nil, message.ErrRepoNotVerified
+	}
+	return report, err
+}
+
+// countBuckets sets the provider's count of commits of every bucket, counting the whole span of the buckets at once
+// and only splitting a span in halves when its count differs from the stored one. The buckets of a span whose
+// counts match are taken to be complete, so a verification of a complete history costs a single request.
+func (uc *gitRepoUsecase) countBuckets(ctx context.Context, repo domain.RepoMetadata, buckets []domain.IntegrityBucket) error {
+	if len(buckets) == 0 {
+		return nil
+	}
+
+	since, until := buckets[0].Since, buckets[len(buckets)-1].Until
+	count, err := uc.gitClient.CountCommits(ctx, repo, since, until)
+	if err != nil {
+		log.Err(err).Msgf("Failed to count commits of repository %s from %v to %v: %v", repo.Name, since, until, err)
+		return err
+	}
+
+	stored := 0
+	for _, b := range buckets {
+		stored += b.StoredCount
+	}
+	if len(buckets) == 1 {
+		buckets[0].ProviderCount = count
+		return nil
+	}
+	if count == stored {
+		for i := range buckets {
+			buckets[i].ProviderCount = buckets[i].StoredCount
+		}
+		return nil
+	}
+
+	half := len(buckets) / 2
+	if err := uc.countBuckets(ctx, repo, buckets[:half]); err != nil {
+		return err
+	}
+	return uc.countBuckets(ctx, repo, buckets[half:])
+}
+
+// verifyHistory compares the stored commits of every month of the tracking window of a repository with the
+// provider's count and walks their parent graph for orphaned commits, then persists the result
+func (uc *gitRepoUsecase) verifyHistory(ctx context.Context, repo domain.RepoMetadata) (*domain.IntegrityReport, error) {
+	log.Info().Msgf("verifying commit history of repo: %s", repo.Name)
+
+	commits, err := uc.commitRepository.CommitsByRepository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	// the provider counts the commits of the primary branch only
+	commits, err = uc.primaryBranchCommits(ctx, repo, commits)
+	if err != nil {
+		return nil, err
+	}
+
+	var first time.Time
+	for _, c := range commits {
+		if first.IsZero() || c.CommitDate().Before(first) {
+			first = c.CommitDate()
+		}
+	}
+
+	window := trackingRange(repo)
+	buckets := domain.MonthlyBuckets(window.Since, first, window.Until)
+
+	for _, c := range commits {
+		date := c.CommitDate()
+		i := sort.Search(len(buckets), func(i int) bool { return buckets[i].Until.After(date) })
+		if i < len(buckets) && !date.Before(buckets[i].Since) {
+			buckets[i].StoredCount++
+		}
+	}
+
+	if err := uc.countBuckets(ctx, repo, buckets); err != nil {
+		return nil, err
+	}
+
+	report := domain.IntegrityReport{
+		RepositoryID: repo.ID,
+		Completeness: domain.Completeness(buckets),
+		Buckets:      buckets,
+		VerifiedAt:   time.Now(),
+	}
+
+	for _, b := range buckets {
+		report.StoredCount += b.StoredCount
+		report.ProviderCount += b.ProviderCount
+		if b.IsIncomplete() {
+			report.MissingRanges = append(report.MissingRanges, domain.SyncRange{Since: b.Since, Until: b.Until})
+		}
+	}
+
+	// the missing parent of an orphaned commit was committed before it, in its month or the previous one
+	var orphanRanges []domain.SyncRange
+	if len(buckets) > 0 {
+		report.OrphanedCommits = domain.OrphanedCommits(commits, buckets[0].Until)
+
+		dates := make(map[string]time.Time, len(commits))
+		for _, c := range commits {
+			dates[c.CommitID] = c.CommitDate()
+		}
+		for _, sha := range report.OrphanedCommits {
+			i := sort.Search(len(buckets), func(i int) bool { return buckets[i].Until.After(dates[sha]) })
+			if i == 0 || i == len(buckets) {
+				continue
+			}
+			orphanRanges = append(orphanRanges, domain.SyncRange{Since: buckets[i-1].Since, Until: buckets[i].Until})
+		}
+	}
+
+	jobs, err := uc.scheduleRefetches(ctx, repo, report.MissingRanges, orphanRanges)
+	if err != nil {
+		log.Err(err).Msgf("Error scheduling refetches of repository %s: %v", repo.Name, err)
+	}
+	report.RefetchJobs = len(jobs)
+
+	saved, err := uc.integrityRepository.SaveIntegrityReport(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("verified commit history of repo %s: %.2f%% complete, %d orphaned commits, %d refetches scheduled",
+		repo.Name, saved.Completeness*100, len(saved.OrphanedCommits), saved.RefetchJobs)
+	return saved, nil
+}
+
+// scheduleRefetches creates and starts a backfill job for every part of the missing ranges and of the ranges of
+// orphaned commits not already covered by an open backfill job of the repository. The range of an orphaned commit
+// already refetched once is not refetched again, its parent is out of reach of the tracked history.
+func (uc *gitRepoUsecase) scheduleRefetches(ctx context.Context, repo domain.RepoMetadata, missing, orphaned []domain.SyncRange) ([]domain.BackfillJob, error) {
+	if len(missing) == 0 && len(orphaned) == 0 {
+		return nil, nil
+	}
+
+	jobs, err := uc.backfillRepository.BackfillJobsByRepository(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	var open, refetched []domain.SyncRange
+	for _, job := range jobs {
+		r := domain.SyncRange{Since: job.Since, Until: job.Until}
+		if job.IsOpen() {
+			open = append(open, r)
+		}
+		if job.IsOpen() || job.Status == domain.BackfillStatusCompleted {
+			refetched = append(refetched, r)
+		}
+	}
+
+	var ranges []domain.SyncRange
+	for _, r := range domain.MergeSyncRanges(missing) {
+		ranges = append(ranges, domain.MissingSyncRanges(r, open)...)
+	}
+	for _, r := range domain.MergeSyncRanges(orphaned) {
+		ranges = append(ranges, domain.MissingSyncRanges(r, refetched)...)
+	}
+
+	var scheduled []domain.BackfillJob
+	for _, r := range domain.MergeSyncRanges(ranges) {
+		job, err := uc.backfillRepository.SaveBackfillJob(ctx, domain.BackfillJob{
+			RepositoryID: repo.ID,
+			Since:        r.Since,
+			Until:        r.Until,
+			Status:       domain.BackfillStatusPending,
+			Page:         1,
+		})
+		if err != nil {
+			return scheduled, err
+		}
+		log.Info().Msgf("refetch of repo %s scheduled from %v to %v", repo.Name, r.Since, r.Until)
+		scheduled = append(scheduled, *job)
+	}
+
+	// paused repositories run their refetches once resumed
+	if !repo.Paused {
+		repoCtx := uc.monitors.start(context.WithoutCancel(ctx), repo.PublicID)
+		for _, job := range scheduled {
+			go uc.runBackfill(repoCtx, repo, job)
+		}
+	}
+
+	return scheduled, nil
+}
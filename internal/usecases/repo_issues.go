@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// issuesPerPage is the largest page size of the issues API
+	issuesPerPage = 100
+	// referenceParseBatchSize is the number of commits parsed for references per batch
+	referenceParseBatchSize = 500
+)
+
+// syncIssues stores the issues of a repository updated since the last sync and within its tracking window
+func (uc *gitRepoUsecase) syncIssues(ctx context.Context, repo domain.RepoMetadata) error {
+	since, _ := repo.TrackingWindow()
+	latest, err := uc.issueRepository.LatestIssueUpdate(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if latest != nil && latest.After(since) {
+		since = *latest
+	}
+
+	synced := 0
+	for page := 1; ; page++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		issues, morePages, err := uc.gitClient.FetchIssues(ctx, repo, since, page, issuesPerPage)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.issueRepository.SaveIssues(ctx, issues); err != nil {
+			return err
+		}
+		synced += len(issues)
+
+		if !morePages {
+			break
+		}
+	}
+
+	if synced > 0 {
+		log.Info().Msgf("%d issues of repository %s synced", synced, repo.Name)
+	}
+	return nil
+}
+
+// syncCommitReferences parses the messages of the commits of a repository not parsed yet for issue references
+// and for the ticket references matching the configured patterns
+func (uc *gitRepoUsecase) syncCommitReferences(ctx context.Context, repo domain.RepoMetadata) error {
+	parsed := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		commits, err := uc.issueRepository.UnparsedCommits(ctx, repo, referenceParseBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(commits) == 0 {
+			break
+		}
+
+		var refs []domain.CommitReference
+		for _, c := range commits {
+			for _, ref := range domain.ParseReferences(c.Message, repo.Name, uc.config.TicketPatterns) {
+				ref.RepositoryID = repo.ID
+				ref.CommitID = c.CommitID
+				refs = append(refs, ref)
+			}
+		}
+
+		if err := uc.issueRepository.SaveCommitReferences(ctx, repo, commitIDs(commits), refs); err != nil {
+			return err
+		}
+		parsed += len(commits)
+
+		if len(commits) < referenceParseBatchSize {
+			break
+		}
+	}
+
+	if parsed > 0 {
+		log.Info().Msgf("%d commits of repository %s parsed for references", parsed, repo.Name)
+	}
+	return nil
+}
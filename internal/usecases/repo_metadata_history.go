@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/kenmobility/git-api-service/pkg/message"
+	"github.com/rs/zerolog/log"
+)
+
+// MetadataHistory returns the metadata snapshots of a repository captured in [from, to), downsampled to the
+// latest snapshot of every interval, a zero from or to leaves the history unbounded on that side
+func (uc *gitRepoUsecase) MetadataHistory(ctx context.Context, repoId string, from time.Time, to time.Time, interval string) ([]domain.RepoMetadataSnapshot, error) {
+	snapshotInterval := domain.SnapshotIntervalDay
+	if interval != "" {
+		snapshotInterval = domain.SnapshotInterval(interval)
+	}
+	if !snapshotInterval.IsValid() {
+		return nil, message.ErrInvalidInterval
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, message.ErrInvalidHistoryWindow
+	}
+
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := uc.snapshotRepository.MetadataSnapshots(ctx, *repo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.DownsampleSnapshots(snapshots, snapshotInterval), nil
+}
+
+// refreshRepoMetadata updates the stars, forks, watchers, open issues, language, description and lifecycle fields of
+// a repository from the git provider and appends them to its metadata history, following the repository if it moved
+// upstream and updating its upstream state
+func (uc *gitRepoUsecase) refreshRepoMetadata(ctx context.Context, repo domain.RepoMetadata) (*domain.RepoMetadata, error) {
+	upstream, err := uc.fetchUpstreamMetadata(ctx, &repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.followUpstreamIdentity(ctx, &repo, *upstream); err != nil {
+		log.Err(err).Msgf("Error following upstream name of repository %s: %v", repo.Name, err)
+	}
+
+	if err := uc.updateUpstreamState(ctx, &repo, upstream.UpstreamState); err != nil {
+		log.Err(err).Msgf("Error updating upstream state of repository %s: %v", repo.Name, err)
+	}
+
+	now := time.Now()
+	repo.Description = upstream.Description
+	repo.Language = upstream.Language
+	repo.ForksCount = upstream.ForksCount
+	repo.StarsCount = upstream.StarsCount
+	repo.OpenIssuesCount = upstream.OpenIssuesCount
+	repo.WatchersCount = upstream.WatchersCount
+	repo.Archived = upstream.Archived
+	repo.Disabled = upstream.Disabled
+	repo.Visibility = upstream.Visibility
+	repo.PushedAt = upstream.PushedAt
+	repo.DefaultBranch = upstream.DefaultBranch
+	repo.UpdatedAt = now
+
+	updated, err := uc.repoMetadataRepository.UpdateRepoStats(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.snapshotRepository.SaveMetadataSnapshot(ctx, domain.NewRepoMetadataSnapshot(*updated, now)); err != nil {
+		return nil, err
+	}
+
+	log.Info().Msgf("metadata of repository %s refreshed", updated.Name)
+	return updated, nil
+}
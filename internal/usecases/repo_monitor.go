@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+)
+
+// repoMonitors keeps track of the cancellable contexts under which a repository's
+// indexing and monitoring goroutines run, keyed by repository public id
+type repoMonitors struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelFunc
+	ctxs    map[string]context.Context
+}
+
+func newRepoMonitors() *repoMonitors {
+	return &repoMonitors{
+		cancels: make(map[string]context.CancelFunc),
+		ctxs:    make(map[string]context.Context),
+	}
+}
+
+// start returns the monitoring context of a repository, creating one derived from ctx if none is running
+func (m *repoMonitors) start(ctx context.Context, repoId string) context.Context {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if repoCtx, ok := m.ctxs[repoId]; ok && repoCtx.Err() == nil {
+		return repoCtx
+	}
+
+	repoCtx, cancel := context.WithCancel(ctx)
+	m.ctxs[repoId] = repoCtx
+	m.cancels[repoId] = cancel
+
+	return repoCtx
+}
+
+// stop cancels every goroutine running under the repository's monitoring context
+func (m *repoMonitors) stop(repoId string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cancel, ok := m.cancels[repoId]; ok {
+		cancel()
+	}
+	delete(m.cancels, repoId)
+	delete(m.ctxs, repoId)
+}
@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+// pullRequestsPerPage is the largest page size of the pull requests APIs
+const pullRequestsPerPage = 100
+
+// syncPullRequests stores the pull requests of a repository updated since the last complete sync and within its
+// tracking window, with their review count, and links the merged ones to the commits they introduced. Each page is
+// saved as soon as it is linked and the pull requests already stored with the same update date are skipped, so an
+// interrupted sync only fetches the pages it walked again. The checkpoint moves to the newest update date once the
+// walk completes.
+func (uc *gitRepoUsecase) syncPullRequests(ctx context.Context, repo domain.RepoMetadata) error {
+	syncedUntil, err := uc.pullRequestRepository.PullRequestsSyncedUntil(ctx, repo)
+	if err != nil {
+		return err
+	}
+	since, _ := repo.TrackingWindow()
+
+	var newest *time.Time
+	synced := 0
+	for page := 1; ; page++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		pullRequests, morePages, err := uc.gitClient.FetchPullRequests(ctx, repo, page, pullRequestsPerPage)
+		if err != nil {
+			return err
+		}
+		if newest == nil && len(pullRequests) > 0 {
+			newest = &pullRequests[0].UpdatedAt
+		}
+
+		numbers := make([]int, 0, len(pullRequests))
+		for _, pr := range pullRequests {
+			numbers = append(numbers, pr.Number)
+		}
+		stored, err := uc.pullRequestRepository.PullRequestUpdates(ctx, repo, numbers)
+		if err != nil {
+			return err
+		}
+
+		reachedSynced := false
+		var updated []domain.PullRequest
+		for _, pr := range pullRequests {
+			if (syncedUntil != nil && !pr.UpdatedAt.After(*syncedUntil)) || pr.UpdatedAt.Before(since) {
+				reachedSynced = true
+				break
+			}
+			if updatedAt, ok := stored[pr.Number]; ok && updatedAt.Equal(pr.UpdatedAt) {
+				continue
+			}
+
+			if pr.ReviewCount, err = uc.gitClient.CountPullRequestReviews(ctx, repo, pr.Number); err != nil {
+				return err
+			}
+			if err := uc.linkPullRequestCommits(ctx, repo, pr); err != nil {
+				return err
+			}
+			updated = append(updated, pr)
+		}
+
+		if err := uc.pullRequestRepository.SavePullRequests(ctx, updated); err != nil {
+			return err
+		}
+		synced += len(updated)
+
+		if reachedSynced || !morePages {
+			break
+		}
+	}
+
+	if newest != nil {
+		if err := uc.pullRequestRepository.SavePullRequestsSyncedUntil(ctx, repo, *newest); err != nil {
+			return err
+		}
+	}
+
+	if synced > 0 {
+		log.Info().Msgf("%d pull requests of repository %s synced", synced, repo.Name)
+	}
+	return nil
+}
+
+// linkPullRequestCommits links a merged pull request to its commits and to its merge commit, which is the only
+// commit a squashed or rebased pull request leaves on the base branch
+func (uc *gitRepoUsecase) linkPullRequestCommits(ctx context.Context, repo domain.RepoMetadata, pr domain.PullRequest) error {
+	if pr.State != domain.PullRequestMerged {
+		return nil
+	}
+
+	var shas []string
+	for page := 1; ; page++ {
+		commits, morePages, err := uc.gitClient.FetchPullRequestCommits(ctx, repo, pr.Number, page, pullRequestsPerPage)
+		if err != nil {
+			return err
+		}
+		shas = append(shas, commits...)
+
+		if !morePages {
+			break
+		}
+	}
+	if pr.MergeCommitSHA != "" {
+		shas = append(shas, pr.MergeCommitSHA)
+	}
+
+	return uc.pullRequestRepository.SavePullRequestCommits(ctx, repo, pr.Number, shas)
+}
@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"context"
+	"slices"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+// releasesPerPage is the largest page size of the tags and releases APIs
+const releasesPerPage = 100
+
+// syncReleases stores the tags and releases of a repository, then records the earliest release containing each
+// unreleased commit of the repository. Tags and releases are listed newest first, so each listing stops at the first
+// page holding a stored one. The unreleased commits are walked from the tags of every release, so that commits stored
+// after their release was synced, eg older commits backfilled or commits of a newly tracked branch, are released too.
+func (uc *gitRepoUsecase) syncReleases(ctx context.Context, repo domain.RepoMetadata) error {
+	storedTags, err := uc.releaseRepository.TagsByRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	tagSHAs := make(map[string]string, len(storedTags))
+	for _, t := range storedTags {
+		tagSHAs[t.Name] = t.SHA
+	}
+
+	for page := 1; ; page++ {
+		tags, morePages, err := uc.gitClient.FetchTags(ctx, repo, page, releasesPerPage)
+		if err != nil {
+			return err
+		}
+		if err := uc.releaseRepository.SaveTags(ctx, tags); err != nil {
+			return err
+		}
+
+		if !morePages || slices.ContainsFunc(tags, func(t domain.Tag) bool { return tagSHAs[t.Name] == t.SHA }) {
+			break
+		}
+	}
+
+	stored, err := uc.releaseRepository.ReleasesByRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+	storedReleases := make(map[string]bool, len(stored))
+	for _, r := range stored {
+		storedReleases[r.Tag] = true
+	}
+
+	for page := 1; ; page++ {
+		releases, morePages, err := uc.gitClient.FetchReleases(ctx, repo, page, releasesPerPage)
+		if err != nil {
+			return err
+		}
+		if err := uc.releaseRepository.SaveReleases(ctx, releases); err != nil {
+			return err
+		}
+
+		if !morePages || slices.ContainsFunc(releases, func(r domain.Release) bool { return storedReleases[r.Tag] }) {
+			break
+		}
+	}
+
+	releases, err := uc.releaseRepository.ReleasesByRepository(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	// drafts and releases whose tag is not ingested yet are synced once they are published and tagged
+	var pendingTags []string
+	for _, r := range releases {
+		if r.CommitsSynced || r.Draft || r.PublishedAt == nil || r.TagSHA == "" {
+			continue
+		}
+		pendingTags = append(pendingTags, r.Tag)
+	}
+
+	commits, err := uc.commitRepository.UnreleasedCommits(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 && len(pendingTags) == 0 {
+		return nil
+	}
+	releasedChildren, err := uc.commitRepository.ReleasedChildren(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	firstReleases := domain.FirstReleases(commits, releases, releasedChildren)
+	if len(firstReleases) == 0 && len(pendingTags) == 0 {
+		return nil
+	}
+	if err := uc.releaseRepository.UpdateFirstReleases(ctx, repo, firstReleases, pendingTags); err != nil {
+		return err
+	}
+
+	log.Info().Msgf("%d new releases of repository %s synced, %d of %d unreleased commits released", len(pendingTags), repo.Name, len(firstReleases), len(commits))
+	return nil
+}
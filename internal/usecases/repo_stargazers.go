@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/kenmobility/git-api-service/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// stargazersPerPage is the largest page size of the stargazers API
+	stargazersPerPage = 100
+	// stargazersMaxPages is the last page listed by the stargazers API, the stars of larger repositories
+	// are only known up to their first 40000 stargazers
+	stargazersMaxPages = 400
+)
+
+// StarHistory returns the stars given to a repository per week since its first star and the number
+// of stars it had at the end of every week, the history is partial while fewer stars are stored than
+// the repository has upstream
+func (uc *gitRepoUsecase) StarHistory(ctx context.Context, repoId string) (*domain.StarHistory, error) {
+	repo, err := uc.repoMetadataRepository.RepoMetadataByPublicId(ctx, repoId)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := uc.stargazerRepository.WeeklyStars(ctx, *repo)
+	if err != nil {
+		return nil, err
+	}
+
+	weekly := domain.WeeklyStars(counts, time.Now())
+	cumulative := domain.CumulativeStars(weekly)
+	history := &domain.StarHistory{Weekly: weekly, Cumulative: cumulative, StarsCount: repo.StarsCount}
+	if len(cumulative) > 0 {
+		history.Total = cumulative[len(cumulative)-1].Stars
+	}
+	history.Partial = history.Total < repo.StarsCount
+	return history, nil
+}
+
+// FastestGrowing ranks the tracked repositories by the stars they gained since a date
+func (uc *gitRepoUsecase) FastestGrowing(ctx context.Context, since time.Time, limit int) ([]domain.StarGrowth, error) {
+	return uc.stargazerRepository.FastestGrowingRepos(ctx, since, limit)
+}
+
+// syncStargazers stores the stargazers of a repository added since its last sync. Stars are listed oldest first,
+// so fetching resumes from the page of the first unstored star, one page earlier to pick up the stars moved back
+// by removed stars; the stars already stored are skipped. More stored stars than the repository has upstream
+// means stars were removed, they are reconciled against a full listing of the stargazers.
+func (uc *gitRepoUsecase) syncStargazers(ctx context.Context, repo domain.RepoMetadata) (int, error) {
+	stored, err := uc.stargazerRepository.StargazerCount(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+
+	saved := 0
+	for page := max(1, stored/stargazersPerPage); ; page++ {
+		if page > stargazersMaxPages {
+			log.Warn().Msgf("stargazers of repository %s listed up to the %d pages listed by the git provider", repo.Name, stargazersMaxPages)
+			break
+		}
+		if ctx.Err() != nil {
+			return saved, ctx.Err()
+		}
+
+		stargazers, morePages, err := uc.gitClient.FetchStargazers(ctx, repo, page, stargazersPerPage)
+		if err != nil {
+			return saved, err
+		}
+
+		n, err := uc.stargazerRepository.SaveStargazers(ctx, stargazers)
+		if err != nil {
+			return saved, err
+		}
+		saved += n
+
+		if !morePages {
+			break
+		}
+	}
+
+	if saved > 0 {
+		log.Info().Msgf("%d stargazers of repository %s saved", saved, repo.Name)
+	}
+
+	if stored+saved > repo.StarsCount {
+		removed, err := uc.removeUnstarred(ctx, repo)
+		if err != nil {
+			return saved, err
+		}
+		if removed > 0 {
+			log.Info().Msgf("%d removed stars of repository %s deleted", removed, repo.Name)
+		}
+	}
+	return saved, nil
+}
+
+// removeUnstarred lists all the stargazers of a repository and deletes the stored stars no longer listed, when
+// the listing stops at the page limit only the stars given up to the last listed star are reconciled
+func (uc *gitRepoUsecase) removeUnstarred(ctx context.Context, repo domain.RepoMetadata) (int, error) {
+	logins := make([]string, 0, repo.StarsCount)
+	until := time.Now()
+	for page := 1; ; page++ {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+
+		stargazers, morePages, err := uc.gitClient.FetchStargazers(ctx, repo, page, stargazersPerPage)
+		if err != nil {
+			return 0, err
+		}
+		for _, sg := range stargazers {
+			logins = append(logins, sg.Login)
+		}
+
+		if !morePages {
+			break
+		}
+		if page == stargazersMaxPages {
+			if len(stargazers) == 0 {
+				return 0, nil
+			}
+			until = stargazers[len(stargazers)-1].StarredAt
+			break
+		}
+	}
+
+	return uc.stargazerRepository.DeleteStargazersExcept(ctx, repo, logins, until)
+}
@@ -9,9 +9,38 @@ var (
 	ErrResolvingRepositoryName  = errors.New("no repository meta data was found with specified name")
 	ErrDefaultRepoAlreadySeeded = errors.New("default repo already seeded")
 	ErrRepoAlreadyAdded         = errors.New("repository is already added")
+	ErrRepoFetchInProgress      = errors.New("repository commits are currently being fetched, try again later")
+	ErrCommitNotFound           = errors.New("commit not found in any tracked repository")
+	ErrCommitAlreadySaved       = errors.New("commit is already saved for the repository")
+	ErrRepoGone                 = errors.New("repository not found upstream, it was deleted, made private or taken down")
+	ErrUnknownRef               = errors.New("branch or commit not found upstream")
+	ErrCommitDetailUnavailable  = errors.New("commit detail not available upstream")
+	ErrInvalidUpstreamState     = errors.New("invalid state, it must be one of active, archived or gone")
+	ErrInvalidOwnerName         = errors.New("invalid owner, it must be an organization or user name")
+	ErrOwnerNotFound            = errors.New("no organization or user was found with specified name")
+	ErrInvalidNamePattern       = errors.New("invalid name_pattern, it must be a valid regular expression")
+	ErrRepoNotVerified          = errors.New("repository history has not been verified yet")
+	ErrRepoNotReindexed         = errors.New("repository has not been reindexed yet")
+	ErrOrgImportInProgress      = errors.New("repositories of this organization or user are currently being imported, try again later")
+	ErrOrgNotImported           = errors.New("organization or user has not been imported yet")
 
-	ErrRepoMetaDataNotFetched = errors.New("repository metadata not fetched, ensure repository is valid and public")
-	ErrInvalidRepositoryName  = errors.New("invalid repository name, eg format is {owner/repositoryName}")
+	ErrRepoMetaDataNotFetched  = errors.New("repository metadata not fetched, ensure repository is valid and public")
+	ErrInvalidRepositoryName   = errors.New("invalid repository name, eg format is {owner/repositoryName}")
+	ErrInvalidTrackingWindow   = errors.New("invalid tracking window, since must be before until")
+	ErrInvalidFetchInterval    = errors.New("invalid fetch interval, it must be at least one minute")
+	ErrInvalidCommitsPerPage   = errors.New("invalid per_page, it must be between 1 and 100")
+	ErrInvalidHistoryWindow    = errors.New("invalid history window, from must be before to")
+	ErrInvalidInterval         = errors.New("invalid interval, it must be one of hour, day, week or month")
+	ErrInvalidBranchPattern    = errors.New("invalid branches, each branch must be a name or a glob pattern eg release/*")
+	ErrBranchNotTracked        = errors.New("branch is not tracked for this repository")
+	ErrReleaseNotFound         = errors.New("no release was found with specified tag for this repository")
+	ErrInvalidPullRequestState = errors.New("invalid state, it must be one of open, closed or merged")
+	ErrInvalidIssueNumber      = errors.New("invalid issue number, it must be a positive integer")
+	ErrEnrichmentYielded       = errors.New("commits enrichment paused while commits are being indexed")
+	ErrInvalidHotspotSort      = errors.New("invalid sort, it must be one of score, changes, churn, authors or recency")
+	ErrPathRequired            = errors.New("path is required")
+	ErrContributorNotFound     = errors.New("no contributor was found with specified id or author key")
+	ErrInvalidAuthorKey        = errors.New("invalid author key, eg format is contributor:{id}, login:{login}, email:{email} or name:{name}")
 
 	ErrRateLimitExceeded = errors.New("rate limit exceeded")
 	ErrContextCancelled  = errors.New("context cancelled")